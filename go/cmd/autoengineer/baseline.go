@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/liam-witterick/autoengineer/go/internal/baseline"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/gate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagBaselineFrom   string
+	flagBaselineOutput string
+)
+
+// newBaselineCmd builds the `baseline` command group
+func newBaselineCmd() *cobra.Command {
+	baselineCmd := &cobra.Command{
+		Use:   "baseline",
+		Short: "Manage the baseline of findings that already exist",
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create [-- scan flags]",
+		Short: "Snapshot current findings into a baseline file",
+		Long: `Snapshot current findings into a baseline file to commit to the repository.
+
+Later runs compare their findings to the baseline by fingerprint, mark each
+one new or existing, and report baseline findings that are gone as fixed.
+Use --new-only to show and action only new findings.
+
+Without --from, a scan is run first (with --scan-only). Flags after -- are
+passed to it, e.g.:
+
+  autoengineer baseline create -- --no-scanners`,
+		RunE: runBaselineCreate,
+	}
+	createCmd.Flags().StringVar(&flagBaselineFrom, "from", "", "Create the baseline from a saved findings file instead of running a scan")
+	createCmd.Flags().StringVar(&flagBaselineOutput, "output", baseline.DefaultPath, "Baseline file to write")
+
+	baselineCmd.AddCommand(createCmd)
+	return baselineCmd
+}
+
+func runBaselineCreate(cmd *cobra.Command, args []string) error {
+	source := flagBaselineFrom
+	if source == "" {
+		tmp, err := os.MkdirTemp("", "autoengineer-baseline-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		source = filepath.Join(tmp, "findings.json")
+
+		if err := scanForBaseline(source, args); err != nil {
+			return err
+		}
+	}
+
+	all, err := findings.LoadFile(source)
+	if err != nil {
+		return err
+	}
+
+	b := baseline.Create(all)
+	if err := b.Save(flagBaselineOutput); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	fmt.Printf("\n📏 Baselined %d finding(s) in %s\n", len(b.Entries), flagBaselineOutput)
+	fmt.Println("   Commit this file; later runs mark findings not in it as new.")
+	return nil
+}
+
+// scanForBaseline runs a full scan in a subprocess, saving findings to output.
+// The existing baseline is not applied so every current finding is captured.
+func scanForBaseline(output string, args []string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate autoengineer binary: %w", err)
+	}
+
+	scanArgs := append([]string{"--scan-only", "--output", output, "--baseline", ""}, args...)
+	scanCmd := exec.Command(self, scanArgs...)
+	scanCmd.Stdout = os.Stdout
+	scanCmd.Stderr = os.Stderr
+	if err := scanCmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fmt.Errorf("scan failed: %w", err)
+		}
+		switch exitErr.ExitCode() {
+		case gate.ExitFindings:
+		case gate.ExitScannerFailed:
+			fmt.Println("⚠️  A scanner failed; the baseline may be missing its findings")
+		default:
+			return fmt.Errorf("scan failed (exit code %d)", exitErr.ExitCode())
+		}
+	}
+	return nil
+}
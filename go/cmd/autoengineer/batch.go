@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/liam-witterick/autoengineer/go/internal/batch"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/gate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagBatchRepos       string
+	flagBatchDir         string
+	flagBatchConcurrency int
+	flagBatchOutputDir   string
+)
+
+// summaryFile is the roll-up written to the batch output directory
+const summaryFile = "summary.json"
+
+// newBatchCmd builds the `batch` command for scanning many local checkouts
+func newBatchCmd() *cobra.Command {
+	batchCmd := &cobra.Command{
+		Use:   "batch [-- scan flags]",
+		Short: "Scan many local repository checkouts and roll up their findings",
+		Long: `Scan many local repository checkouts with bounded concurrency.
+
+Each repository is scanned with --scan-only in its own directory, using its own
+.github/autoengineer*.yaml. Findings are written to <output-dir>/<repo>.json,
+scan output to <output-dir>/<repo>.log and the fleet roll-up to
+<output-dir>/` + summaryFile + `.
+
+Flags after -- are passed to every scan, e.g.:
+
+  autoengineer batch --repos repos.yaml -- --no-scanners --scope security`,
+		RunE: runBatch,
+	}
+	batchCmd.Flags().StringVar(&flagBatchRepos, "repos", "", "YAML file listing the checkouts to scan")
+	batchCmd.Flags().StringVar(&flagBatchDir, "dir", "", "Scan every git checkout directly inside this directory")
+	batchCmd.Flags().IntVar(&flagBatchConcurrency, "concurrency", 0, fmt.Sprintf("Maximum number of repositories scanned at once (default %d, or concurrency: in the repos file)", batch.DefaultConcurrency))
+	batchCmd.Flags().StringVar(&flagBatchOutputDir, "output-dir", "./autoengineer-batch", "Directory for per-repo findings, logs and the roll-up summary")
+	return batchCmd
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	if flagBatchRepos == "" && flagBatchDir == "" {
+		return fmt.Errorf("batch requires --repos or --dir")
+	}
+
+	cfg := &batch.Config{}
+	if flagBatchRepos != "" {
+		loaded, err := batch.LoadConfig(flagBatchRepos)
+		if err != nil {
+			return err
+		}
+		cfg = loaded
+	}
+	if flagBatchDir != "" {
+		dir, err := filepath.Abs(flagBatchDir)
+		if err != nil {
+			return err
+		}
+		cfg.Dirs = append(cfg.Dirs, dir)
+	}
+	if flagBatchConcurrency > 0 {
+		cfg.Concurrency = flagBatchConcurrency
+	}
+
+	repos, err := cfg.ResolveRepos()
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("no repositories to scan")
+	}
+
+	outputDir, err := filepath.Abs(flagBatchOutputDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate autoengineer binary: %w", err)
+	}
+
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = batch.DefaultConcurrency
+	}
+	fmt.Printf("\n📚 Scanning %d repositories, up to %d at a time...\n\n", len(repos), concurrency)
+
+	// Each scan runs in its own process so it sees its repository's working directory
+	scan := func(ctx context.Context, repo batch.Repo, output string) error {
+		scanArgs := append([]string{"--scan-only", "--output", output}, cfg.Args...)
+		scanArgs = append(scanArgs, repo.Args...)
+		scanArgs = append(scanArgs, args...)
+
+		logPath := filepath.Join(outputDir, repo.Name+".log")
+		logFile, err := os.Create(logPath)
+		if err != nil {
+			return fmt.Errorf("failed to create log: %w", err)
+		}
+		defer logFile.Close()
+
+		scanCmd := exec.CommandContext(ctx, self, scanArgs...)
+		scanCmd.Dir = repo.Path
+		scanCmd.Stdout = logFile
+		scanCmd.Stderr = logFile
+		if err := scanCmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && (exitErr.ExitCode() == gate.ExitFindings || exitErr.ExitCode() == gate.ExitScannerFailed) {
+				return &gate.ExitError{Code: exitErr.ExitCode(), Err: fmt.Errorf("gate failed, see %s", logPath)}
+			}
+			return fmt.Errorf("scan failed (%v), see %s", err, logPath)
+		}
+		return nil
+	}
+
+	var mu sync.Mutex
+	results := batch.Run(context.Background(), repos, concurrency, outputDir, scan, func(r batch.Result) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Failed() {
+			fmt.Printf("   ❌ %s: %s\n", r.Name, r.Error)
+		} else if r.ExitCode != gate.ExitOK {
+			fmt.Printf("   🚫 %s: %d finding(s), exit code %d\n", r.Name, r.Counts.Total, r.ExitCode)
+		} else {
+			fmt.Printf("   ✅ %s: %d finding(s)\n", r.Name, r.Counts.Total)
+		}
+	})
+
+	summary := batch.Summarize(results)
+	displayBatchSummary(summary)
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	summaryPath := filepath.Join(outputDir, summaryFile)
+	if err := os.WriteFile(summaryPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	fmt.Printf("\n💾 Findings and summary saved to %s\n", outputDir)
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed to scan", summary.Failed, len(results))
+	}
+	if code := summary.ExitCode(); code != gate.ExitOK {
+		cmd.SilenceUsage = true
+		return &gate.ExitError{Code: code, Err: fmt.Errorf("one or more repositories failed their gate (exit code %d)", code)}
+	}
+	return nil
+}
+
+// displayBatchSummary prints per-repo counts and fleet totals by severity and category
+func displayBatchSummary(summary batch.Summary) {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("📊 FLEET SUMMARY")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	fmt.Printf("%-28s %8s %6s %7s %5s %5s %6s\n", "REPOSITORY", "CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO", "TOTAL")
+	for _, r := range summary.Repos {
+		if r.Failed() {
+			fmt.Printf("%-28s %s\n", r.Name, "❌ failed")
+			continue
+		}
+		printSeverityRow(r.Name, r.Counts)
+	}
+
+	totals := summary.Totals
+	printSeverityRow("TOTAL", totals)
+
+	if categories := totals.Categories(); len(categories) > 0 {
+		fmt.Println()
+		for _, name := range categories {
+			fmt.Printf("%s %-16s%d finding(s)\n", findings.CategoryEmoji(name), name, totals.Category[name])
+		}
+	}
+
+	if summary.Failed > 0 {
+		fmt.Printf("\n⚠️  %d repository scan(s) failed - see the .log files for details\n", summary.Failed)
+	}
+}
+
+// printSeverityRow prints a fleet summary row of counts by severity
+func printSeverityRow(name string, c batch.Counts) {
+	fmt.Printf("%-28s %8d %6d %7d %5d %5d %6d\n", name,
+		c.Severity[findings.SeverityCritical], c.Severity[findings.SeverityHigh], c.Severity[findings.SeverityMedium],
+		c.Severity[findings.SeverityLow], c.Severity[findings.SeverityInfo], c.Total)
+}
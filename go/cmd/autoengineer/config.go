@@ -0,0 +1,271 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// newConfigCmd builds the `config` command group
+func newConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the repository's autoengineer configuration",
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration",
+		Args:  cobra.NoArgs,
+		RunE:  runConfigShow,
+	}
+
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration files for unknown keys, invalid values and malformed globs",
+		Long: `Check .github/autoengineer.yaml, .github/autoengineer-ignore.yaml and nested
+project autoengineer.yaml files. Each file is decoded strictly and checked
+against its JSON Schema (see 'autoengineer config schema'), then scope names,
+scanner names and globs are checked. Problems are reported as file:line:column.
+
+Scans run the same validation at startup and stop on any problem.`,
+		Args: cobra.NoArgs,
+		RunE: runConfigValidate,
+	}
+
+	schemaCmd := &cobra.Command{
+		Use:       "schema [config|ignore|project]",
+		Short:     "Print the JSON Schema of a configuration file",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: config.SchemaKinds,
+		RunE:      runConfigSchema,
+	}
+
+	configCmd.AddCommand(showCmd)
+	configCmd.AddCommand(withoutSettings(validateCmd))
+	configCmd.AddCommand(withoutSettings(schemaCmd))
+	return configCmd
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	files := listRepoFiles()
+	var invalid *config.InvalidError
+	if err := config.Validate(files); errors.As(err, &invalid) {
+		for _, p := range invalid.Problems {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", p)
+		}
+	}
+
+	bases, prov, err := config.LoadProvenance()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	ignoreCfg, err := config.LoadIgnoreConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load ignore config: %w", err)
+	}
+	scannerCfg, err := config.LoadScannerConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load scanner config: %w", err)
+	}
+	llmCfg, err := config.LoadLLMConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load llm config: %w", err)
+	}
+	verifyCfg, err := config.LoadVerificationConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load verification config: %w", err)
+	}
+	scopes, err := config.LoadScopeConfigs()
+	if err != nil {
+		return fmt.Errorf("failed to load scopes: %w", err)
+	}
+	overrides, err := config.LoadSeverityOverrides()
+	if err != nil {
+		return fmt.Errorf("failed to load severity overrides: %w", err)
+	}
+	projects, err := config.LoadProjects(ignoreCfg, files)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	// Fill in the defaults the loaders apply lazily
+	llmCfg.Backend = llmCfg.BackendName()
+	if llmCfg.Backend == config.LLMBackendOpenAI {
+		llmCfg.BaseURL = llmCfg.EffectiveBaseURL()
+	}
+	verifyCfg.Mode = verifyCfg.EffectiveMode()
+
+	heading := "# .github/autoengineer.yaml (each value is followed by the file it came from)"
+	if len(bases) > 0 {
+		heading += "\n# extends, applied in order before the file itself: " + strings.Join(bases, ", ")
+	}
+	main := config.FullConfig{Scanners: scannerCfg, LLM: llmCfg, Verification: verifyCfg, Scopes: scopes, SeverityOverrides: overrides}
+	err = printSourcedYAML(heading, main, func(path, item string) string {
+		source := prov.Source(path)
+		if item != "" {
+			source = prov.Item(path, item)
+		}
+		if source == "" {
+			return "default"
+		}
+		return source
+	})
+	if err != nil {
+		return err
+	}
+	if err := printSettings(cmd, prov); err != nil {
+		return err
+	}
+	ignorePath := config.IgnoreFilePath()
+	ignoreHeading := "# " + ignorePath
+	if ignorePath == "" {
+		ignorePath = "default"
+		ignoreHeading = "# .github/autoengineer-ignore.yaml (not found)"
+	}
+	err = printSourcedYAML(ignoreHeading, ignoreCfg, func(path, item string) string {
+		// Items shared through extends are recorded under the ignore section
+		if source := prov.Item("ignore."+path, item); item != "" && source != "" {
+			return source
+		}
+		return ignorePath
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range projects.All() {
+		if err := printYAML(fmt.Sprintf("# %s (project %s, with inherited settings)", p.ConfigPath, p.Name), p.Ignore); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	err := config.Validate(listRepoFiles())
+	var invalid *config.InvalidError
+	if errors.As(err, &invalid) {
+		for _, p := range invalid.Problems {
+			fmt.Printf("❌ %s\n", p)
+		}
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d configuration problem(s)", len(invalid.Problems))
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println("✅ Configuration is valid")
+	return nil
+}
+
+func runConfigSchema(cmd *cobra.Command, args []string) error {
+	kind := config.KindConfig
+	if len(args) > 0 {
+		kind = args[0]
+	}
+	data := config.Schema(kind)
+	if data == nil {
+		return fmt.Errorf("unknown schema: %s (must be %s)", kind, strings.Join(config.SchemaKinds, ", "))
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+// printYAML prints a heading comment and v as YAML
+func printYAML(heading string, v interface{}) error {
+	var sb strings.Builder
+	enc := yaml.NewEncoder(&sb)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	fmt.Println(heading)
+	fmt.Println(sb.String())
+	return nil
+}
+
+// printSourcedYAML prints v like printYAML, with a comment after each value
+// naming where it came from. source is given the dotted path of a value and,
+// for list items, the item's key (see config.ItemKey).
+func printSourcedYAML(heading string, v interface{}, source func(path, item string) string) error {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return err
+	}
+	annotateSources(&node, "", source)
+	return printYAML(heading, &node)
+}
+
+// annotateSources sets the line comment of each value below n to its source
+func annotateSources(n *yaml.Node, path string, source func(path, item string) string) {
+	if n.Kind == yaml.DocumentNode {
+		for _, c := range n.Content {
+			annotateSources(c, path, source)
+		}
+		return
+	}
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		p := key.Value
+		if path != "" {
+			p = path + "." + key.Value
+		}
+
+		switch {
+		case value.Kind == yaml.MappingNode && len(value.Content) > 0:
+			annotateSources(value, p, source)
+		case value.Kind == yaml.SequenceNode && len(value.Content) > 0:
+			for _, item := range value.Content {
+				// Mapping items are commented on their first line
+				target, name := item, item.Value
+				if item.Kind == yaml.MappingNode && len(item.Content) > 1 {
+					var v interface{}
+					if err := item.Decode(&v); err == nil {
+						name = config.ItemKey(p, v)
+					}
+					target = item.Content[1]
+				}
+				target.LineComment = source(p, name)
+			}
+		default:
+			value.LineComment = source(p, "")
+		}
+	}
+}
+
+// printSettings prints the effective scan settings and where each comes from,
+// naming the file of settings from the defaults or a profile
+func printSettings(cmd *cobra.Command, prov config.Provenance) error {
+	resolved, err := resolveSettings(cmd.Root(), false)
+	if err != nil {
+		return err
+	}
+
+	heading := "# settings (flags > AUTOENGINEER_* environment > profile > defaults)"
+	if flagProfile != "" {
+		heading += ", profile " + flagProfile
+	}
+	fmt.Println(heading)
+	for _, r := range resolved {
+		name := config.SettingName(r.Flag)
+		source := r.Source
+		if r.Source == sourceDefaults {
+			source += " in " + prov.Source("defaults."+name)
+		} else if profile, ok := strings.CutPrefix(r.Source, sourceProfile+" "); ok {
+			source += " in " + prov.Source("profiles."+profile+"."+name)
+		}
+		fmt.Printf("%s: %q  # %s\n", name, r.Value, source)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/issues"
+	"github.com/spf13/cobra"
+)
+
+// newDelegateCmd builds the `delegate` command
+func newDelegateCmd() *cobra.Command {
+	delegateCmd := &cobra.Command{
+		Use:   "delegate [issue numbers...]",
+		Short: "Delegate tracked issues to Copilot coding agent",
+		Long: `Delegate issues to Copilot coding agent, which opens a pull request for each.
+
+With issue numbers, those issues are delegated. Without, the open issues
+tracking the findings in the findings file are delegated. Issues that are
+already delegated are skipped.`,
+		RunE: runDelegate,
+	}
+	delegateCmd.Flags().StringVar(&flagFindingsFile, "findings", defaultFindingsFile, "Findings file whose tracked issues are delegated when no issue numbers are given")
+	delegateCmd.Flags().StringVar(&flagMinSeverity, "min-severity", "info", "Only delegate issues for findings at or above this severity level (info|low|medium|high|critical)")
+	delegateCmd.Flags().StringVar(&flagFilter, "filter", "", `Only delegate issues for findings matching this expression, e.g. 'severity >= medium && file =~ "^modules/"'`)
+	delegateCmd.Flags().BoolVar(&flagNewOnly, "new-only", false, "Only delegate issues for findings the scan marked new relative to the baseline")
+	return withSettings(delegateCmd)
+}
+
+func runDelegate(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if len(args) > 0 {
+		issueNums := make([]int, 0, len(args))
+		for _, arg := range args {
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid issue number: %s", arg)
+			}
+			issueNums = append(issueNums, n)
+		}
+		return delegateIssues(ctx, issueNums)
+	}
+
+	selected, err := loadSelectedFindings()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\n🔍 Fetching existing tracked issues...")
+	t, err := newTracker()
+	if err != nil {
+		return err
+	}
+	existingIssues := t.fetchExisting(ctx)
+
+	var issueNums []int
+	untracked := 0
+	for _, f := range selected {
+		issue, ok := issues.TrackingIssue(existingIssues, f)
+		if !ok {
+			untracked++
+			continue
+		}
+		issueNums = append(issueNums, issue.Number)
+	}
+
+	if untracked > 0 {
+		fmt.Printf("   %d finding(s) have no open issue (create them with: autoengineer issues create)\n", untracked)
+	}
+	if len(issueNums) == 0 {
+		fmt.Println("✅ No tracked issues to delegate")
+		return nil
+	}
+	return delegateIssues(ctx, issueNums)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/spf13/cobra"
+)
+
+// Output formats of diff and report
+const (
+	formatText     = "text"
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+)
+
+// validateFormat checks an output format flag
+func validateFormat(format string) error {
+	if format != formatText && format != formatJSON && format != formatMarkdown {
+		return fmt.Errorf("invalid --format: %s (must be text, json, or markdown)", format)
+	}
+	return nil
+}
+
+var flagDiffFormat string
+
+// newDiffCmd builds the `diff` command for comparing two findings files
+func newDiffCmd() *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff <before.json> <after.json>",
+		Short: "Compare two findings files",
+		Long: `Compare two findings files, e.g. a main-branch scan and a pull request scan.
+
+Findings are paired by fingerprint (category, normalized title and files), then
+by the similarity used to merge duplicate findings. Unpaired findings are
+reported as added or resolved, and paired ones whose severity or files differ
+as changed.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runDiff,
+	}
+	diffCmd.Flags().StringVar(&flagDiffFormat, "format", formatText, "Output format (text|json|markdown)")
+	return diffCmd
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(flagDiffFormat); err != nil {
+		return err
+	}
+
+	before, err := findings.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+	after, err := findings.LoadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	d := findings.DiffFindings(before, after)
+
+	switch flagDiffFormat {
+	case formatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	case formatMarkdown:
+		findings.WriteDiffMarkdown(os.Stdout, d)
+	default:
+		findings.WriteDiffText(os.Stdout, d)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+	"github.com/liam-witterick/autoengineer/go/internal/scanner"
+	"github.com/liam-witterick/autoengineer/go/internal/setup"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagInitYes           bool
+	flagInitBackend       string
+	flagInitBaseURL       string
+	flagInitModel         string
+	flagInitDisableScopes []string
+	flagInitIgnorePaths   []string
+	flagInitWorkflow      bool
+	flagInitSchedule      string
+	flagInitCreateIssues  bool
+	flagInitMinSeverity   string
+	flagInitForce         bool
+)
+
+// newInitCmd builds the `init` command
+func newInitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create commented configuration files and an optional scheduled workflow",
+		Long: `Create .github/autoengineer.yaml, .github/autoengineer-ignore.yaml and
+.github/copilot-instructions.md, plus an optional scheduled GitHub Actions
+workflow that runs autoengineer.
+
+The repository is inspected first (technologies, installed scanners and
+existing labels) and a few questions are asked. Every question has a flag;
+questions whose flag is set are not asked, and --yes accepts the defaults
+for the rest, e.g.:
+
+  autoengineer init --yes --workflow --create-issues --min-severity high
+
+Existing files are kept unless --force is set.`,
+		Args: cobra.NoArgs,
+		RunE: runInit,
+	}
+
+	cmd.Flags().BoolVarP(&flagInitYes, "yes", "y", false, "Don't ask questions; use flags and defaults")
+	cmd.Flags().StringVar(&flagInitBackend, "backend", config.LLMBackendCopilot, "LLM backend (copilot|openai)")
+	cmd.Flags().StringVar(&flagInitBaseURL, "base-url", "", "API root for the openai backend, e.g. http://localhost:11434/v1")
+	cmd.Flags().StringVar(&flagInitModel, "model", "", "Model for the openai backend")
+	cmd.Flags().StringSliceVar(&flagInitDisableScopes, "disable-scope", nil, "Scope to disable (repeatable)")
+	cmd.Flags().StringSliceVar(&flagInitIgnorePaths, "ignore-path", nil, "Path glob to ignore (repeatable; defaults to detected example, vendor and fixture directories)")
+	cmd.Flags().BoolVar(&flagInitWorkflow, "workflow", false, "Add a scheduled GitHub Actions workflow")
+	cmd.Flags().StringVar(&flagInitSchedule, "schedule", setup.DefaultSchedule, "Cron schedule for the workflow")
+	cmd.Flags().BoolVar(&flagInitCreateIssues, "create-issues", false, "Make the workflow create issues instead of only saving findings")
+	cmd.Flags().StringVar(&flagInitMinSeverity, "min-severity", findings.SeverityInfo, "Lowest severity the workflow creates issues for (info|low|medium|high|critical)")
+	cmd.Flags().BoolVar(&flagInitForce, "force", false, "Overwrite existing files")
+	return withoutSettings(cmd)
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	fmt.Println("\n🧰 Initializing AutoEngineer configuration...")
+
+	files := listRepoFiles()
+	inv := inventory.Detect(".", files)
+	displayInventory(inv)
+
+	// Scanners are detected without a config so disabled ones are still listed
+	mgr := scanner.NewManager(&config.ScannerConfig{})
+	if len(files) > 0 {
+		mgr.SetInventory(inv)
+	}
+	fmt.Println("\n🔍 Local scanners:")
+	var scanners []setup.Scanner
+	for _, status := range mgr.DetectScanners() {
+		if status.Type != scanner.TypeLocal {
+			continue
+		}
+		scanners = append(scanners, setup.Scanner{Name: status.Name, Installed: status.Installed})
+		if status.Installed {
+			fmt.Printf("   ✅ %s (%s)\n", status.Name, status.Version)
+		} else {
+			fmt.Printf("   ⏭️  %s (not installed)\n", status.Name)
+		}
+	}
+
+	labels := fetchLabels(cmd.Context())
+
+	opts := setup.Options{
+		Technologies: inv.Technologies(),
+		Scanners:     scanners,
+		Schedule:     flagInitSchedule,
+	}
+
+	p := &prompter{reader: bufio.NewReader(os.Stdin), flags: cmd, yes: flagInitYes}
+	fmt.Println()
+
+	opts.Backend = p.ask("backend", "LLM backend (copilot/openai)", flagInitBackend)
+	if opts.Backend != config.LLMBackendCopilot && opts.Backend != config.LLMBackendOpenAI {
+		return fmt.Errorf("invalid backend: %s (must be %s or %s)", opts.Backend, config.LLMBackendCopilot, config.LLMBackendOpenAI)
+	}
+	if opts.Backend == config.LLMBackendOpenAI {
+		opts.BaseURL = p.ask("base-url", "API base URL (blank for api.openai.com)", flagInitBaseURL)
+		opts.Model = p.ask("model", "Model", flagInitModel)
+		if opts.Model == "" {
+			return fmt.Errorf("a model is required for the %s backend (use --model)", config.LLMBackendOpenAI)
+		}
+	}
+
+	opts.Label = p.ask("label", "Issue label", flagLabel)
+	if opts.Label == "" {
+		opts.Label = setup.DefaultLabel
+	}
+	for _, l := range labels {
+		if strings.EqualFold(l, opts.Label) {
+			fmt.Printf("   ℹ️  Label %q already exists and will be reused\n", l)
+			break
+		}
+	}
+
+	opts.IgnorePaths = flagInitIgnorePaths
+	if !cmd.Flags().Changed("ignore-path") {
+		if suggested := setup.SuggestIgnorePaths(files); len(suggested) > 0 {
+			if p.confirm("", fmt.Sprintf("Ignore %s", strings.Join(suggested, ", ")), true) {
+				opts.IgnorePaths = suggested
+			}
+		}
+	}
+
+	registry, err := loadScopeRegistry()
+	if err != nil {
+		return err
+	}
+	disabled := p.ask("disable-scope", fmt.Sprintf("Scopes to disable (%s; comma-separated, blank for none)", strings.Join(registry.Names(), ", ")), strings.Join(flagInitDisableScopes, ","))
+	for _, name := range splitList(disabled) {
+		if _, ok := registry.Get(name); !ok {
+			return fmt.Errorf("unknown scope: %s (must be one of %s)", name, strings.Join(registry.Names(), ", "))
+		}
+		opts.DisabledScopes = append(opts.DisabledScopes, name)
+	}
+
+	opts.Workflow = p.confirm("workflow", "Add a scheduled GitHub Actions workflow", flagInitWorkflow)
+	if opts.Workflow {
+		opts.Schedule = p.ask("schedule", "Cron schedule", flagInitSchedule)
+		opts.CreateIssues = p.confirm("create-issues", "Create issues from scheduled runs (otherwise only save findings)", flagInitCreateIssues)
+		if opts.CreateIssues {
+			opts.MinSeverity = p.ask("min-severity", "Lowest severity to create issues for (info/low/medium/high/critical)", flagInitMinSeverity)
+			if !findings.ValidateSeverity(opts.MinSeverity) {
+				return fmt.Errorf("invalid --min-severity: %s (must be info, low, medium, high, or critical)", opts.MinSeverity)
+			}
+		}
+	}
+
+	rendered, err := setup.Render(opts)
+	if err != nil {
+		return err
+	}
+	results, err := setup.Write(".", rendered, flagInitForce)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	skipped := false
+	for _, r := range results {
+		if r.Skipped {
+			skipped = true
+			fmt.Printf("⏭️  %s (exists, kept)\n", r.Path)
+		} else {
+			fmt.Printf("✅ Wrote %s\n", r.Path)
+		}
+	}
+	if skipped {
+		fmt.Println("   Use --force to overwrite existing files.")
+	}
+
+	fmt.Println("\nNext steps:")
+	fmt.Println("   1. Review the generated files and describe your priorities in " + setup.InstructionsPath)
+	fmt.Println("   2. Run 'autoengineer check' to confirm dependencies")
+	if opts.Workflow {
+		if opts.Backend == config.LLMBackendOpenAI {
+			fmt.Println("   3. Add an OPENAI_API_KEY repository secret for the workflow")
+		} else {
+			fmt.Println("   3. Add a COPILOT_GITHUB_TOKEN repository secret (a token with Copilot access) for the workflow")
+		}
+	} else {
+		fmt.Println("   3. Run 'autoengineer scan'")
+	}
+	return nil
+}
+
+// fetchLabels lists the repository's labels, warning and returning none on failure
+func fetchLabels(ctx context.Context) []string {
+	owner, repo, err := getRepoInfo()
+	if err != nil {
+		fmt.Println("\n   ⚠️  Warning: not a GitHub repository, existing labels not checked")
+		return nil
+	}
+	client, err := newIssuesClient(owner, repo, setup.DefaultLabel)
+	if err != nil {
+		fmt.Printf("\n   ⚠️  Warning: failed to create issues client: %v\n", err)
+		return nil
+	}
+	labels, err := client.ListLabels(ctx)
+	if err != nil {
+		fmt.Printf("\n   ⚠️  Warning: %v\n", err)
+		return nil
+	}
+	fmt.Printf("\n🏷️  Existing labels in %s/%s: %s\n", owner, repo, strings.Join(labels, ", "))
+	return labels
+}
+
+// prompter asks init's questions, skipping those answered by a flag
+type prompter struct {
+	reader *bufio.Reader
+	flags  *cobra.Command
+	yes    bool
+}
+
+// answered reports whether a question is answered without asking
+func (p *prompter) answered(flag string) bool {
+	return p.yes || (flag != "" && p.flags.Flags().Changed(flag))
+}
+
+// ask prompts for a value, returning def when the answer is blank
+func (p *prompter) ask(flag, question, def string) string {
+	if p.answered(flag) {
+		return def
+	}
+	if def != "" {
+		fmt.Printf("%s [%s]: ", question, def)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+	input, err := p.reader.ReadString('\n')
+	if answer := strings.TrimSpace(input); answer != "" {
+		return answer
+	}
+	if err != nil {
+		fmt.Println()
+	}
+	return def
+}
+
+// confirm asks a yes/no question, returning def when the answer is blank
+func (p *prompter) confirm(flag, question string, def bool) bool {
+	if p.answered(flag) {
+		return def
+	}
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	switch strings.ToLower(p.ask("", fmt.Sprintf("%s? (%s)", question, hint), "")) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return def
+	}
+}
+
+// splitList splits a comma-separated answer, dropping blanks
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
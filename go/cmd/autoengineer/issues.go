@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/spf13/cobra"
+)
+
+// flagFindingsFile is the findings file read by issues create, delegate, status and report
+var flagFindingsFile string
+
+// defaultFindingsFile is where scan saves findings by default
+const defaultFindingsFile = "./findings.json"
+
+// newIssuesCmd builds the `issues` command group
+func newIssuesCmd() *cobra.Command {
+	issuesCmd := &cobra.Command{
+		Use:   "issues",
+		Short: "Track findings as GitHub issues",
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create GitHub issues for the findings in a findings file",
+		Long: `Create a GitHub issue for each finding in a findings file saved by scan,
+skipping findings that already have an open issue (unless --force).`,
+		Args: cobra.NoArgs,
+		RunE: runIssuesCreate,
+	}
+	createCmd.Flags().StringVar(&flagFindingsFile, "findings", defaultFindingsFile, "Findings file to read")
+	createCmd.Flags().StringVar(&flagMinSeverity, "min-severity", "info", "Only create issues for findings at or above this severity level (info|low|medium|high|critical)")
+	createCmd.Flags().StringVar(&flagFilter, "filter", "", `Only create issues for findings matching this expression, e.g. 'severity >= medium && file =~ "^modules/"'`)
+	createCmd.Flags().BoolVar(&flagNewOnly, "new-only", false, "Only create issues for findings the scan marked new relative to the baseline")
+	createCmd.Flags().BoolVar(&flagForce, "force", false, "Create issues even if duplicates exist")
+	createCmd.Flags().BoolVar(&flagDelegate, "delegate", false, "Delegate the created issues to Copilot coding agent")
+
+	issuesCmd.AddCommand(withSettings(createCmd))
+	return issuesCmd
+}
+
+func runIssuesCreate(cmd *cobra.Command, args []string) error {
+	selected, err := loadSelectedFindings()
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		fmt.Println("✅ No findings to create issues for")
+		return nil
+	}
+
+	ctx := context.Background()
+	issueNums, err := createIssuesAuto(ctx, selected)
+	if err != nil {
+		return err
+	}
+
+	if flagDelegate && len(issueNums) > 0 {
+		return delegateIssues(ctx, issueNums)
+	}
+	return nil
+}
+
+// loadSelectedFindings loads --findings and applies --min-severity, --filter and --new-only
+func loadSelectedFindings() ([]findings.Finding, error) {
+	if flagMinSeverity != "" && !findings.ValidateSeverity(flagMinSeverity) {
+		return nil, fmt.Errorf("invalid --min-severity: %s (must be info, low, medium, high, or critical)", flagMinSeverity)
+	}
+	expr, err := parseFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := loadFindings(flagFindingsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := findings.FilterByExpression(findings.FilterBySeverity(all, flagMinSeverity), expr)
+	if flagNewOnly {
+		selected = newFindings(selected)
+	}
+	return selected, nil
+}
+
+// parseFilter compiles --filter, returning nil when it is not set
+func parseFilter() (*findings.Expression, error) {
+	if flagFilter == "" {
+		return nil, nil
+	}
+	expr, err := findings.ParseExpression(flagFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --filter %q: %w", flagFilter, err)
+	}
+	return expr, nil
+}
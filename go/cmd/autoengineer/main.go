@@ -2,20 +2,25 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 
+	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/liam-witterick/autoengineer/go/internal/analysis"
+	"github.com/liam-witterick/autoengineer/go/internal/cassette"
 	"github.com/liam-witterick/autoengineer/go/internal/config"
-	"github.com/liam-witterick/autoengineer/go/internal/copilot"
 	"github.com/liam-witterick/autoengineer/go/internal/findings"
-	"github.com/liam-witterick/autoengineer/go/internal/interactive"
+	"github.com/liam-witterick/autoengineer/go/internal/gate"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
 	"github.com/liam-witterick/autoengineer/go/internal/issues"
 	"github.com/liam-witterick/autoengineer/go/internal/progress"
 	"github.com/liam-witterick/autoengineer/go/internal/scanner"
+	"github.com/liam-witterick/autoengineer/go/internal/shard"
+	"github.com/liam-witterick/autoengineer/go/internal/verify"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +33,7 @@ var (
 	flagCreateIssues         bool
 	flagDelegate             bool
 	flagMinSeverity          string
+	flagFilter               string
 	flagOutput               string
 	flagForce                bool
 	flagScope                string
@@ -38,8 +44,21 @@ var (
 	flagInstructions         string
 	flagInstructionsText     string
 	flagUseExistingFindings  bool
+	flagRecord               string
+	flagReplay               string
+	flagShard                string
+	flagShardWorkers         int
+	flagScanOnly             bool
+	flagFailOn               string
+	flagFailOnNew            bool
+	flagBaseline             string
+	flagNewOnly              bool
+	flagExpiringWithin       int
 )
 
+// activeCassette records or replays LLM, GitHub API and scanner interactions (nil when disabled)
+var activeCassette *cassette.Cassette
+
 func main() {
 	rootCmd := &cobra.Command{
 		Use:   "autoengineer",
@@ -50,31 +69,42 @@ AutoEngineer discovers, tracks, and delegates infrastructure and pipeline improv
 powered entirely by GitHub Copilot CLI and Copilot coding agent.
 
 WORKFLOW:
-    1. 🔍 DISCOVER  - Copilot CLI analyzes repo, finds issues
-    2. 📋 TRACK     - Creates GitHub Issues from findings
+    1. 🔍 DISCOVER  - Copilot CLI analyzes repo, finds issues          (autoengineer scan)
+    2. 📋 TRACK     - Creates GitHub Issues from findings              (autoengineer issues create)
     3. 🔧 DELEGATE  - Sends fixes to Copilot CLI (local) or Copilot Coding Agent (cloud)
-    4. 🔗 CLOSE     - PRs link back to issues, closing the loop`,
+                                                                       (autoengineer delegate)
+    4. 🔗 CLOSE     - PRs link back to issues, closing the loop        (autoengineer status)
+
+Running autoengineer without a command is the same as 'autoengineer scan',
+so existing flags keep working.`,
 		RunE: run,
 	}
 
-	rootCmd.Flags().BoolVar(&flagAuto, "auto", false, "[DEPRECATED] Use --create-issues instead")
-	rootCmd.Flags().BoolVar(&flagCreateIssues, "create-issues", false, "Skip prompts and create GitHub issues automatically")
-	rootCmd.Flags().BoolVar(&flagDelegate, "delegate", false, "Skip prompts and delegate fixes to Copilot coding agent (requires --create-issues)")
-	rootCmd.Flags().StringVar(&flagMinSeverity, "min-severity", "low", "Only action findings at or above this severity level (low|medium|high)")
-	rootCmd.Flags().StringVar(&flagOutput, "output", "./findings.json", "Save findings to specified file")
-	rootCmd.Flags().BoolVar(&flagForce, "force", false, "Create issues even if duplicates exist")
-	rootCmd.Flags().StringVar(&flagScope, "scope", "all", "Run focused analysis (security|pipeline|infra|all)")
+	addScanFlags(rootCmd)
 	rootCmd.Flags().BoolVar(&flagCheck, "check", false, "Check dependencies and exit")
 	rootCmd.Flags().BoolVar(&flagVersion, "version", false, "Show version")
-	rootCmd.Flags().BoolVar(&flagNoScanners, "no-scanners", false, "Skip external scanner integration")
-	rootCmd.Flags().BoolVar(&flagFast, "fast", false, "Fast mode - skip external scanners (alias for --no-scanners)")
-	rootCmd.Flags().StringVar(&flagInstructions, "instructions", "", "Path to custom instructions file")
-	rootCmd.Flags().StringVar(&flagInstructionsText, "instructions-text", "", "Custom instructions as text")
-	rootCmd.Flags().BoolVar(&flagUseExistingFindings, "use-existing-findings", false, "Load findings from file instead of running a new scan")
+	addSettingsFlags(rootCmd)
+
+	rootCmd.AddCommand(newScanCmd())
+	rootCmd.AddCommand(newIssuesCmd())
+	rootCmd.AddCommand(newDelegateCmd())
+	rootCmd.AddCommand(newStatusCmd())
+	rootCmd.AddCommand(newReportCmd())
+	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newInitCmd())
+	rootCmd.AddCommand(newCheckCmd())
+	rootCmd.AddCommand(newPromptsCmd())
+	rootCmd.AddCommand(newBatchCmd())
+	rootCmd.AddCommand(newBaselineCmd())
+	rootCmd.AddCommand(newDiffCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		var exitErr *gate.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+		os.Exit(gate.ExitToolError)
 	}
 }
 
@@ -88,215 +118,54 @@ func run(cmd *cobra.Command, args []string) error {
 		return checkDependencies()
 	}
 
-	// Handle --auto deprecation
-	if flagAuto {
-		fmt.Println("⚠️  --auto is deprecated. Use --create-issues instead.")
-		flagCreateIssues = true
-	}
-
-	// Validate flag combinations
-	if flagDelegate && !flagCreateIssues {
-		return fmt.Errorf("--delegate requires --create-issues")
-	}
-
-	// Validate min-severity
-	if flagMinSeverity != "" && !findings.ValidateSeverity(flagMinSeverity) {
-		return fmt.Errorf("invalid --min-severity: %s (must be low, medium, or high)", flagMinSeverity)
-	}
-
-	// Ensure we're in a git repo
-	if !isGitRepo() {
-		return fmt.Errorf("not in a git repository")
-	}
-
-	ctx := context.Background()
-
-	// Load ignore configuration
-	cfg, err := config.LoadIgnoreConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load ignore config: %w", err)
-	}
-
-	// Load scanner configuration
-	scannerCfg, err := config.LoadScannerConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load scanner config: %w", err)
-	}
-
-	// Load custom instructions
-	var extraContext string
-	
-	// Priority order: --instructions-text > --instructions > .github/copilot-instructions.md
-	if flagInstructionsText != "" {
-		// Use inline instructions text
-		extraContext = config.FormatInstructions(flagInstructionsText)
-	} else if flagInstructions != "" {
-		// Use custom instructions file
-		instructions, err := config.LoadInstructions(flagInstructions)
-		if err != nil {
-			return fmt.Errorf("failed to load instructions from %s: %w", flagInstructions, err)
-		}
-		extraContext = instructions
-	} else {
-		// Try to load default instructions file
-		instructions, err := config.LoadDefaultInstructions()
-		if err != nil {
-			return fmt.Errorf("failed to load default instructions: %w", err)
-		}
-		extraContext = instructions
-	}
-
-	// Check if scope is disabled
-	if flagScope != "all" && cfg.IsScopeDisabled(flagScope) {
-		fmt.Printf("⚠️  Scope '%s' is disabled in ignore config\n", flagScope)
-		return nil
-	}
-
-	// Fetch existing tracked issues before analysis
-	fmt.Println("\n🔍 Fetching existing tracked issues...")
-	
-	owner, repo, err := getRepoInfo()
-	if err != nil {
-		return fmt.Errorf("failed to get repo info: %w", err)
-	}
-
-	label := os.Getenv("AUTOENGINEER_LABEL")
-	if label == "" {
-		label = "autoengineer"
-	}
-
-	issuesClient, err := issues.NewClient(owner, repo, label)
-	if err != nil {
-		return fmt.Errorf("failed to create issues client: %w", err)
-	}
-
-	existingIssues, err := issuesClient.ListOpenIssues(ctx)
-	if err != nil {
-		fmt.Printf("   ⚠️  Warning: failed to fetch existing issues: %v\n", err)
-		existingIssues = []issues.SearchResult{}
-	} else {
-		fmt.Printf("   Found %d existing tracked issue(s)\n", len(existingIssues))
-	}
-
-	var allFindings []findings.Finding
-	var scannerStatuses []scanner.ScannerStatus
+	return runScan(cmd, args)
+}
 
-	// Load findings from file or run new scan
-	if flagUseExistingFindings {
-		// Load findings from existing file
-		fmt.Printf("\n📂 Loading findings from %s...\n", flagOutput)
-		
-		loadedFindings, err := loadFindings(flagOutput)
+// setupCassette opens the cassette selected by --record or --replay
+func setupCassette() error {
+	var err error
+	switch {
+	case flagRecord != "":
+		activeCassette, err = cassette.Record(flagRecord)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to start recording: %w", err)
 		}
-		
-		fmt.Printf("   Loaded %d finding(s)\n", len(loadedFindings))
-		allFindings = loadedFindings
-	} else {
-		// Build existing context for the analysis prompt
-		existingContext := analysis.BuildExistingContext(existingIssues)
-
-		// Run analysis with progress tracking
-		fmt.Println("\n🔍 Running analysis...")
-		fmt.Println()
-
-		// Determine if scanners should run
-		skipScanners := flagNoScanners || flagFast
-
-		var err error
-		allFindings, scannerStatuses, err = runAnalysisWithScanners(ctx, flagScope, cfg, scannerCfg, skipScanners, existingContext, extraContext)
+		fmt.Printf("📼 Recording LLM, GitHub API and scanner interactions to %s\n", flagRecord)
+	case flagReplay != "":
+		activeCassette, err = cassette.Replay(flagReplay)
 		if err != nil {
-			return fmt.Errorf("analysis failed: %w", err)
-		}
-
-		fmt.Println()
-
-		// Display scanner summary
-		if !skipScanners && len(scannerStatuses) > 0 {
-			displayScannerSummary(scannerStatuses)
-		}
-
-		// Run intelligent deduplication with Copilot
-		// This merges related findings across categories and filters out duplicates of existing issues
-		if len(allFindings) > 0 {
-			fmt.Println()
-			fmt.Println("🔄 Deduplicating findings...")
-			
-			client := copilot.NewClient()
-			deduplicated, err := client.RunDeduplication(ctx, allFindings, existingIssues)
-			if err != nil {
-				// Log the error but continue with original findings
-				fmt.Printf("   ⚠️  Warning: deduplication failed, continuing with original findings: %v\n", err)
-			} else {
-				beforeDedup := len(allFindings)
-				allFindings = deduplicated
-				deduplicatedCount := beforeDedup - len(allFindings)
-				if deduplicatedCount > 0 {
-					fmt.Printf("   Removed %d duplicate/related finding(s)\n", deduplicatedCount)
-				} else {
-					fmt.Printf("   No duplicates found\n")
-				}
-			}
-		}
-	}
-
-	// Filter findings by ignore config
-	filtered, ignoredCount := findings.Filter(allFindings, cfg)
-
-	if ignoredCount > 0 {
-		fmt.Printf("   Ignored %d finding(s) based on config\n", ignoredCount)
-	}
-
-	// Apply severity filtering
-	beforeSeverityFilter := len(filtered)
-	if flagMinSeverity != "" && flagMinSeverity != findings.SeverityLow {
-		filtered = findings.FilterBySeverity(filtered, flagMinSeverity)
-		severityFilteredCount := beforeSeverityFilter - len(filtered)
-		if severityFilteredCount > 0 {
-			fmt.Printf("   Filtered %d finding(s) below %s severity\n", severityFilteredCount, flagMinSeverity)
+			return fmt.Errorf("failed to load cassette: %w", err)
 		}
+		fmt.Printf("📼 Replaying LLM, GitHub API and scanner interactions from %s\n", flagReplay)
 	}
+	return nil
+}
 
-	// Save findings to file (only when running new scan)
-	// We skip saving when using existing findings to avoid overwriting
-	// the original file with potentially filtered/modified results
-	if !flagUseExistingFindings {
-		if err := saveFindings(filtered, flagOutput); err != nil {
-			return fmt.Errorf("failed to save findings: %w", err)
-		}
+// newIssuesClient creates a GitHub issues client, routed through the cassette when active
+func newIssuesClient(owner, repo, label string) (*issues.Client, error) {
+	if activeCassette == nil {
+		return issues.NewClient(owner, repo, label)
 	}
 
-	// Display preview with existing issues
-	displayPreview(existingIssues, filtered, ignoredCount)
-
-	if len(filtered) == 0 && len(existingIssues) == 0 {
-		fmt.Println("\n✅ No findings to report!")
-		return nil
+	opts := api.ClientOptions{Transport: activeCassette.WrapTransport(nil)}
+	if activeCassette.Replaying() {
+		// Replays never reach GitHub, so don't require gh to be authenticated
+		opts.Host = "github.com"
+		opts.AuthToken = "replay"
 	}
+	return issues.NewClientWithOptions(owner, repo, label, opts)
+}
 
-	// Auto mode: create issues automatically
-	if flagCreateIssues {
-		issueNums, err := createIssuesAuto(ctx, filtered)
-		if err != nil {
-			return err
-		}
-
-		// Delegate to Copilot coding agent if requested
-		if flagDelegate && len(issueNums) > 0 {
-			return delegateIssues(ctx, issueNums)
-		}
-
-		return nil
+// newCheckCmd builds the `check` command; --check is an alias for it
+func newCheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Check dependencies, scanners, technology inventory and project configuration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkDependencies()
+		},
 	}
-
-	// Interactive mode (owner, repo, label already fetched above)
-	session, err := interactive.NewSession(filtered, owner, repo, label)
-	if err != nil {
-		return fmt.Errorf("failed to create interactive session: %w", err)
-	}
-
-	return session.Run(ctx)
 }
 
 func checkDependencies() error {
@@ -305,13 +174,31 @@ func checkDependencies() error {
 
 	allOK := true
 
+	// Load LLM backend config - the copilot CLI is only required when it runs the analysis
+	llmCfg, err := config.LoadLLMConfig()
+	if err != nil {
+		fmt.Printf("   ❌ llm config invalid: %v\n", err)
+		allOK = false
+		llmCfg = &config.LLMConfig{}
+	}
+	copilotRequired := llmCfg.BackendName() == config.LLMBackendCopilot
+
 	// Check copilot
 	if checkCommand("copilot") {
 		ver := getVersion("copilot", "--version")
 		fmt.Printf("   ✅ copilot (%s)\n", ver)
-	} else {
+	} else if copilotRequired {
 		fmt.Println("   ❌ copilot (missing)")
 		allOK = false
+	} else {
+		fmt.Println("   ⏭️  copilot (not installed - local fixes unavailable)")
+	}
+
+	// Report the analysis backend
+	if llmCfg.BackendName() == config.LLMBackendOpenAI {
+		fmt.Printf("   ✅ llm backend: %s (%s at %s)\n", llmCfg.BackendName(), llmCfg.Model, llmCfg.EffectiveBaseURL())
+	} else {
+		fmt.Printf("   ✅ llm backend: %s\n", llmCfg.BackendName())
 	}
 
 	// Check gh
@@ -345,17 +232,58 @@ func checkDependencies() error {
 		scannerCfg = &config.ScannerConfig{}
 	}
 
+	files := listRepoFiles()
+	inv := inventory.Detect(".", files)
+
 	mgr := scanner.NewManager(scannerCfg)
+	if len(files) > 0 {
+		mgr.SetInventory(inv)
+	}
 	statuses := mgr.DetectScanners()
 
 	for _, status := range statuses {
-		if status.Installed {
-			fmt.Printf("   ✅ %s (%s)\n", status.Name, status.Version)
-		} else {
+		switch {
+		case !status.Installed:
 			fmt.Printf("   ⏭️  %s (not installed - will be skipped)\n", status.Name)
+		case status.Reason == "nothing to scan":
+			fmt.Printf("   ⏭️  %s (%s) - nothing to scan, will be skipped\n", status.Name, status.Version)
+		default:
+			fmt.Printf("   ✅ %s (%s)\n", status.Name, status.Version)
 		}
 	}
 
+	// Report the technology inventory and the scopes it rules out
+	displayInventory(inv)
+	if registry, err := loadScopeRegistry(); err != nil {
+		fmt.Printf("   ⚠️  Warning: %v\n", err)
+	} else {
+		_, skipped := applicableScopes(registry.Names(), registry, inv, files)
+		for _, s := range skipped {
+			fmt.Printf("   ⏭️  %s scope (%s - will be skipped)\n", s.Name, s.SkipReason())
+		}
+	}
+
+	// Validate the configuration files
+	fmt.Println()
+	fmt.Println("🔍 Configuration:")
+	var invalid *config.InvalidError
+	if err := config.Validate(files); errors.As(err, &invalid) {
+		for _, p := range invalid.Problems {
+			fmt.Printf("   ❌ %s\n", p)
+		}
+		allOK = false
+	} else if err != nil {
+		fmt.Printf("   ❌ %v\n", err)
+		allOK = false
+	} else {
+		fmt.Println("   ✅ valid")
+	}
+
+	// Report nested per-directory configuration
+	if !displayProjects(files) {
+		allOK = false
+	}
+
 	// Check for custom instructions
 	fmt.Println()
 	fmt.Println("🔍 Custom Instructions:")
@@ -397,168 +325,312 @@ func isGitRepo() bool {
 	return cmd.Run() == nil
 }
 
-func runAnalysis(ctx context.Context, scope string, cfg *config.IgnoreConfig, tracker *progress.ScopeTracker, existingContext string, extraContext string) ([]findings.Finding, error) {
-	client := copilot.NewClient()
+// loadScopeRegistry builds the scope registry from autoengineer.yaml and
+// registers custom categories for display
+func loadScopeRegistry() (*analysis.Registry, error) {
+	scopeCfgs, err := config.LoadScopeConfigs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scopes: %w", err)
+	}
 
-	base := analysis.BaseAnalyzer{
-		Client:          client,
-		ExistingContext: existingContext,
-		ExtraContext:    extraContext,
+	registry, err := analysis.NewRegistry(scopeCfgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scopes: %w", err)
 	}
 
-	var allFindings []findings.Finding
+	registry.RegisterCategories()
+	return registry, nil
+}
+
+// loadExtraContext loads the custom instructions appended to analysis prompts
+func loadExtraContext() (string, error) {
+	// Priority order: --instructions-text > --instructions > .github/copilot-instructions.md
+	if flagInstructionsText != "" {
+		// Use inline instructions text
+		return config.FormatInstructions(flagInstructionsText), nil
+	}
 
-	switch scope {
-	case "security":
-		if cfg.IsScopeDisabled("security") {
-			fmt.Println("   ⚠️  Security scope is disabled")
-			return []findings.Finding{}, nil
-		}
-		if tracker != nil {
-			tracker.StartScope("security")
-		}
-		analyzer := analysis.NewSecurityAnalyzer(base)
-		results, err := analyzer.Run(ctx)
+	if flagInstructions != "" {
+		// Use custom instructions file
+		instructions, err := config.LoadInstructions(flagInstructions)
 		if err != nil {
-			if tracker != nil {
-				tracker.FailScope("security", err)
-			}
-			return nil, err
+			return "", fmt.Errorf("failed to load instructions from %s: %w", flagInstructions, err)
 		}
-		if tracker != nil {
-			tracker.CompleteScope("security", len(results))
-		}
-		allFindings = results
+		return instructions, nil
+	}
 
-	case "pipeline":
-		if cfg.IsScopeDisabled("pipeline") {
-			fmt.Println("   ⚠️  Pipeline scope is disabled")
-			return []findings.Finding{}, nil
-		}
-		if tracker != nil {
-			tracker.StartScope("pipeline")
+	// Try to load default instructions file
+	instructions, err := config.LoadDefaultInstructions()
+	if err != nil {
+		return "", fmt.Errorf("failed to load default instructions: %w", err)
+	}
+	return instructions, nil
+}
+
+// listRepoFiles returns the files tracked in the repository for prompt templates
+// and the technology inventory
+func listRepoFiles() []string {
+	files, err := inventory.ListFiles(".")
+	if err != nil {
+		return nil
+	}
+	return files
+}
+
+// attributeProjects sets the owning nested project on each finding
+func attributeProjects(all []findings.Finding, projects *config.Projects) {
+	for i := range all {
+		all[i].Project = ""
+		if project := projects.Owner(all[i].Files); project != nil {
+			all[i].Project = project.Name
 		}
-		analyzer := analysis.NewPipelineAnalyzer(base)
-		results, err := analyzer.Run(ctx)
-		if err != nil {
-			if tracker != nil {
-				tracker.FailScope("pipeline", err)
+	}
+}
+
+// dropDisabledScopes removes findings whose project disables the scope that
+// produced them, matching scopes by category
+func dropDisabledScopes(all []findings.Finding, projects *config.Projects, registry *analysis.Registry) ([]findings.Finding, int) {
+	kept := make([]findings.Finding, 0, len(all))
+	dropped := 0
+	for _, f := range all {
+		disabled := false
+		if f.Project != "" {
+			ignore := projects.Ignore(f.Project)
+			for _, s := range registry.Scopes() {
+				if s.Category == f.Category && ignore.IsScopeDisabled(s.Name) {
+					disabled = true
+					break
+				}
 			}
-			return nil, err
 		}
-		if tracker != nil {
-			tracker.CompleteScope("pipeline", len(results))
+
+		if disabled {
+			dropped++
+			continue
 		}
-		allFindings = results
+		kept = append(kept, f)
+	}
+	return kept, dropped
+}
 
-	case "infra":
-		if cfg.IsScopeDisabled("infra") {
-			fmt.Println("   ⚠️  Infra scope is disabled")
-			return []findings.Finding{}, nil
+// displayProjects prints the nested autoengineer.yaml projects for --check,
+// returning false if one of them is invalid
+func displayProjects(files []string) bool {
+	ignoreCfg, err := config.LoadIgnoreConfig()
+	if err != nil {
+		ignoreCfg = &config.IgnoreConfig{}
+	}
+
+	projects, err := config.LoadProjects(ignoreCfg, files)
+	if err != nil {
+		fmt.Println()
+		fmt.Println("🗂️  Projects:")
+		fmt.Printf("   ❌ %v\n", err)
+		return false
+	}
+	if len(projects.All()) == 0 {
+		return true
+	}
+
+	fmt.Println()
+	fmt.Println("🗂️  Projects:")
+	for _, project := range projects.All() {
+		fmt.Printf("   ✅ %s (%s)\n", project.Name, project.ConfigPath)
+	}
+	return true
+}
+
+// displayProjectCounts prints the number of findings owned by each nested project
+func displayProjectCounts(all []findings.Finding, projects *config.Projects) {
+	counts := make(map[string]int)
+	for _, f := range all {
+		if f.Project != "" {
+			counts[f.Project]++
 		}
-		if tracker != nil {
-			tracker.StartScope("infra")
+	}
+	if len(counts) == 0 {
+		return
+	}
+
+	fmt.Println("\n🗂️  By project:")
+	for _, project := range projects.All() {
+		if counts[project.Name] > 0 {
+			fmt.Printf("   %-24s%d finding(s)\n", project.Name, counts[project.Name])
 		}
-		analyzer := analysis.NewInfraAnalyzer(base)
-		results, err := analyzer.Run(ctx)
-		if err != nil {
-			if tracker != nil {
-				tracker.FailScope("infra", err)
-			}
-			return nil, err
+	}
+}
+
+// displayInventory prints the detected technologies with their file counts
+func displayInventory(inv inventory.Inventory) {
+	fmt.Println("\n📦 Technology inventory:")
+	if len(inv.Entries) == 0 {
+		fmt.Println("   No infrastructure or pipeline code detected")
+		return
+	}
+	for _, e := range inv.Entries {
+		fmt.Printf("   %-16s%d file(s)\n", inventory.Title(e.Technology), e.Count())
+	}
+}
+
+// applicableScopes drops the scopes that have nothing to analyze in the repo.
+// Without a file list nothing is known about the repo and every scope is kept.
+func applicableScopes(scopes []string, registry *analysis.Registry, inv inventory.Inventory, files []string) ([]string, []analysis.Scope) {
+	if len(files) == 0 {
+		return scopes, nil
+	}
+
+	var kept []string
+	var skipped []analysis.Scope
+	for _, name := range scopes {
+		s, ok := registry.Get(name)
+		if ok && !s.Applicable(inv, files) {
+			skipped = append(skipped, s)
+			continue
 		}
-		if tracker != nil {
-			tracker.CompleteScope("infra", len(results))
+		kept = append(kept, name)
+	}
+	return kept, skipped
+}
+
+// selectScopes resolves --scope to the enabled scopes to analyze, in registry order
+func selectScopes(scope string, cfg *config.IgnoreConfig, registry *analysis.Registry) ([]string, error) {
+	var candidates []string
+	if scope == "all" {
+		candidates = registry.Names()
+	} else {
+		if _, ok := registry.Get(scope); !ok {
+			return nil, fmt.Errorf("invalid scope: %s (must be %s)", scope, registry.Usage())
 		}
-		allFindings = results
+		candidates = []string{scope}
+	}
 
-	case "all":
-		// Run all scopes concurrently
-		type result struct {
-			scope    string
-			findings []findings.Finding
-			err      error
+	enabledScopes := []string{}
+	for _, s := range candidates {
+		if !cfg.IsScopeDisabled(s) {
+			enabledScopes = append(enabledScopes, s)
 		}
+	}
+	return enabledScopes, nil
+}
 
-		scopes := []string{"security", "pipeline", "infra"}
-		enabledScopes := []string{}
+// analysisJob is one scope run over the whole repository or over one shard
+type analysisJob struct {
+	scope string
+	shard *shard.Shard
+}
 
-		for _, s := range scopes {
-			if !cfg.IsScopeDisabled(s) {
-				enabledScopes = append(enabledScopes, s)
-			}
+// label identifies the job in progress output
+func (j analysisJob) label() string {
+	if j.shard == nil {
+		return j.scope
+	}
+	return fmt.Sprintf("%s [%s]", j.scope, j.shard.Name())
+}
+
+// planJobs expands scopes into one job per shard (or one per scope when not
+// sharding). With skipEmpty, shards with nothing for a scope to analyze are dropped.
+func planJobs(registry *analysis.Registry, scopes []string, shards []shard.Shard, inv inventory.Inventory, skipEmpty bool) []analysisJob {
+	var jobs []analysisJob
+	for _, name := range scopes {
+		if shards == nil {
+			jobs = append(jobs, analysisJob{scope: name})
+			continue
 		}
 
-		if len(enabledScopes) == 0 {
-			return []findings.Finding{}, nil
+		s, _ := registry.Get(name)
+		for i := range shards {
+			sh := &shards[i]
+			if skipEmpty && !s.Applicable(inv.Subset(sh.Files), sh.Files) {
+				continue
+			}
+			jobs = append(jobs, analysisJob{scope: name, shard: sh})
 		}
+	}
+	return jobs
+}
 
-		resultCh := make(chan result, len(enabledScopes))
+// runAnalysis runs the jobs with at most workers running at once (all at once
+// when workers is 0) and merges their findings
+func runAnalysis(ctx context.Context, registry *analysis.Registry, jobs []analysisJob, base analysis.BaseAnalyzer, workers int, tracker *progress.ScopeTracker) ([]findings.Finding, error) {
+	type result struct {
+		findings []findings.Finding
+		err      error
+	}
 
-		// Start each enabled scope
-		for _, s := range enabledScopes {
-			if tracker != nil {
-				tracker.StartScope(s)
-			}
+	if len(jobs) == 0 {
+		return []findings.Finding{}, nil
+	}
 
-			go func(scopeName string) {
-				// Create a new client for each concurrent scope to avoid race conditions
-				scopeClient := copilot.NewClient()
-				scopeBase := analysis.BaseAnalyzer{
-					Client:          scopeClient,
-					ExistingContext: existingContext,
-					ExtraContext:    extraContext,
-				}
+	if workers <= 0 || workers > len(jobs) {
+		workers = len(jobs)
+	}
 
-				var analyzer analysis.Analyzer
-				switch scopeName {
-				case "security":
-					analyzer = analysis.NewSecurityAnalyzer(scopeBase)
-				case "pipeline":
-					analyzer = analysis.NewPipelineAnalyzer(scopeBase)
-				case "infra":
-					analyzer = analysis.NewInfraAnalyzer(scopeBase)
-				}
+	// Build every analyzer up front so configuration errors surface before any prompt runs
+	analyzers := make([]analysis.Analyzer, len(jobs))
+	for i, job := range jobs {
+		jobBase := base
+		if job.shard != nil {
+			jobBase = base.ForShard(*job.shard)
+		}
 
-				results, err := analyzer.Run(ctx)
-				resultCh <- result{scope: scopeName, findings: results, err: err}
-			}(s)
+		analyzer, err := registry.Analyzer(job.scope, jobBase)
+		if err != nil {
+			return nil, err
 		}
+		analyzers[i] = analyzer
+	}
 
-		// Collect results
-		allResults := make([]result, 0, len(enabledScopes))
-		for i := 0; i < len(enabledScopes); i++ {
-			res := <-resultCh
-			allResults = append(allResults, res)
+	// Feed job indexes to a bounded pool of workers
+	// The client holds no per-call state, so it is shared across concurrent jobs
+	results := make([]result, len(jobs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
 
-			if tracker != nil {
-				if res.err != nil {
-					tracker.FailScope(res.scope, res.err)
-				} else {
-					tracker.CompleteScope(res.scope, len(res.findings))
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				label := jobs[i].label()
+				if tracker != nil {
+					tracker.StartScope(label)
 				}
-			}
-		}
 
-		// Check for errors and merge findings with deduplication
-		var findingSlices [][]findings.Finding
-		for _, res := range allResults {
-			if res.err != nil {
-				return nil, fmt.Errorf("%s analysis failed: %w", res.scope, res.err)
+				found, err := analyzers[i].Run(ctx)
+				results[i] = result{findings: found, err: err}
+
+				if tracker != nil {
+					if err != nil {
+						tracker.FailScope(label, err)
+					} else {
+						tracker.CompleteScope(label, len(found))
+					}
+				}
 			}
-			findingSlices = append(findingSlices, res.findings)
-		}
-		allFindings = findings.Merge(findingSlices...)
+		}()
+	}
 
-	default:
-		return nil, fmt.Errorf("invalid scope: %s (must be security|pipeline|infra|all)", scope)
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	// Check for errors and merge findings with deduplication, in job order
+	// so the merged list doesn't depend on which job finished first
+	var findingSlices [][]findings.Finding
+	for i, res := range results {
+		if res.err != nil {
+			return nil, fmt.Errorf("%s analysis failed: %w", jobs[i].label(), res.err)
+		}
+		findingSlices = append(findingSlices, res.findings)
 	}
 
-	return allFindings, nil
+	return findings.Merge(findingSlices...), nil
 }
 
 // runAnalysisWithScanners runs both Copilot analysis and external scanners in parallel
-func runAnalysisWithScanners(ctx context.Context, scope string, cfg *config.IgnoreConfig, scannerCfg *config.ScannerConfig, skipScanners bool, existingContext string, extraContext string) ([]findings.Finding, []scanner.ScannerStatus, error) {
+func runAnalysisWithScanners(ctx context.Context, scope string, cfg *config.IgnoreConfig, scannerCfg *config.ScannerConfig, registry *analysis.Registry, base analysis.BaseAnalyzer, shards []shard.Shard, workers int, verifier *verify.Verifier, skipScanners bool) ([]findings.Finding, []scanner.ScannerStatus, error) {
 	type result struct {
 		findings []findings.Finding
 		statuses []scanner.ScannerStatus
@@ -566,29 +638,43 @@ func runAnalysisWithScanners(ctx context.Context, scope string, cfg *config.Igno
 	}
 
 	// Determine scopes to analyze
-	var scopes []string
+	scopes, err := selectScopes(scope, cfg, registry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// With --scope all, skip scopes with nothing to analyze; an explicit scope always runs
 	if scope == "all" {
-		scopes = []string{"security", "pipeline", "infra"}
-		// Filter out disabled scopes
-		enabledScopes := []string{}
-		for _, s := range scopes {
-			if !cfg.IsScopeDisabled(s) {
-				enabledScopes = append(enabledScopes, s)
-			}
+		var skipped []analysis.Scope
+		scopes, skipped = applicableScopes(scopes, registry, base.Inventory, base.Files)
+		for _, s := range skipped {
+			fmt.Printf("⏭️  %s: skipped (%s)\n", s.Title, s.SkipReason())
 		}
-		scopes = enabledScopes
-	} else {
-		if !cfg.IsScopeDisabled(scope) {
-			scopes = []string{scope}
-		} else {
-			scopes = []string{}
+		if len(skipped) > 0 {
+			fmt.Println()
 		}
 	}
 
+	// Expand scopes into jobs, one per shard when sharding
+	jobs := planJobs(registry, scopes, shards, base.Inventory, scope == "all" && len(base.Files) > 0)
+	if shards == nil {
+		// Unsharded scopes all run at once
+		workers = 0
+	}
+
 	// Create progress tracker
 	var tracker *progress.ScopeTracker
-	if len(scopes) > 0 {
-		tracker = progress.NewScopeTracker(scopes)
+	if len(jobs) > 0 {
+		labels := make([]string, len(jobs))
+		for i, job := range jobs {
+			labels[i] = job.label()
+		}
+		tracker = progress.NewScopeTracker(labels)
+		for _, job := range jobs {
+			if s, ok := registry.Get(job.scope); ok && s.Emoji != "" {
+				tracker.SetScopeEmoji(job.label(), s.Emoji)
+			}
+		}
 	}
 
 	// Run Copilot analysis and scanners in parallel
@@ -597,7 +683,7 @@ func runAnalysisWithScanners(ctx context.Context, scope string, cfg *config.Igno
 
 	// Run Copilot analysis
 	go func() {
-		copilotFindings, err := runAnalysis(ctx, scope, cfg, tracker, existingContext, extraContext)
+		copilotFindings, err := runAnalysis(ctx, registry, jobs, base, workers, tracker)
 		copilotCh <- result{findings: copilotFindings, err: err}
 	}()
 
@@ -608,8 +694,31 @@ func runAnalysisWithScanners(ctx context.Context, scope string, cfg *config.Igno
 			return
 		}
 
+		// Replays serve the recorded scanner findings instead of running the scanners
+		if activeCassette.Replaying() {
+			scannerFindings, err := activeCassette.ReplayScanners(scope)
+			scannerCh <- result{findings: scannerFindings, err: err}
+			return
+		}
+
 		mgr := scanner.NewManager(scannerCfg)
+		if len(base.Files) > 0 {
+			mgr.SetInventory(base.Inventory)
+			mgr.SetSkipPaths(scanner.SkippedPaths(base.Files, func(file string) bool {
+				ignoreCfg := cfg
+				if p := base.Projects.Owner([]string{file}); p != nil {
+					ignoreCfg = p.Ignore
+				}
+				return ignoreCfg.MatchesPath(file)
+			}))
+		}
 		scannerFindings, statuses := mgr.RunAll(ctx, scope)
+		if activeCassette != nil {
+			if err := activeCassette.RecordScanners(scope, scannerFindings); err != nil {
+				scannerCh <- result{err: err}
+				return
+			}
+		}
 		scannerCh <- result{findings: scannerFindings, statuses: statuses}
 	}()
 
@@ -625,13 +734,48 @@ func runAnalysisWithScanners(ctx context.Context, scope string, cfg *config.Igno
 	if copilotResult.err != nil {
 		return nil, nil, copilotResult.err
 	}
+	if scannerResult.err != nil {
+		return nil, nil, scannerResult.err
+	}
+
+	// Ground LLM findings in the working tree before merging
+	// Scanner findings come from parsing real files and are not verified
+	verified := verifier.Verify(copilotResult.findings)
+	displayVerificationSummary(verified)
 
 	// Merge findings from all sources and deduplicate
-	allFindings := findings.Merge(copilotResult.findings, scannerResult.findings)
+	allFindings := findings.Merge(verified.Findings, scannerResult.findings)
 
 	return allFindings, scannerResult.statuses, nil
 }
 
+// displayVerificationSummary reports what the hallucination guard changed
+func displayVerificationSummary(result verify.Result) {
+	if len(result.Dropped) == 0 && result.Flagged == 0 && result.MissingFiles == 0 &&
+		result.CorrectedSnippets == 0 && result.RemovedSnippets == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("🔎 Verification:")
+
+	if result.CorrectedSnippets > 0 {
+		fmt.Printf("   🩹 Corrected line numbers for %d code snippet(s)\n", result.CorrectedSnippets)
+	}
+	if result.MissingFiles > 0 {
+		fmt.Printf("   ⚠️  Removed %d reference(s) to files that don't exist\n", result.MissingFiles)
+	}
+	if result.RemovedSnippets > 0 {
+		fmt.Printf("   ⚠️  Removed %d code snippet(s) not found in the referenced files\n", result.RemovedSnippets)
+	}
+	if result.Flagged > 0 {
+		fmt.Printf("   🏷️  Flagged %d finding(s) as %s\n", result.Flagged, findings.TagUnverified)
+	}
+	for _, dropped := range result.Dropped {
+		fmt.Printf("   🗑️  Dropped: %s (%s)\n", dropped.Finding.Title, dropped.Reason)
+	}
+}
+
 // displayScannerSummary shows which scanners ran and their results
 func displayScannerSummary(statuses []scanner.ScannerStatus) {
 	fmt.Println()
@@ -653,32 +797,14 @@ func displayScannerSummary(statuses []scanner.ScannerStatus) {
 }
 
 func saveFindings(allFindings []findings.Finding, outputPath string) error {
-	data, err := json.MarshalIndent(allFindings, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(outputPath, data, 0644)
+	return findings.SaveFile(allFindings, outputPath)
 }
 
 func loadFindings(inputPath string) ([]findings.Finding, error) {
-	data, err := os.ReadFile(inputPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("findings file not found: %s", inputPath)
-		}
-		return nil, fmt.Errorf("failed to read findings file: %w", err)
-	}
-
-	var allFindings []findings.Finding
-	if err := json.Unmarshal(data, &allFindings); err != nil {
-		return nil, fmt.Errorf("failed to parse findings file: %w", err)
-	}
-
-	return allFindings, nil
+	return findings.LoadFile(inputPath)
 }
 
-func displayPreview(existingIssues []issues.SearchResult, allFindings []findings.Finding, ignoredCount int) {
+func displayPreview(existingIssues []issues.SearchResult, allFindings []findings.Finding, ignoredCount int, inv inventory.Inventory, projects *config.Projects) {
 	fmt.Println()
 	
 	// Display existing tracked issues
@@ -707,6 +833,12 @@ func displayPreview(existingIssues []issues.SearchResult, allFindings []findings
 		fmt.Printf("\n⏭️  Ignored:        %d finding(s) (based on ignore config)\n", ignoredCount)
 	}
 
+	if len(inv.Entries) > 0 {
+		fmt.Printf("\n📦 Analyzed:       %s\n", inv.Summary())
+	}
+
+	displayProjectCounts(allFindings, projects)
+
 	fmt.Println()
 
 	findings.DisplayFindings(allFindings, findings.DefaultDisplayOptions())
@@ -715,21 +847,11 @@ func displayPreview(existingIssues []issues.SearchResult, allFindings []findings
 func createIssuesAuto(ctx context.Context, allFindings []findings.Finding) ([]int, error) {
 	fmt.Println("\n📝 Creating GitHub issues...")
 
-	// Get repo info
-	owner, repo, err := getRepoInfo()
+	t, err := newTracker()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repo info: %w", err)
-	}
-
-	label := os.Getenv("AUTOENGINEER_LABEL")
-	if label == "" {
-		label = "autoengineer"
-	}
-
-	client, err := issues.NewClient(owner, repo, label)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create issues client: %w", err)
+		return nil, err
 	}
+	client := t.client
 
 	// Ensure label exists
 	if err := client.EnsureLabel(ctx); err != nil {
@@ -781,22 +903,11 @@ func delegateIssues(ctx context.Context, issueNums []int) error {
 
 	fmt.Println("\n🤖 Delegating fixes to Copilot coding agent...")
 
-	// Get repo info
-	owner, repo, err := getRepoInfo()
-	if err != nil {
-		return fmt.Errorf("failed to get repo info: %w", err)
-	}
-
-	label := os.Getenv("AUTOENGINEER_LABEL")
-	if label == "" {
-		label = "autoengineer"
-	}
-
-	// Create issues client for adding delegated labels
-	issuesClient, err := issues.NewClient(owner, repo, label)
+	t, err := newTracker()
 	if err != nil {
-		return fmt.Errorf("failed to create issues client: %w", err)
+		return err
 	}
+	issuesClient := t.client
 
 	// Ensure delegated label exists
 	if err := issuesClient.EnsureDelegatedLabel(ctx); err != nil {
@@ -1,12 +1,24 @@
 package main
 
 import (
+	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/liam-witterick/autoengineer/go/internal/analysis"
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/copilot"
 	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+	"github.com/liam-witterick/autoengineer/go/internal/shard"
+	"github.com/liam-witterick/autoengineer/go/internal/verify"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func TestSaveAndLoadFindings(t *testing.T) {
@@ -123,3 +135,504 @@ func TestLoadFindings_EmptyFile(t *testing.T) {
 		t.Errorf("expected 0 findings for empty array, got %d", len(loadedFindings))
 	}
 }
+
+// shardClient answers each shard prompt with one finding and records how many prompts ran at once
+type shardClient struct {
+	mu       sync.Mutex
+	running  int
+	peak     int
+	prompts  []string
+	findings map[string]findings.Finding
+}
+
+func (c *shardClient) RunAnalysis(ctx context.Context, prompt string) ([]findings.Finding, error) {
+	c.mu.Lock()
+	c.running++
+	if c.running > c.peak {
+		c.peak = c.running
+	}
+	c.prompts = append(c.prompts, prompt)
+	c.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running--
+
+	for dir, f := range c.findings {
+		if strings.Contains(prompt, "files under "+dir+"/") {
+			return []findings.Finding{f}, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestRunAnalysisSharded(t *testing.T) {
+	registry, err := analysis.NewRegistry(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := []string{"api/main.tf", "web/Dockerfile", "db/main.tf", "docs/guide.md"}
+	inv := inventory.Inventory{Entries: []inventory.Entry{
+		{Technology: inventory.Terraform, Files: []string{"api/main.tf", "db/main.tf"}},
+		{Technology: inventory.Dockerfile, Files: []string{"web/Dockerfile"}},
+	}}
+	shards, err := shard.Partition(files, shard.ModeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &shardClient{findings: map[string]findings.Finding{
+		"api": {Title: "Public load balancer listener", Severity: "high", Files: []string{"api/main.tf"}},
+		"web": {Title: "Container runs as root user", Severity: "medium", Files: []string{"web/Dockerfile"}},
+		"db":  {Title: "Database snapshot retention disabled", Severity: "low", Files: []string{"db/main.tf"}},
+	}}
+	base := analysis.BaseAnalyzer{Client: client, Files: files, Inventory: inv}
+
+	// docs has nothing for the infra scope to analyze
+	jobs := planJobs(registry, []string{"infra"}, shards, inv, true)
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %d", len(jobs))
+	}
+	if jobs[0].label() != "infra [api]" {
+		t.Errorf("label() = %q", jobs[0].label())
+	}
+
+	results, err := runAnalysis(context.Background(), registry, jobs, base, 2, nil)
+	if err != nil {
+		t.Fatalf("runAnalysis() error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Errorf("expected 3 merged findings, got %d", len(results))
+	}
+	if client.peak > 2 {
+		t.Errorf("expected at most 2 concurrent prompts, got %d", client.peak)
+	}
+	for _, f := range results {
+		if f.Category != findings.CategoryInfra {
+			t.Errorf("finding %q should be categorized as infra, got %s", f.Title, f.Category)
+		}
+	}
+}
+
+func TestProjectAttributionAndDisabledScopes(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	os.MkdirAll("services/payments", 0755)
+	os.WriteFile("services/payments/autoengineer.yaml", []byte("name: payments\ndisabled_scopes: [pipeline]\n"), 0644)
+
+	projects, err := config.LoadProjects(&config.IgnoreConfig{}, []string{"services/payments/autoengineer.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := analysis.NewRegistry(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := []findings.Finding{
+		{Title: "Unpinned deploy action", Category: findings.CategoryPipeline, Files: []string{"services/payments/.github/deploy.yml"}},
+		{Title: "Unencrypted bucket", Category: findings.CategorySecurity, Files: []string{"services/payments/s3.tf"}},
+		{Title: "Unpinned checkout", Category: findings.CategoryPipeline, Files: []string{".github/workflows/ci.yml"}},
+		// Checkov reports paths with a leading /
+		{Title: "Public bucket", Category: findings.CategorySecurity, Files: []string{"/services/payments/public.tf"}, Source: "checkov"},
+	}
+
+	attributeProjects(all, projects)
+	if all[0].Project != "payments" || all[1].Project != "payments" || all[2].Project != "" || all[3].Project != "payments" {
+		t.Fatalf("unexpected attribution: %q, %q, %q, %q", all[0].Project, all[1].Project, all[2].Project, all[3].Project)
+	}
+
+	kept, dropped := dropDisabledScopes(all, projects, registry)
+	if dropped != 1 || len(kept) != 3 {
+		t.Fatalf("expected the payments pipeline finding to be dropped, kept %d and dropped %d", len(kept), dropped)
+	}
+	if kept[0].Title != "Unencrypted bucket" || kept[1].Title != "Unpinned checkout" || kept[2].Title != "Public bucket" {
+		t.Errorf("unexpected findings kept: %+v", kept)
+	}
+}
+
+// dedupBackend answers the deduplication prompt with a canned response
+type dedupBackend struct {
+	response string
+}
+
+func (b dedupBackend) Name() string { return "fake" }
+
+func (b dedupBackend) Complete(ctx context.Context, prompt string) (string, error) {
+	return b.response, nil
+}
+
+// scannerFindings are what Checkov and Trivy report for modules/s3/main.tf
+var scannerFindings = []findings.Finding{
+	{
+		Title: "Ensure the S3 bucket has access logging enabled", Category: findings.CategorySecurity, Severity: findings.SeverityHigh,
+		Files: []string{"modules/s3/main.tf"}, Rule: "CKV_AWS_18", Source: "checkov",
+		CodeSnippets: []findings.CodeSnippet{{File: "modules/s3/main.tf", StartLine: 3, EndLine: 5, Code: `resource "aws_s3_bucket" "logs" {`}},
+	},
+	{
+		Title: "S3 bucket does not have logging enabled", Category: findings.CategorySecurity, Severity: findings.SeverityMedium,
+		Files: []string{"modules/s3/main.tf"}, Rule: "AVD-AWS-0089", Source: "trivy",
+	},
+	{
+		Title: "S3 bucket is publicly readable", Category: findings.CategorySecurity, Severity: findings.SeverityCritical,
+		Files: []string{"modules/s3/main.tf"}, Rule: "AVD-AWS-0086", Source: "trivy",
+	},
+}
+
+// scannerDedupResponse merges the two logging findings and leaves out the
+// rule, source, tags and exact snippets, as models do
+const scannerDedupResponse = `[{"id": "1", "merged_ids": ["1", "2"], "category": "security", "title": "S3 bucket access logging disabled", "severity": "high", "files": ["modules/s3/main.tf"]}, ` +
+	`{"id": "3", "category": "security", "title": "S3 bucket is publicly readable", "severity": "critical", "files": ["modules/s3/main.tf"]}]`
+
+// runScanPipeline deduplicates findings with the canned response, then
+// applies the configuration the way a scan does, in a repository whose
+// modules/s3/main.tf has the given contents
+func runScanPipeline(t *testing.T, all []findings.Finding, ignore *config.IgnoreConfig, overrides []config.SeverityOverride, mainTF string) ([]findings.Finding, int) {
+	t.Helper()
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	os.Chdir(t.TempDir())
+	os.MkdirAll("modules/s3", 0755)
+	os.WriteFile("modules/s3/main.tf", []byte(mainTF), 0644)
+
+	registry, err := analysis.NewRegistry(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	projects, err := config.LoadProjects(ignore, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := &scanContext{
+		cfg:               ignore,
+		projects:          projects,
+		registry:          registry,
+		severityOverrides: overrides,
+		llmClient:         copilot.NewClientWithBackend(dedupBackend{response: scannerDedupResponse}),
+	}
+
+	deduplicated := sc.deduplicate(context.Background(), append([]findings.Finding(nil), all...), nil)
+	if len(deduplicated) != 2 {
+		t.Fatalf("expected deduplication to merge the logging findings, got %+v", deduplicated)
+	}
+	filtered, ignored, _ := sc.applyConfig(deduplicated)
+	return filtered, ignored
+}
+
+func TestSeverityOverridesByRuleAfterDeduplication(t *testing.T) {
+	overrides := []config.SeverityOverride{
+		{Rule: "ckv_aws_18", Severity: findings.SeverityLow},
+		{Rule: "AVD-AWS-0086", Severity: findings.SeverityHigh},
+	}
+	filtered, _ := runScanPipeline(t, scannerFindings, &config.IgnoreConfig{}, overrides, "")
+
+	if len(filtered) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", filtered)
+	}
+	logging, public := filtered[0], filtered[1]
+	if logging.Severity != findings.SeverityLow || logging.OriginalSeverity != findings.SeverityHigh {
+		t.Errorf("expected the merged finding's rule override to apply, got %s (originally %q)", logging.Severity, logging.OriginalSeverity)
+	}
+	if public.Severity != findings.SeverityHigh || public.OriginalSeverity != findings.SeverityCritical {
+		t.Errorf("expected the Trivy rule override to apply, got %s (originally %q)", public.Severity, public.OriginalSeverity)
+	}
+}
+
+func TestInlineSuppressionAfterDeduplication(t *testing.T) {
+	mainTF := "# Access logs go to the central account\n# autoengineer:ignore CKV_AWS_18 reason=logged by CloudTrail\nresource \"aws_s3_bucket\" \"logs\" {\n  bucket = \"logs\"\n}\n"
+	filtered, _ := runScanPipeline(t, scannerFindings, &config.IgnoreConfig{}, nil, mainTF)
+
+	if len(filtered) != 1 || filtered[0].Rule != "AVD-AWS-0086" {
+		t.Errorf("expected the annotated Checkov rule to suppress the merged finding, got %+v", filtered)
+	}
+}
+
+func TestFilterBySourceAfterDeduplication(t *testing.T) {
+	filtered, _ := runScanPipeline(t, scannerFindings, &config.IgnoreConfig{}, nil, "")
+
+	expr, err := findings.ParseExpression(`source == "trivy"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The merged logging finding keeps the source of its Checkov rule
+	trivy := findings.FilterByExpression(filtered, expr)
+	if len(trivy) != 1 || trivy[0].Title != "S3 bucket is publicly readable" {
+		t.Errorf("expected the Trivy finding to match, got %+v", trivy)
+	}
+	if filtered[0].Source != "checkov" {
+		t.Errorf("expected the merged finding to keep its source, got %q", filtered[0].Source)
+	}
+}
+
+func TestUnverifiedTagSurvivesDeduplication(t *testing.T) {
+	verifier := verify.New(t.TempDir(), &config.VerificationConfig{Mode: config.VerificationModeFlag})
+	verified := verifier.Verify([]findings.Finding{
+		{Title: "Lambda role can assume any role", Category: findings.CategorySecurity, Severity: findings.SeverityHigh, Files: []string{"modules/lambda/iam.tf"}},
+	})
+	if verified.Flagged != 1 {
+		t.Fatalf("expected the finding to be flagged, got %+v", verified)
+	}
+
+	response := `[{"id": "1", "category": "security", "title": "Lambda role can assume any role", "severity": "high", "files": ["modules/lambda/iam.tf"]}]`
+	sc := &scanContext{llmClient: copilot.NewClientWithBackend(dedupBackend{response: response})}
+	deduplicated := sc.deduplicate(context.Background(), verified.Findings, nil)
+
+	if len(deduplicated) != 1 || !deduplicated[0].HasTag(findings.TagUnverified) {
+		t.Errorf("expected the unverified tag to survive deduplication, got %+v", deduplicated)
+	}
+}
+
+func TestScanFlagsAliasRoot(t *testing.T) {
+	root := &cobra.Command{Use: "autoengineer"}
+	addScanFlags(root)
+	scan := newScanCmd()
+
+	scan.Flags().VisitAll(func(f *pflag.Flag) {
+		if root.Flags().Lookup(f.Name) == nil {
+			t.Errorf("scan flag --%s is missing from the root command", f.Name)
+		}
+	})
+}
+
+func TestLoadSelectedFindings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.json")
+	if err := findings.SaveFile([]findings.Finding{
+		{Title: "Public bucket", Severity: findings.SeverityHigh, Baseline: findings.BaselineExisting},
+		{Title: "Open security group", Severity: findings.SeverityHigh, Baseline: findings.BaselineNew},
+		{Title: "Missing tags", Severity: findings.SeverityLow, Baseline: findings.BaselineNew},
+	}, path); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(file, severity string, newOnly bool) {
+		flagFindingsFile, flagMinSeverity, flagNewOnly = file, severity, newOnly
+	}(flagFindingsFile, flagMinSeverity, flagNewOnly)
+	flagFindingsFile, flagMinSeverity, flagNewOnly = path, findings.SeverityHigh, true
+
+	selected, err := loadSelectedFindings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selected) != 1 || selected[0].Title != "Open security group" {
+		t.Errorf("expected only the new high-severity finding, got %+v", selected)
+	}
+
+	flagMinSeverity = "urgent"
+	if _, err := loadSelectedFindings(); err == nil {
+		t.Error("expected an invalid --min-severity to be rejected")
+	}
+}
+
+func TestLoadSelectedFindingsFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.json")
+	if err := findings.SaveFile([]findings.Finding{
+		{Title: "Public bucket", Category: findings.CategorySecurity, Severity: findings.SeverityHigh, Source: "trivy", Files: []string{"modules/s3/main.tf"}},
+		{Title: "Open security group", Category: findings.CategorySecurity, Severity: findings.SeverityHigh, Source: "checkov", Files: []string{"modules/sg/main.tf"}},
+		{Title: "Missing tags", Category: findings.CategoryInfra, Severity: findings.SeverityLow, Files: []string{"modules/vpc/main.tf"}},
+	}, path); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(file, severity, filter string, newOnly bool) {
+		flagFindingsFile, flagMinSeverity, flagFilter, flagNewOnly = file, severity, filter, newOnly
+	}(flagFindingsFile, flagMinSeverity, flagFilter, flagNewOnly)
+	flagFindingsFile, flagMinSeverity, flagNewOnly = path, "", false
+	flagFilter = `category == "security" && file =~ "^modules/" && source == trivy`
+
+	selected, err := loadSelectedFindings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selected) != 1 || selected[0].Title != "Public bucket" {
+		t.Errorf("expected only the trivy finding, got %+v", selected)
+	}
+
+	flagFilter = `severity >= urgent`
+	_, err = loadSelectedFindings()
+	if err == nil || !strings.Contains(err.Error(), `invalid --filter "severity >= urgent": column 13: unknown severity "urgent"`) {
+		t.Errorf("expected an invalid --filter to be rejected, got %v", err)
+	}
+}
+
+func TestResolveSettingsLayering(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".github", "autoengineer.yaml"), []byte(`defaults:
+  scope: security
+  min_severity: medium
+  output: saved.json
+  label: devops
+profiles:
+  ci:
+    min_severity: high
+    scan_only: true
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	defer func(scope, severity, output, label, profile, file string, scanOnly bool) {
+		flagScope, flagMinSeverity, flagOutput, flagLabel, flagProfile, flagFindingsFile, flagScanOnly = scope, severity, output, label, profile, file, scanOnly
+	}(flagScope, flagMinSeverity, flagOutput, flagLabel, flagProfile, flagFindingsFile, flagScanOnly)
+
+	t.Setenv("AUTOENGINEER_SCOPE", "infra")
+	t.Setenv(config.ProfileEnv, "")
+
+	root := &cobra.Command{Use: "autoengineer"}
+	addSettingsFlags(root)
+	scan := newScanCmd()
+	root.AddCommand(scan)
+	root.AddCommand(newIssuesCmd())
+
+	if err := scan.ParseFlags([]string{"--profile", "ci", "--output", "cli.json"}); err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := resolveSettings(scan, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sources := make(map[string]string)
+	for _, r := range resolved {
+		sources[r.Flag] = r.Source
+	}
+	want := map[string]string{
+		"scope":        "AUTOENGINEER_SCOPE",
+		"min-severity": "profile ci",
+		"scan-only":    "profile ci",
+		"label":        "defaults",
+		"output":       "flag",
+		"shard":        "default",
+	}
+	for flag, source := range want {
+		if sources[flag] != source {
+			t.Errorf("--%s source = %q, want %q", flag, sources[flag], source)
+		}
+	}
+	if flagScope != "infra" || flagMinSeverity != "high" || !flagScanOnly || flagLabel != "devops" || flagOutput != "cli.json" {
+		t.Errorf("unexpected flag values: scope=%s min-severity=%s scan-only=%v label=%s output=%s",
+			flagScope, flagMinSeverity, flagScanOnly, flagLabel, flagOutput)
+	}
+
+	// Commands reading the findings file use the output setting
+	create, _, err := root.Find([]string{"issues", "create"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := create.ParseFlags(nil); err != nil {
+		t.Fatal(err)
+	}
+	flagProfile = ""
+	if err := applySettings(create); err != nil {
+		t.Fatal(err)
+	}
+	if flagFindingsFile != "saved.json" {
+		t.Errorf("--findings = %s, want the output setting saved.json", flagFindingsFile)
+	}
+
+	flagProfile = "nightly"
+	if _, err := resolveSettings(scan, false); err == nil || !strings.Contains(err.Error(), "defined profiles: ci") {
+		t.Errorf("expected an unknown profile error listing ci, got %v", err)
+	}
+}
+
+func TestSettingsSkippedForConfigValidate(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".github", "autoengineer.yaml"), []byte("defaults:\n  scope: [security\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+	t.Setenv(config.ProfileEnv, "")
+
+	root := &cobra.Command{Use: "autoengineer"}
+	addSettingsFlags(root)
+	scan := newScanCmd()
+	root.AddCommand(scan)
+	root.AddCommand(newConfigCmd())
+	root.AddCommand(newInitCmd())
+
+	for _, path := range [][]string{{"config", "validate"}, {"config", "schema"}, {"init"}} {
+		cmd, _, err := root.Find(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := root.PersistentPreRunE(cmd, nil); err != nil {
+			t.Errorf("%s: expected the settings to be skipped, got %v", strings.Join(path, " "), err)
+		}
+	}
+
+	if err := root.PersistentPreRunE(scan, nil); err == nil {
+		t.Error("expected scan to stop on the broken config file")
+	}
+	if !scan.SilenceUsage {
+		t.Error("expected a config error to silence the usage message")
+	}
+}
+
+// captureConfigShow runs config show and returns what it printed
+func captureConfigShow(t *testing.T, show *cobra.Command) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	runErr := runConfigShow(show, nil)
+	os.Stdout = stdout
+	w.Close()
+	data, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	return string(data)
+}
+
+func TestConfigShowWithoutIgnoreFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	root := &cobra.Command{Use: "autoengineer"}
+	addSettingsFlags(root)
+	root.AddCommand(newConfigCmd())
+	show, _, err := root.Find([]string{"config", "show"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureConfigShow(t, show)
+	if !strings.Contains(out, "# .github/autoengineer-ignore.yaml (not found)\naccepted: [] # default\nignore_paths: [] # default\n") {
+		t.Errorf("expected the ignore defaults to be labelled default, got:\n%s", out)
+	}
+
+	if err := os.MkdirAll(".github", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(".github", "autoengineer-ignore.yml"), []byte("ignore_paths:\n  - docs/**\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out = captureConfigShow(t, show)
+	if !strings.Contains(out, "- docs/** # .github/autoengineer-ignore.yml\n") {
+		t.Errorf("expected the ignore values to be labelled with the file they came from, got:\n%s", out)
+	}
+}
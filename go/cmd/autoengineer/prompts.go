@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/analysis"
+	"github.com/liam-witterick/autoengineer/go/internal/copilot"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+	"github.com/liam-witterick/autoengineer/go/internal/issues"
+	"github.com/liam-witterick/autoengineer/go/internal/prompts"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagPromptsOffline  bool
+	flagPromptsFindings string
+)
+
+// newPromptsCmd builds the `prompts` command for inspecting prompt templates
+func newPromptsCmd() *cobra.Command {
+	promptsCmd := &cobra.Command{
+		Use:   "prompts",
+		Short: "Inspect the LLM prompt templates",
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show [template]",
+		Short: "Render the effective prompt for a template, or list templates and their source",
+		Long: `Render the effective prompt for a template, exactly as it would be sent to the LLM backend.
+
+Templates are embedded in the binary and can be overridden by a file of the same
+name in ` + prompts.OverrideDir + `/ (e.g. security.tmpl).
+
+Templates: security, pipeline, infra, dedup and one per custom scope`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runPromptsShow,
+	}
+	showCmd.Flags().StringVar(&flagInstructions, "instructions", "", "Path to custom instructions file")
+	showCmd.Flags().StringVar(&flagInstructionsText, "instructions-text", "", "Custom instructions as text")
+	showCmd.Flags().BoolVar(&flagPromptsOffline, "offline", false, "Don't fetch existing tracked issues from GitHub")
+	showCmd.Flags().StringVar(&flagPromptsFindings, "findings", "./findings.json", "Findings file used to render the dedup template")
+
+	promptsCmd.AddCommand(showCmd)
+	return promptsCmd
+}
+
+func runPromptsShow(cmd *cobra.Command, args []string) error {
+	registry, err := loadScopeRegistry()
+	if err != nil {
+		return err
+	}
+
+	promptSet, err := prompts.Load(prompts.OverrideDir, registry.CustomNames()...)
+	if err != nil {
+		return fmt.Errorf("failed to load prompt templates: %w", err)
+	}
+
+	if len(args) == 0 {
+		fmt.Println("📝 Prompt templates:")
+		for _, name := range promptSet.Names() {
+			fmt.Printf("   %-10s %s\n", name, promptSet.Source(name))
+		}
+		return nil
+	}
+
+	name := args[0]
+	scope, isScope := registry.Get(name)
+	if name != prompts.NameDedup && !isScope {
+		return fmt.Errorf("unknown prompt template %q (must be one of: %s)", name, strings.Join(promptSet.Names(), ", "))
+	}
+
+	existingIssues := fetchExistingIssuesForPrompt()
+
+	var prompt string
+	if name == prompts.NameDedup {
+		var toDedup []findings.Finding
+		if _, err := os.Stat(flagPromptsFindings); err == nil {
+			toDedup, err = loadFindings(flagPromptsFindings)
+			if err != nil {
+				return err
+			}
+		}
+
+		client := copilot.NewClient()
+		client.Prompts = promptSet
+		prompt, err = client.DeduplicationPrompt(toDedup, existingIssues)
+	} else {
+		extraContext, loadErr := loadExtraContext()
+		if loadErr != nil {
+			return loadErr
+		}
+
+		files := listRepoFiles()
+		base := analysis.BaseAnalyzer{
+			Prompts:        promptSet,
+			ExtraContext:   extraContext,
+			ExistingIssues: existingIssues,
+			Files:          files,
+			Inventory:      inventory.Detect(".", files),
+		}
+		prompt, err = base.BuildPrompt(scope)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(prompt)
+	return nil
+}
+
+// fetchExistingIssuesForPrompt lists open tracked issues, warning on stderr
+// instead of failing so templates can still be rendered without access to GitHub
+func fetchExistingIssuesForPrompt() []issues.SearchResult {
+	if flagPromptsOffline {
+		return nil
+	}
+
+	owner, repo, err := getRepoInfo()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Rendering without existing issues: %v\n", err)
+		return nil
+	}
+
+	client, err := newIssuesClient(owner, repo, flagLabel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Rendering without existing issues: %v\n", err)
+		return nil
+	}
+
+	existingIssues, err := client.ListOpenIssues(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Rendering without existing issues: %v\n", err)
+		return nil
+	}
+	return existingIssues
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/spf13/cobra"
+)
+
+var flagReportFormat string
+
+// newReportCmd builds the `report` command
+func newReportCmd() *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Render the findings in a findings file",
+		Long: `Render the findings in a findings file saved by scan as text, JSON or
+markdown (e.g. for a pull request comment or job summary).`,
+		Args: cobra.NoArgs,
+		RunE: runReport,
+	}
+	reportCmd.Flags().StringVar(&flagFindingsFile, "findings", defaultFindingsFile, "Findings file to read")
+	reportCmd.Flags().StringVar(&flagReportFormat, "format", formatText, "Output format (text|json|markdown)")
+	reportCmd.Flags().StringVar(&flagMinSeverity, "min-severity", "info", "Only report findings at or above this severity level (info|low|medium|high|critical)")
+	reportCmd.Flags().StringVar(&flagFilter, "filter", "", `Only report findings matching this expression, e.g. 'severity >= medium && file =~ "^modules/"'`)
+	reportCmd.Flags().BoolVar(&flagNewOnly, "new-only", false, "Only report findings the scan marked new relative to the baseline")
+	return withSettings(reportCmd)
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(flagReportFormat); err != nil {
+		return err
+	}
+
+	selected, err := loadSelectedFindings()
+	if err != nil {
+		return err
+	}
+
+	switch flagReportFormat {
+	case formatJSON:
+		if selected == nil {
+			selected = []findings.Finding{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(selected)
+	case formatMarkdown:
+		findings.WriteMarkdown(os.Stdout, selected)
+	default:
+		findings.DisplaySummary(selected)
+		fmt.Println()
+		findings.DisplayFindings(selected, findings.DetailedDisplayOptions())
+	}
+	return nil
+}
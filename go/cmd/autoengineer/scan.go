@@ -0,0 +1,641 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liam-witterick/autoengineer/go/internal/analysis"
+	"github.com/liam-witterick/autoengineer/go/internal/baseline"
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/copilot"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/gate"
+	"github.com/liam-witterick/autoengineer/go/internal/interactive"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+	"github.com/liam-witterick/autoengineer/go/internal/issues"
+	"github.com/liam-witterick/autoengineer/go/internal/prompts"
+	"github.com/liam-witterick/autoengineer/go/internal/scanner"
+	"github.com/liam-witterick/autoengineer/go/internal/shard"
+	"github.com/liam-witterick/autoengineer/go/internal/suppress"
+	"github.com/liam-witterick/autoengineer/go/internal/verify"
+	"github.com/spf13/cobra"
+)
+
+// newScanCmd builds the `scan` command. The root command's flags are a
+// backwards-compatible alias for it.
+func newScanCmd() *cobra.Command {
+	scanCmd := &cobra.Command{
+		Use:   "scan",
+		Short: "Analyze the repository, then review, track or delegate the findings",
+		Long: `Analyze the repository with the LLM backend and external scanners, save the
+findings, and review them interactively (or create issues with --create-issues).
+
+Use --scan-only to stop after saving the findings; the issues create, delegate,
+status and report commands then work on the saved findings file.`,
+		Args: cobra.NoArgs,
+		RunE: runScan,
+	}
+	addScanFlags(scanCmd)
+	return withSettings(scanCmd)
+}
+
+// addScanFlags registers the scan flags on a command
+func addScanFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&flagAuto, "auto", false, "[DEPRECATED] Use --create-issues instead")
+	cmd.Flags().BoolVar(&flagCreateIssues, "create-issues", false, "Skip prompts and create GitHub issues automatically")
+	cmd.Flags().BoolVar(&flagDelegate, "delegate", false, "Skip prompts and delegate fixes to Copilot coding agent (requires --create-issues)")
+	cmd.Flags().StringVar(&flagMinSeverity, "min-severity", "info", "Only action findings at or above this severity level (info|low|medium|high|critical)")
+	cmd.Flags().StringVar(&flagFilter, "filter", "", `Only action findings matching this expression, e.g. 'severity >= medium && file =~ "^modules/"'`)
+	cmd.Flags().StringVar(&flagOutput, "output", "./findings.json", "Save findings to specified file")
+	cmd.Flags().BoolVar(&flagForce, "force", false, "Create issues even if duplicates exist")
+	cmd.Flags().StringVar(&flagScope, "scope", "all", "Run focused analysis (security|pipeline|infra|<custom scope>|all)")
+	cmd.Flags().BoolVar(&flagNoScanners, "no-scanners", false, "Skip external scanner integration")
+	cmd.Flags().BoolVar(&flagFast, "fast", false, "Fast mode - skip external scanners (alias for --no-scanners)")
+	cmd.Flags().StringVar(&flagInstructions, "instructions", "", "Path to custom instructions file")
+	cmd.Flags().StringVar(&flagInstructionsText, "instructions-text", "", "Custom instructions as text")
+	cmd.Flags().BoolVar(&flagUseExistingFindings, "use-existing-findings", false, "Load findings from file instead of running a new scan")
+	cmd.Flags().StringVar(&flagRecord, "record", "", "Record LLM prompts/responses, GitHub API exchanges and scanner results to a cassette directory")
+	cmd.Flags().StringVar(&flagReplay, "replay", "", "Replay LLM, GitHub API and scanner interactions from a cassette directory (offline)")
+	cmd.Flags().StringVar(&flagShard, "shard", shard.ModeNone, "Run each scope per shard of the repo (none|dir|project)")
+	cmd.Flags().IntVar(&flagShardWorkers, "shard-workers", 4, "Maximum number of shard prompts run at once")
+	cmd.Flags().BoolVar(&flagScanOnly, "scan-only", false, "Save and show findings, then exit without prompting or creating issues")
+	cmd.Flags().StringVar(&flagFailOn, "fail-on", "", "Exit with code 2 when findings are at or above this severity (info|low|medium|high|critical)")
+	cmd.Flags().BoolVar(&flagFailOnNew, "fail-on-new", false, "Only fail on findings not in the baseline or tracked as issues (with --fail-on, or any severity)")
+	cmd.Flags().StringVar(&flagBaseline, "baseline", baseline.DefaultPath, "Baseline file marking findings as new or existing (used when it exists; \"\" to disable)")
+	cmd.Flags().BoolVar(&flagNewOnly, "new-only", false, "Only show and action findings that are not in the baseline")
+	cmd.Flags().IntVar(&flagExpiringWithin, "expiring-within", 30, "Warn about accepted risks expiring within this many days")
+}
+
+// scanContext is the configuration a scan loads before analysis starts
+type scanContext struct {
+	cfg               *config.IgnoreConfig
+	files             []string
+	projects          *config.Projects
+	scannerCfg        *config.ScannerConfig
+	verifyCfg         *config.VerificationConfig
+	severityOverrides []config.SeverityOverride
+	llmClient         *copilot.Client
+	registry          *analysis.Registry
+	promptSet         *prompts.Set
+	extraContext      string
+	baseline          *baseline.Baseline
+}
+
+func runScan(cmd *cobra.Command, args []string) error {
+	policy, err := validateScanFlags()
+	if err != nil {
+		return err
+	}
+
+	// Ensure we're in a git repo
+	if !isGitRepo() {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	sc, err := loadScanContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Check if scope is disabled
+	if flagScope != "all" && sc.cfg.IsScopeDisabled(flagScope) {
+		fmt.Printf("⚠️  Scope '%s' is disabled in ignore config\n", flagScope)
+		return nil
+	}
+
+	ctx := context.Background()
+
+	// Fetch existing tracked issues before analysis
+	fmt.Println("\n🔍 Fetching existing tracked issues...")
+	t, err := newTracker()
+	if err != nil {
+		return err
+	}
+	existingIssues := t.fetchExisting(ctx)
+
+	allFindings, scannerStatuses, inv, err := sc.collectFindings(ctx, existingIssues)
+	if err != nil {
+		return err
+	}
+
+	filtered, ignoredCount, suppressed := sc.applyConfig(allFindings)
+	if ignoredCount > 0 {
+		fmt.Printf("   Ignored %d finding(s) based on config\n", ignoredCount)
+	}
+
+	review := findings.ReviewAcceptances(sc.acceptedItems(), allFindings, time.Now(), time.Duration(flagExpiringWithin)*24*time.Hour)
+	if flagScope != "all" {
+		// Findings of the other scopes weren't looked for
+		review.Unused = nil
+	}
+	displayAcceptanceReview(review)
+	displaySuppressions(suppressed)
+
+	// Mark each finding new or existing relative to the baseline
+	var classification baseline.Classification
+	if sc.baseline != nil {
+		classification = sc.baseline.Classify(filtered)
+	}
+
+	// The CI gate looks at everything not ignored, regardless of --min-severity and --filter
+	gated := filtered
+
+	// Apply severity filtering
+	beforeSeverityFilter := len(filtered)
+	if flagMinSeverity != "" && flagMinSeverity != findings.SeverityInfo {
+		filtered = findings.FilterBySeverity(filtered, flagMinSeverity)
+		severityFilteredCount := beforeSeverityFilter - len(filtered)
+		if severityFilteredCount > 0 {
+			fmt.Printf("   Filtered %d finding(s) below %s severity\n", severityFilteredCount, flagMinSeverity)
+		}
+	}
+
+	// Apply the --filter expression
+	expr, err := parseFilter()
+	if err != nil {
+		return err
+	}
+	if expr != nil {
+		beforeExpressionFilter := len(filtered)
+		filtered = findings.FilterByExpression(filtered, expr)
+		if n := beforeExpressionFilter - len(filtered); n > 0 {
+			fmt.Printf("   Filtered %d finding(s) not matching --filter\n", n)
+		}
+	}
+
+	// Save findings to file (only when running new scan)
+	// We skip saving when using existing findings to avoid overwriting
+	// the original file with potentially filtered/modified results
+	if !flagUseExistingFindings {
+		if err := saveFindings(filtered, flagOutput); err != nil {
+			return fmt.Errorf("failed to save findings: %w", err)
+		}
+	}
+
+	// Saved findings keep existing ones; only the preview and actions drop them
+	if flagNewOnly {
+		filtered = newFindings(filtered)
+	}
+
+	// Display preview with existing issues
+	displayPreview(existingIssues, filtered, ignoredCount, inv, sc.projects)
+	if sc.baseline != nil {
+		displayBaseline(classification, flagScope)
+	}
+
+	// Decide the exit code before issues are created, so findings actioned
+	// by this run still count as new
+	gateResult := gate.Evaluate(policy, gated, func(f findings.Finding) bool {
+		return f.Baseline == findings.BaselineExisting || issues.Tracks(existingIssues, f)
+	}, scannerStatuses)
+	displayGate(gateResult)
+
+	if !flagScanOnly {
+		if err := actionFindings(ctx, filtered, existingIssues, t); err != nil {
+			return err
+		}
+	}
+
+	if err := gateResult.Err(); err != nil {
+		// A failed gate is an outcome, not a usage mistake
+		cmd.SilenceUsage = true
+		return err
+	}
+	return nil
+}
+
+// validateScanFlags checks flag values and combinations, returning the CI gate policy
+func validateScanFlags() (gate.Policy, error) {
+	// Handle --auto deprecation
+	if flagAuto {
+		fmt.Println("⚠️  --auto is deprecated. Use --create-issues instead.")
+		flagCreateIssues = true
+	}
+
+	// Validate flag combinations
+	if flagDelegate && !flagCreateIssues {
+		return gate.Policy{}, fmt.Errorf("--delegate requires --create-issues")
+	}
+	if flagScanOnly && flagCreateIssues {
+		return gate.Policy{}, fmt.Errorf("--scan-only cannot be used with --create-issues")
+	}
+
+	// Validate min-severity
+	if flagMinSeverity != "" && !findings.ValidateSeverity(flagMinSeverity) {
+		return gate.Policy{}, fmt.Errorf("invalid --min-severity: %s (must be info, low, medium, high, or critical)", flagMinSeverity)
+	}
+	if _, err := parseFilter(); err != nil {
+		return gate.Policy{}, err
+	}
+
+	if flagRecord != "" && flagReplay != "" {
+		return gate.Policy{}, fmt.Errorf("--record and --replay cannot be used together")
+	}
+
+	policy := gate.Policy{FailOn: flagFailOn, FailOnNew: flagFailOnNew}
+	if err := policy.Validate(); err != nil {
+		return gate.Policy{}, err
+	}
+
+	if err := shard.ValidateMode(flagShard); err != nil {
+		return gate.Policy{}, fmt.Errorf("invalid --shard: %s (must be %s)", flagShard, strings.Join(shard.Modes, "|"))
+	}
+	if flagShardWorkers < 1 {
+		return gate.Policy{}, fmt.Errorf("--shard-workers must be at least 1")
+	}
+	if flagExpiringWithin < 0 {
+		return gate.Policy{}, fmt.Errorf("--expiring-within must not be negative")
+	}
+
+	return policy, nil
+}
+
+// loadScanContext loads the configuration, LLM client, scopes, prompts and
+// baseline a scan needs, and starts recording or replaying if requested
+func loadScanContext(cmd *cobra.Command) (*scanContext, error) {
+	sc := &scanContext{}
+	var err error
+
+	// Load the baseline of findings that already exist
+	if flagBaseline != "" {
+		sc.baseline, err = baseline.Load(flagBaseline)
+		if err != nil {
+			return nil, err
+		}
+		if sc.baseline == nil && cmd.Flags().Changed("baseline") {
+			return nil, fmt.Errorf("baseline not found: %s", flagBaseline)
+		}
+	}
+	if flagNewOnly && sc.baseline == nil {
+		return nil, fmt.Errorf("--new-only requires a baseline (create one with: autoengineer baseline create)")
+	}
+
+	// Catch typos and invalid values before anything is loaded
+	sc.files = listRepoFiles()
+	if err := config.Validate(sc.files); err != nil {
+		return nil, err
+	}
+
+	// Load ignore configuration
+	sc.cfg, err = config.LoadIgnoreConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ignore config: %w", err)
+	}
+
+	// Load nested per-directory autoengineer.yaml projects
+	sc.projects, err = config.LoadProjects(sc.cfg, sc.files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	// Load scanner configuration
+	sc.scannerCfg, err = config.LoadScannerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scanner config: %w", err)
+	}
+
+	// Load LLM backend configuration
+	llmCfg, err := config.LoadLLMConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load llm config: %w", err)
+	}
+
+	sc.llmClient, err = copilot.NewClientFromConfig(llmCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create llm client: %w", err)
+	}
+
+	// Set up record/replay before any LLM or GitHub API traffic
+	if err := setupCassette(); err != nil {
+		return nil, err
+	}
+	if activeCassette != nil {
+		sc.llmClient.Backend = activeCassette.WrapBackend(sc.llmClient.Backend)
+	}
+
+	// Load hallucination guard configuration
+	sc.verifyCfg, err = config.LoadVerificationConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load verification config: %w", err)
+	}
+
+	sc.severityOverrides, err = config.LoadSeverityOverrides()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load severity overrides: %w", err)
+	}
+
+	// Load built-in and custom analysis scopes
+	sc.registry, err = loadScopeRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	if flagScope != "all" {
+		if _, ok := sc.registry.Get(flagScope); !ok {
+			return nil, fmt.Errorf("invalid --scope: %s (must be %s)", flagScope, sc.registry.Usage())
+		}
+	}
+
+	// Load prompt templates, applying repository overrides
+	sc.promptSet, err = prompts.Load(prompts.OverrideDir, sc.registry.CustomNames()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prompt templates: %w", err)
+	}
+	sc.llmClient.Prompts = sc.promptSet
+
+	// Load custom instructions
+	sc.extraContext, err = loadExtraContext()
+	if err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+// collectFindings loads findings from --output with --use-existing-findings,
+// or runs the analysis, scanners and deduplication
+func (sc *scanContext) collectFindings(ctx context.Context, existingIssues []issues.SearchResult) ([]findings.Finding, []scanner.ScannerStatus, inventory.Inventory, error) {
+	if flagUseExistingFindings {
+		fmt.Printf("\n📂 Loading findings from %s...\n", flagOutput)
+
+		loadedFindings, err := loadFindings(flagOutput)
+		if err != nil {
+			return nil, nil, inventory.Inventory{}, err
+		}
+
+		fmt.Printf("   Loaded %d finding(s)\n", len(loadedFindings))
+		return loadedFindings, nil, inventory.Inventory{}, nil
+	}
+
+	// Detect which technologies the repo uses
+	inv := inventory.Detect(".", sc.files)
+	displayInventory(inv)
+
+	// Everything the prompt templates can reference
+	base := analysis.BaseAnalyzer{
+		Client:         sc.llmClient,
+		Prompts:        sc.promptSet,
+		ExtraContext:   sc.extraContext,
+		ExistingIssues: existingIssues,
+		Files:          sc.files,
+		Inventory:      inv,
+		Projects:       sc.projects,
+	}
+
+	// Split large repos into shards analyzed separately
+	shards, err := shard.Partition(sc.files, flagShard)
+	if err != nil {
+		return nil, nil, inv, err
+	}
+	if shards != nil {
+		fmt.Printf("\n🧩 Sharding by %s: %d shard(s), up to %d prompt(s) at a time\n", flagShard, len(shards), flagShardWorkers)
+	}
+
+	// Run analysis with progress tracking
+	fmt.Println("\n🔍 Running analysis...")
+	fmt.Println()
+
+	// Determine if scanners should run
+	skipScanners := flagNoScanners || flagFast
+
+	allFindings, scannerStatuses, err := runAnalysisWithScanners(ctx, flagScope, sc.cfg, sc.scannerCfg, sc.registry, base, shards, flagShardWorkers, verify.New(".", sc.verifyCfg), skipScanners)
+	if err != nil {
+		return nil, nil, inv, fmt.Errorf("analysis failed: %w", err)
+	}
+
+	fmt.Println()
+
+	// Display scanner summary
+	if !skipScanners && len(scannerStatuses) > 0 {
+		displayScannerSummary(scannerStatuses)
+	}
+
+	return sc.deduplicate(ctx, allFindings, existingIssues), scannerStatuses, inv, nil
+}
+
+// deduplicate runs intelligent deduplication with the LLM backend, which
+// merges related findings across categories and filters out duplicates of
+// existing issues. Returns the findings unchanged if deduplication fails.
+func (sc *scanContext) deduplicate(ctx context.Context, allFindings []findings.Finding, existingIssues []issues.SearchResult) []findings.Finding {
+	if len(allFindings) == 0 {
+		return allFindings
+	}
+
+	fmt.Println()
+	fmt.Println("🔄 Deduplicating findings...")
+
+	deduplicated, err := sc.llmClient.RunDeduplication(ctx, allFindings, existingIssues)
+	if err != nil {
+		// Log the error but continue with original findings
+		fmt.Printf("   ⚠️  Warning: deduplication failed, continuing with original findings: %v\n", err)
+		return allFindings
+	}
+
+	deduplicatedCount := len(allFindings) - len(deduplicated)
+	if deduplicatedCount > 0 {
+		fmt.Printf("   Removed %d duplicate/related finding(s)\n", deduplicatedCount)
+	} else {
+		fmt.Printf("   No duplicates found\n")
+	}
+	return deduplicated
+}
+
+// applyConfig applies the repository's severity overrides, ignore config,
+// disabled scopes and inline suppressions to the collected findings. It
+// returns the findings left, how many the config ignored and those suppressed.
+func (sc *scanContext) applyConfig(allFindings []findings.Finding) ([]findings.Finding, int, []suppress.Suppressed) {
+	// Severity overrides apply first, so ignore rules and every later step
+	// see the adjusted severity
+	if overridden := findings.ApplySeverityOverrides(allFindings, sc.severityOverrides); overridden > 0 {
+		fmt.Printf("   Overrode the severity of %d finding(s) based on config\n", overridden)
+	}
+
+	// Attribute findings to projects, then filter each by its project's ignore config
+	attributeProjects(allFindings, sc.projects)
+	filtered, ignoredCount := findings.FilterWith(allFindings, func(f findings.Finding) *config.IgnoreConfig {
+		return sc.projects.Ignore(f.Project)
+	})
+	filtered, disabledCount := dropDisabledScopes(filtered, sc.projects, sc.registry)
+	ignoredCount += disabledCount
+
+	// Inline comments suppress findings next to the code they are about
+	filtered, suppressed := suppress.New(".").Apply(filtered)
+	return filtered, ignoredCount, suppressed
+}
+
+// tracker is the repository's GitHub issue tracker
+type tracker struct {
+	owner  string
+	repo   string
+	label  string
+	client *issues.Client
+}
+
+// newTracker connects to the issues of the repository's origin remote,
+// labelled with --label
+func newTracker() (*tracker, error) {
+	owner, repo, err := getRepoInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo info: %w", err)
+	}
+
+	client, err := newIssuesClient(owner, repo, flagLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issues client: %w", err)
+	}
+
+	return &tracker{owner: owner, repo: repo, label: flagLabel, client: client}, nil
+}
+
+// fetchExisting lists the open tracked issues, warning and returning none on failure
+func (t *tracker) fetchExisting(ctx context.Context) []issues.SearchResult {
+	existingIssues, err := t.client.ListOpenIssues(ctx)
+	if err != nil {
+		fmt.Printf("   ⚠️  Warning: failed to fetch existing issues: %v\n", err)
+		return []issues.SearchResult{}
+	}
+	fmt.Printf("   Found %d existing tracked issue(s)\n", len(existingIssues))
+	return existingIssues
+}
+
+// actionFindings creates issues automatically or starts the interactive session
+func actionFindings(ctx context.Context, filtered []findings.Finding, existingIssues []issues.SearchResult, t *tracker) error {
+	if len(filtered) == 0 && len(existingIssues) == 0 {
+		fmt.Println("\n✅ No findings to report!")
+		return nil
+	}
+
+	// Auto mode: create issues automatically
+	if flagCreateIssues {
+		issueNums, err := createIssuesAuto(ctx, filtered)
+		if err != nil {
+			return err
+		}
+
+		// Delegate to Copilot coding agent if requested
+		if flagDelegate && len(issueNums) > 0 {
+			return delegateIssues(ctx, issueNums)
+		}
+
+		return nil
+	}
+
+	// Interactive mode
+	session := interactive.NewSessionWithClient(filtered, t.owner, t.repo, t.label, t.client)
+	return session.Run(ctx)
+}
+
+// newFindings returns the findings not in the baseline
+func newFindings(all []findings.Finding) []findings.Finding {
+	var out []findings.Finding
+	for _, f := range all {
+		if f.Baseline != findings.BaselineExisting {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// displayBaseline shows how the run compares to the baseline. With a focused
+// scope, only baseline entries of that category can be reported as fixed.
+func displayBaseline(c baseline.Classification, scope string) {
+	var fixed []baseline.Entry
+	for _, e := range c.Fixed {
+		if scope == "all" || e.Category == scope {
+			fixed = append(fixed, e)
+		}
+	}
+
+	fmt.Printf("\n📏 Baseline:       🆕 %d new  📌 %d existing  ✅ %d fixed\n", len(c.New), len(c.Existing), len(fixed))
+	for _, e := range fixed {
+		fmt.Printf("   ✅ %s %s\n", findings.SeverityEmoji(e.Severity), e.Title)
+	}
+	if flagNewOnly && len(c.Existing) > 0 {
+		fmt.Printf("   Hiding %d existing finding(s) (--new-only)\n", len(c.Existing))
+	}
+}
+
+// acceptedItems returns the accepted risks of the root and project ignore configs
+func (sc *scanContext) acceptedItems() []config.AcceptedItem {
+	items := append([]config.AcceptedItem(nil), sc.cfg.Accepted...)
+	for _, p := range sc.projects.All() {
+		items = append(items, p.Ignore.Accepted...)
+	}
+	return items
+}
+
+// displayAcceptanceReview warns about expired, expiring and unused accepted risks
+func displayAcceptanceReview(r findings.AcceptanceReview) {
+	if r.Empty() {
+		return
+	}
+
+	fmt.Println()
+	if len(r.Expired) > 0 {
+		fmt.Printf("⏰ %d accepted risk(s) expired; their findings are reported again:\n", len(r.Expired))
+		for _, item := range r.Expired {
+			fmt.Printf("   - %s (expired %s%s)\n", item.Title, item.Expires, acceptedByNote(item))
+		}
+	}
+	if len(r.Expiring) > 0 {
+		fmt.Printf("⏳ %d accepted risk(s) expire within %d day(s), review them:\n", len(r.Expiring), flagExpiringWithin)
+		for _, item := range r.Expiring {
+			fmt.Printf("   - %s (expires %s%s)\n", item.Title, item.Expires, acceptedByNote(item))
+		}
+	}
+	if len(r.Unused) > 0 {
+		fmt.Printf("🧹 %d accepted risk(s) match no finding and can be removed:\n", len(r.Unused))
+		for _, item := range r.Unused {
+			fmt.Printf("   - %s\n", item.Title)
+		}
+	}
+}
+
+// acceptedByNote names who accepted a risk, if recorded
+func acceptedByNote(item config.AcceptedItem) string {
+	if item.AcceptedBy == "" {
+		return ""
+	}
+	return ", accepted by " + item.AcceptedBy
+}
+
+// displaySuppressions lists the findings suppressed by inline comments
+func displaySuppressions(suppressed []suppress.Suppressed) {
+	if len(suppressed) == 0 {
+		return
+	}
+
+	fmt.Printf("\n🔕 %d finding(s) suppressed by inline %s comments:\n", len(suppressed), suppress.Marker)
+	for _, s := range suppressed {
+		reason := s.Annotation.Reason
+		if reason == "" {
+			reason = "no reason given"
+		}
+		fmt.Printf("   - %s:%d %s %s (%s)\n", s.Annotation.File, s.Annotation.Line, findings.SeverityEmoji(s.Finding.Severity), s.Finding.Title, reason)
+	}
+}
+
+// displayGate shows the --fail-on / --fail-on-new verdict
+func displayGate(result gate.Result) {
+	if !result.Policy.Enabled() {
+		return
+	}
+
+	fmt.Println()
+	switch result.Code() {
+	case gate.ExitFindings:
+		fmt.Printf("🚫 Gate failed: %v\n", result.Err())
+		for _, f := range result.Failing {
+			fmt.Printf("   %s %s\n", findings.SeverityEmoji(f.Severity), f.Title)
+		}
+		if len(result.FailedScanners) > 0 {
+			fmt.Printf("   ⚠️  Scanner(s) also failed: %s\n", strings.Join(result.FailedScanners, ", "))
+		}
+	case gate.ExitScannerFailed:
+		fmt.Printf("⚠️  Gate incomplete: %v\n", result.Err())
+	default:
+		fmt.Println("✅ Gate passed")
+	}
+}
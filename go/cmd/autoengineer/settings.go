@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	flagProfile string
+	flagLabel   string
+)
+
+// settingsAnnotation marks commands whose flags take their defaults from the
+// settings in autoengineer.yaml and AUTOENGINEER_* environment variables
+const settingsAnnotation = "autoengineer/settings"
+
+// noSettingsAnnotation marks commands that run without loading the settings,
+// because they check or create the configuration files themselves
+const noSettingsAnnotation = "autoengineer/no-settings"
+
+// addSettingsFlags adds the global --profile and --label flags to the root
+// command and applies the settings before any command runs
+func addSettingsFlags(root *cobra.Command) {
+	root.PersistentFlags().StringVar(&flagProfile, "profile", "", "Apply a named profile of settings from .github/autoengineer.yaml")
+	root.PersistentFlags().StringVar(&flagLabel, "label", "autoengineer", "Label of the issues autoengineer creates and tracks")
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if cmd.Annotations[noSettingsAnnotation] != "" {
+			return nil
+		}
+		if err := applySettings(cmd); err != nil {
+			// A broken config file is not a usage error
+			cmd.SilenceUsage = true
+			return err
+		}
+		return nil
+	}
+	withSettings(root)
+}
+
+// withoutSettings marks a command as running without loading the settings
+func withoutSettings(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[noSettingsAnnotation] = "true"
+	return cmd
+}
+
+// withSettings marks a command as taking its flag defaults from the settings
+func withSettings(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[settingsAnnotation] = "true"
+	return cmd
+}
+
+// settingAliases map flags to the setting they read, where the names differ
+var settingAliases = map[string]string{
+	// Commands reading the findings file default to where scans save it
+	"findings": "output",
+}
+
+// Setting sources, from lowest to highest precedence; environment
+// variables are reported by name
+const (
+	sourceDefault  = "default"
+	sourceDefaults = "defaults"
+	sourceProfile  = "profile"
+	sourceFlag     = "flag"
+)
+
+// appliedSources records where the flags set by applySettings took their
+// values from, so they aren't reported as given on the command line
+var appliedSources = make(map[string]string)
+
+// resolvedSetting is a flag's effective value and where it came from
+type resolvedSetting struct {
+	Flag   string
+	Value  string
+	Source string
+}
+
+// applySettings fills the flags the user didn't give from AUTOENGINEER_*
+// environment variables, then the selected profile, then the defaults in
+// autoengineer.yaml. Flags set this way count as given.
+func applySettings(cmd *cobra.Command) error {
+	_, err := resolveSettings(cmd, true)
+	return err
+}
+
+// resolveSettings works out each setting flag's value and source, setting
+// the flags when apply is true
+func resolveSettings(cmd *cobra.Command, apply bool) ([]resolvedSetting, error) {
+	profile := flagProfile
+	if profile == "" {
+		profile = os.Getenv(config.ProfileEnv)
+	}
+
+	defaults, overrides, err := config.LoadSettings(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if apply {
+		appliedSources = make(map[string]string)
+	}
+
+	annotated := cmd.Annotations[settingsAnnotation] != ""
+	persistent := cmd.Root().PersistentFlags()
+
+	var resolved []resolvedSetting
+	var firstErr error
+	seen := make(map[string]bool)
+	visit := func(f *pflag.Flag) {
+		if seen[f.Name] {
+			return
+		}
+		seen[f.Name] = true
+
+		// Only global flags take settings on commands that don't opt in,
+		// since other commands reuse flag names with different meanings
+		if !annotated && persistent.Lookup(f.Name) != f {
+			return
+		}
+		name := config.SettingName(f.Name)
+		if alias, ok := settingAliases[f.Name]; ok {
+			name = alias
+		}
+		if !config.IsSetting(name) {
+			return
+		}
+
+		r := resolvedSetting{Flag: f.Name, Value: f.Value.String(), Source: sourceDefault}
+		if source, ok := appliedSources[f.Name]; ok && f.Changed {
+			r.Source = source
+		} else if f.Changed {
+			r.Source = sourceFlag
+		} else if value := os.Getenv(config.EnvName(name)); value != "" {
+			r.Value, r.Source = value, config.EnvName(name)
+		} else if value, ok := overrides.Value(name); ok {
+			r.Value, r.Source = value, sourceProfile+" "+profile
+		} else if value, ok := defaults.Value(name); ok {
+			r.Value, r.Source = value, sourceDefaults
+		}
+
+		if apply && r.Source != sourceDefault && r.Source != sourceFlag {
+			if err := cmd.Flags().Set(f.Name, r.Value); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("invalid %s from %s: %w", name, r.Source, err)
+			}
+			appliedSources[f.Name] = r.Source
+		}
+		resolved = append(resolved, r)
+	}
+	cmd.Flags().VisitAll(visit)
+
+	// The root command's own global flags are only merged into its flag set
+	// when it runs
+	persistent.VisitAll(visit)
+
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].Flag < resolved[j].Flag })
+	return resolved, firstErr
+}
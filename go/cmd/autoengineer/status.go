@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/issues"
+	"github.com/spf13/cobra"
+)
+
+// newStatusCmd builds the `status` command
+func newStatusCmd() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show tracked issues, their delegation, and which findings are tracked",
+		Args:  cobra.NoArgs,
+		RunE:  runStatus,
+	}
+	statusCmd.Flags().StringVar(&flagFindingsFile, "findings", defaultFindingsFile, "Findings file to compare with the tracked issues")
+	return withSettings(statusCmd)
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	fmt.Println("\n🔍 Fetching existing tracked issues...")
+	t, err := newTracker()
+	if err != nil {
+		return err
+	}
+	existingIssues, err := t.client.ListOpenIssues(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tracked issues: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("📋 TRACKED ISSUES (%s/%s, label %s)\n", t.owner, t.repo, t.label)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	delegated := 0
+	for _, issue := range existingIssues {
+		marker := ""
+		if issue.IsDelegated() {
+			marker = "  🤖 delegated"
+			delegated++
+		}
+		fmt.Printf("[#%d] %s%s\n", issue.Number, issue.Title, marker)
+	}
+	if len(existingIssues) > 0 {
+		fmt.Println()
+	}
+	fmt.Printf("Open: %d  Delegated: %d  Awaiting delegation: %d\n", len(existingIssues), delegated, len(existingIssues)-delegated)
+
+	// Compare the saved findings with the tracked issues
+	if _, err := os.Stat(flagFindingsFile); err != nil {
+		fmt.Printf("\n📂 No findings file at %s (run: autoengineer scan --scan-only)\n", flagFindingsFile)
+		return nil
+	}
+	all, err := loadFindings(flagFindingsFile)
+	if err != nil {
+		return err
+	}
+
+	tracked, baselined := 0, 0
+	for _, f := range all {
+		if issues.Tracks(existingIssues, f) {
+			tracked++
+		}
+		if f.Baseline == findings.BaselineExisting {
+			baselined++
+		}
+	}
+	fmt.Printf("\n📂 %s: %d finding(s), %d tracked, %d without an issue\n", flagFindingsFile, len(all), tracked, len(all)-tracked)
+	if baselined > 0 {
+		fmt.Printf("   📏 %d in the baseline\n", baselined)
+	}
+	return nil
+}
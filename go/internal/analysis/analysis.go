@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/liam-witterick/autoengineer/go/internal/copilot"
+	"github.com/liam-witterick/autoengineer/go/internal/config"
 	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
 	"github.com/liam-witterick/autoengineer/go/internal/issues"
+	"github.com/liam-witterick/autoengineer/go/internal/prompts"
+	"github.com/liam-witterick/autoengineer/go/internal/shard"
 )
 
 // Analyzer defines the interface for running scoped analyses
@@ -16,11 +19,78 @@ type Analyzer interface {
 	Scope() string
 }
 
+// Client runs an analysis prompt and returns the parsed findings.
+// copilot.Client implements it for every configured LLM backend.
+type Client interface {
+	RunAnalysis(ctx context.Context, prompt string) ([]findings.Finding, error)
+}
+
 // BaseAnalyzer provides common functionality for all analyzers
 type BaseAnalyzer struct {
-	Client          *copilot.Client
-	ExtraContext    string
-	ExistingContext string
+	Client         Client
+	Prompts        *prompts.Set
+	ExtraContext   string
+	ExistingIssues []issues.SearchResult
+	Files          []string
+	Inventory      inventory.Inventory
+
+	// Shard is the directory this analyzer is limited to ("" for the whole repository)
+	Shard string
+
+	// Projects adds the instructions of nested autoengineer.yaml projects
+	// covering the scope's files to the custom instructions
+	Projects *config.Projects
+}
+
+// ForShard returns a copy of the analyzer limited to one shard's files
+func (b BaseAnalyzer) ForShard(s shard.Shard) BaseAnalyzer {
+	b.Shard = s.Dir
+	b.Files = s.Files
+	b.Inventory = b.Inventory.Subset(s.Files)
+	return b
+}
+
+// BuildPrompt renders the prompt template for a scope
+func (b BaseAnalyzer) BuildPrompt(scope Scope) (string, error) {
+	set := b.Prompts
+	if set == nil {
+		set = prompts.Default()
+	}
+
+	files := scope.FilterFiles(b.Files)
+	return set.Render(scope.Name, prompts.AnalysisData{
+		Scope:           scope.Name,
+		Title:           scope.Title,
+		Category:        scope.Category,
+		Instructions:    scope.Instructions,
+		Globs:           scope.Globs,
+		ExistingIssues:  b.ExistingIssues,
+		ExistingContext: BuildExistingContext(b.ExistingIssues),
+		ExtraContext:    b.ExtraContext + b.Projects.Instructions(files),
+		Files:           files,
+		Inventory:       b.Inventory,
+		Shard:           b.Shard,
+	})
+}
+
+// run renders the scope's prompt, runs it and forces the scope's category on the results
+func (b BaseAnalyzer) run(ctx context.Context, scope Scope) ([]findings.Finding, error) {
+	prompt, err := b.BuildPrompt(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := b.Client.RunAnalysis(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure correct category
+	for i := range results {
+		results[i].Category = scope.Category
+	}
+
+	return results, nil
 }
 
 // BuildExistingContext creates a context string from existing issues to add to the analysis prompt
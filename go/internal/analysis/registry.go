@@ -0,0 +1,210 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+)
+
+// Scope describes an analysis scope: the prompt it runs, the files it looks
+// at and how its findings are categorized and displayed
+type Scope struct {
+	Name     string
+	Title    string
+	Category string
+	Emoji    string
+
+	// Globs limit the files the scope looks at (empty means all files)
+	Globs []string
+
+	// Instructions is the prompt of a custom scope
+	Instructions string
+
+	// Technologies are the inventory technologies the scope analyzes
+	// (empty means the scope applies to any repository)
+	Technologies []string
+
+	// Builtin is true for the scopes that ship with AutoEngineer
+	Builtin bool
+}
+
+// builtinScopes are the scopes with dedicated analyzers
+var builtinScopes = map[string]Scope{
+	"security": {Name: "security", Title: "Security", Category: findings.CategorySecurity, Emoji: "🔒", Builtin: true,
+		Technologies: []string{inventory.Terraform, inventory.Helm, inventory.Kubernetes, inventory.Dockerfile,
+			inventory.CloudFormation, inventory.Compose, inventory.Ansible}},
+	"pipeline": {Name: "pipeline", Title: "Pipeline", Category: findings.CategoryPipeline, Emoji: "⚙️", Builtin: true,
+		Technologies: []string{inventory.GitHubActions}},
+	"infra": {Name: "infra", Title: "Infrastructure", Category: findings.CategoryInfra, Emoji: "🏗️", Builtin: true,
+		Technologies: []string{inventory.Terraform, inventory.Helm, inventory.Kubernetes, inventory.Dockerfile,
+			inventory.CloudFormation, inventory.Compose, inventory.Ansible}},
+}
+
+// Applicable reports whether the repository has anything for the scope to
+// analyze: one of its technologies for built-in scopes, or a file matching
+// its globs for custom scopes
+func (s Scope) Applicable(inv inventory.Inventory, files []string) bool {
+	if len(s.Technologies) > 0 {
+		return inv.HasAny(s.Technologies...)
+	}
+	if len(s.Globs) > 0 {
+		return len(s.FilterFiles(files)) > 0
+	}
+	return true
+}
+
+// SkipReason explains why a non-applicable scope was skipped
+func (s Scope) SkipReason() string {
+	if len(s.Technologies) > 0 {
+		titles := make([]string, len(s.Technologies))
+		for i, tech := range s.Technologies {
+			titles[i] = inventory.Title(tech)
+		}
+		return "no " + strings.Join(titles, ", ") + " detected"
+	}
+	return "no files match " + strings.Join(s.Globs, ", ")
+}
+
+// FilterFiles returns the files matching the scope's globs (all files if it has none)
+func (s Scope) FilterFiles(files []string) []string {
+	if len(s.Globs) == 0 {
+		return files
+	}
+
+	var matched []string
+	for _, file := range files {
+		if config.MatchGlobs(s.Globs, file) {
+			matched = append(matched, file)
+		}
+	}
+	return matched
+}
+
+// Registry holds the built-in scopes followed by the scopes declared in autoengineer.yaml
+type Registry struct {
+	scopes []Scope
+}
+
+// NewRegistry creates a registry of the built-in scopes plus the custom ones
+func NewRegistry(custom []config.ScopeConfig) (*Registry, error) {
+	if err := config.ValidateScopes(custom); err != nil {
+		return nil, err
+	}
+
+	r := &Registry{}
+	for _, name := range config.BuiltinScopes {
+		r.scopes = append(r.scopes, builtinScopes[name])
+	}
+
+	for _, sc := range custom {
+		instructions, err := sc.LoadPrompt()
+		if err != nil {
+			return nil, err
+		}
+
+		r.scopes = append(r.scopes, Scope{
+			Name:         sc.Name,
+			Title:        sc.EffectiveTitle(),
+			Category:     sc.EffectiveCategory(),
+			Emoji:        sc.Emoji,
+			Globs:        sc.Files,
+			Instructions: instructions,
+		})
+	}
+
+	return r, nil
+}
+
+// Scopes returns every registered scope in order
+func (r *Registry) Scopes() []Scope {
+	return append([]Scope(nil), r.scopes...)
+}
+
+// Names returns the names of every registered scope in order
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.scopes))
+	for i, s := range r.scopes {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// CustomNames returns the names of the scopes declared in autoengineer.yaml
+func (r *Registry) CustomNames() []string {
+	var names []string
+	for _, s := range r.scopes {
+		if !s.Builtin {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// Get looks up a scope by name
+func (r *Registry) Get(name string) (Scope, bool) {
+	for _, s := range r.scopes {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Scope{}, false
+}
+
+// Usage returns the valid --scope values, e.g. "security|pipeline|infra|all"
+func (r *Registry) Usage() string {
+	return strings.Join(append(r.Names(), "all"), "|")
+}
+
+// RegisterCategories makes custom scope categories known to findings display
+func (r *Registry) RegisterCategories() {
+	for _, s := range r.scopes {
+		if s.Builtin {
+			continue
+		}
+		findings.RegisterCategory(findings.CategoryInfo{Name: s.Category, Title: s.Title, Emoji: s.Emoji})
+	}
+}
+
+// Analyzer returns the analyzer for a scope
+func (r *Registry) Analyzer(name string, base BaseAnalyzer) (Analyzer, error) {
+	scope, ok := r.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("invalid scope: %s (must be %s)", name, r.Usage())
+	}
+
+	switch name {
+	case "security":
+		return NewSecurityAnalyzer(base), nil
+	case "pipeline":
+		return NewPipelineAnalyzer(base), nil
+	case "infra":
+		return NewInfraAnalyzer(base), nil
+	default:
+		return NewCustomAnalyzer(base, scope), nil
+	}
+}
+
+// CustomAnalyzer runs a scope declared in autoengineer.yaml
+type CustomAnalyzer struct {
+	BaseAnalyzer
+	scope Scope
+}
+
+// NewCustomAnalyzer creates an analyzer for a custom scope
+func NewCustomAnalyzer(base BaseAnalyzer, scope Scope) *CustomAnalyzer {
+	return &CustomAnalyzer{BaseAnalyzer: base, scope: scope}
+}
+
+// Scope returns the scope name
+func (a *CustomAnalyzer) Scope() string {
+	return a.scope.Name
+}
+
+// Run executes the custom scope's analysis
+func (a *CustomAnalyzer) Run(ctx context.Context) ([]findings.Finding, error) {
+	return a.run(ctx, a.scope)
+}
@@ -0,0 +1,172 @@
+package analysis
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+	"github.com/liam-witterick/autoengineer/go/internal/prompts"
+)
+
+type fakeClient struct {
+	prompt  string
+	results []findings.Finding
+}
+
+func (c *fakeClient) RunAnalysis(ctx context.Context, prompt string) ([]findings.Finding, error) {
+	c.prompt = prompt
+	return c.results, nil
+}
+
+func TestRegistryBuiltinsAndCustom(t *testing.T) {
+	registry, err := NewRegistry([]config.ScopeConfig{
+		{Name: "cost", Title: "Cost", Emoji: "💰", Files: []string{"**/*.tf"}, Prompt: "- Oversized instances"},
+	})
+	if err != nil {
+		t.Fatalf("NewRegistry() error: %v", err)
+	}
+
+	if got := strings.Join(registry.Names(), ","); got != "security,pipeline,infra,cost" {
+		t.Errorf("Names() = %s", got)
+	}
+	if got := strings.Join(registry.CustomNames(), ","); got != "cost" {
+		t.Errorf("CustomNames() = %s", got)
+	}
+	if got := registry.Usage(); got != "security|pipeline|infra|cost|all" {
+		t.Errorf("Usage() = %s", got)
+	}
+
+	scope, ok := registry.Get("cost")
+	if !ok {
+		t.Fatal("expected cost scope to be registered")
+	}
+	if scope.Category != "cost" || scope.Builtin {
+		t.Errorf("unexpected scope: %+v", scope)
+	}
+
+	if _, err := registry.Analyzer("nope", BaseAnalyzer{}); err == nil {
+		t.Error("expected error for unknown scope")
+	}
+}
+
+func TestRegistryRejectsInvalidScopes(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope config.ScopeConfig
+	}{
+		{"builtin name", config.ScopeConfig{Name: "security", Prompt: "x"}},
+		{"reserved all", config.ScopeConfig{Name: "all", Prompt: "x"}},
+		{"uppercase", config.ScopeConfig{Name: "Cost", Prompt: "x"}},
+		{"no prompt", config.ScopeConfig{Name: "cost"}},
+		{"both prompts", config.ScopeConfig{Name: "cost", Prompt: "x", PromptFile: "cost.md"}},
+		{"bad glob", config.ScopeConfig{Name: "cost", Prompt: "x", Files: []string{"[.tf"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRegistry([]config.ScopeConfig{tt.scope}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestCustomAnalyzerRun(t *testing.T) {
+	registry, err := NewRegistry([]config.ScopeConfig{
+		{Name: "observability", Title: "Observability", Category: "ops", Files: []string{"modules/**"}, Prompt: "- Missing alarms"},
+	})
+	if err != nil {
+		t.Fatalf("NewRegistry() error: %v", err)
+	}
+
+	set, err := prompts.Load("", registry.CustomNames()...)
+	if err != nil {
+		t.Fatalf("prompts.Load() error: %v", err)
+	}
+
+	client := &fakeClient{results: []findings.Finding{{Title: "No alarm", Category: "security"}}}
+	analyzer, err := registry.Analyzer("observability", BaseAnalyzer{
+		Client:  client,
+		Prompts: set,
+		Files:   []string{"modules/rds/main.tf", "README.md"},
+	})
+	if err != nil {
+		t.Fatalf("Analyzer() error: %v", err)
+	}
+
+	results, err := analyzer.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Category != "ops" {
+		t.Errorf("expected finding with scope category, got %+v", results)
+	}
+	for _, want := range []string{"OBSERVABILITY FOCUS AREAS", "- Missing alarms", "- modules/rds/main.tf", `Must be "ops"`} {
+		if !strings.Contains(client.prompt, want) {
+			t.Errorf("prompt should contain %q", want)
+		}
+	}
+	if strings.Contains(client.prompt, "README.md") {
+		t.Error("prompt should only list files matching the scope's globs")
+	}
+}
+
+func TestBuiltinAnalyzerForcesCategory(t *testing.T) {
+	registry, err := NewRegistry(nil)
+	if err != nil {
+		t.Fatalf("NewRegistry() error: %v", err)
+	}
+
+	client := &fakeClient{results: []findings.Finding{{Title: "Unpinned action", Category: "security"}}}
+	analyzer, err := registry.Analyzer("pipeline", BaseAnalyzer{Client: client})
+	if err != nil {
+		t.Fatalf("Analyzer() error: %v", err)
+	}
+
+	results, err := analyzer.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if results[0].Category != findings.CategoryPipeline {
+		t.Errorf("Category = %q, want %q", results[0].Category, findings.CategoryPipeline)
+	}
+	if !strings.Contains(client.prompt, "CI/CD PIPELINE focus") {
+		t.Error("expected the embedded pipeline prompt")
+	}
+}
+
+func TestScopeApplicable(t *testing.T) {
+	registry, err := NewRegistry([]config.ScopeConfig{
+		{Name: "cost", Files: []string{"**/*.tf"}, Prompt: "- Oversized instances"},
+		{Name: "docs", Prompt: "- Missing runbooks"},
+	})
+	if err != nil {
+		t.Fatalf("NewRegistry() error: %v", err)
+	}
+
+	files := []string{".github/workflows/ci.yml", "README.md"}
+	inv := inventory.Inventory{Entries: []inventory.Entry{
+		{Technology: inventory.GitHubActions, Files: []string{".github/workflows/ci.yml"}},
+	}}
+
+	want := map[string]bool{"security": false, "pipeline": true, "infra": false, "cost": false, "docs": true}
+	for name, applicable := range want {
+		s, _ := registry.Get(name)
+		if got := s.Applicable(inv, files); got != applicable {
+			t.Errorf("%s: Applicable() = %v, want %v", name, got, applicable)
+		}
+	}
+
+	cost, _ := registry.Get("cost")
+	if got := cost.SkipReason(); got != "no files match **/*.tf" {
+		t.Errorf("SkipReason() = %q", got)
+	}
+	pipeline, _ := registry.Get("pipeline")
+	if got := pipeline.SkipReason(); got != "no GitHub Actions detected" {
+		t.Errorf("SkipReason() = %q", got)
+	}
+}
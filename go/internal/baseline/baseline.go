@@ -0,0 +1,133 @@
+// Package baseline snapshots a repository's accepted findings so later runs
+// can tell new findings from ones that were already there.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+// DefaultPath is the committed baseline file used when it exists
+const DefaultPath = ".github/autoengineer-baseline.json"
+
+// Version is the baseline file format version
+const Version = 1
+
+// Entry is a finding recorded in the baseline
+type Entry struct {
+	Fingerprint string   `json:"fingerprint"`
+	Title       string   `json:"title"`
+	Category    string   `json:"category"`
+	Severity    string   `json:"severity"`
+	Files       []string `json:"files,omitempty"`
+}
+
+// Baseline is the set of findings present when it was created
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"findings"`
+}
+
+// Create builds a baseline from findings, one entry per fingerprint, sorted
+// by fingerprint so re-creating an unchanged baseline gives an identical file
+func Create(all []findings.Finding) *Baseline {
+	b := &Baseline{Version: Version, Entries: []Entry{}}
+	seen := make(map[string]bool)
+	for _, f := range all {
+		fp := findings.Fingerprint(f)
+		if seen[fp] {
+			continue
+		}
+		seen[fp] = true
+		b.Entries = append(b.Entries, Entry{
+			Fingerprint: fp,
+			Title:       f.Title,
+			Category:    f.Category,
+			Severity:    f.Severity,
+			Files:       f.Files,
+		})
+	}
+	sort.Slice(b.Entries, func(i, j int) bool { return b.Entries[i].Fingerprint < b.Entries[j].Fingerprint })
+	return b
+}
+
+// Load reads a baseline file. A missing file returns nil and no error.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if b.Version > Version {
+		return nil, fmt.Errorf("baseline %s has version %d, this autoengineer supports up to %d", path, b.Version, Version)
+	}
+	return &b, nil
+}
+
+// Save writes the baseline to path, creating its directory
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create baseline directory: %w", err)
+		}
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Classification splits a run's findings relative to a baseline
+type Classification struct {
+	// New findings are not in the baseline
+	New []findings.Finding
+
+	// Existing findings are in the baseline
+	Existing []findings.Finding
+
+	// Fixed are baseline entries no finding matched
+	Fixed []Entry
+}
+
+// Classify compares findings to the baseline, setting each finding's Baseline
+// field to new or existing
+func (b *Baseline) Classify(all []findings.Finding) Classification {
+	var c Classification
+	matched := make(map[string]bool)
+	known := make(map[string]bool)
+	for _, e := range b.Entries {
+		known[e.Fingerprint] = true
+	}
+
+	for i := range all {
+		fp := findings.Fingerprint(all[i])
+		if known[fp] {
+			matched[fp] = true
+			all[i].Baseline = findings.BaselineExisting
+			c.Existing = append(c.Existing, all[i])
+		} else {
+			all[i].Baseline = findings.BaselineNew
+			c.New = append(c.New, all[i])
+		}
+	}
+
+	for _, e := range b.Entries {
+		if !matched[e.Fingerprint] {
+			c.Fixed = append(c.Fixed, e)
+		}
+	}
+	return c
+}
@@ -0,0 +1,88 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+func TestCreateSaveLoad(t *testing.T) {
+	all := []findings.Finding{
+		{Title: "Public S3 bucket", Category: findings.CategorySecurity, Severity: findings.SeverityHigh, Files: []string{"s3.tf"}},
+		{Title: "Unpinned action", Category: findings.CategoryPipeline, Severity: findings.SeverityMedium, Files: []string{"ci.yml"}},
+		{Title: "public s3 bucket", Category: findings.CategorySecurity, Severity: findings.SeverityLow, Files: []string{"s3.tf"}},
+	}
+
+	b := Create(all)
+	if len(b.Entries) != 2 {
+		t.Fatalf("expected duplicate fingerprints to collapse to 2 entries, got %d", len(b.Entries))
+	}
+	if b.Entries[0].Fingerprint > b.Entries[1].Fingerprint {
+		t.Error("entries should be sorted by fingerprint")
+	}
+
+	path := filepath.Join(t.TempDir(), ".github", "autoengineer-baseline.json")
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := os.ReadFile(path)
+	if err := Create(all).Save(path); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := os.ReadFile(path)
+	if string(first) != string(second) {
+		t.Error("re-creating an unchanged baseline should write an identical file")
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Version != Version || len(loaded.Entries) != 2 {
+		t.Errorf("unexpected loaded baseline: %+v", loaded)
+	}
+}
+
+func TestLoadMissingAndNewer(t *testing.T) {
+	dir := t.TempDir()
+	b, err := Load(filepath.Join(dir, "missing.json"))
+	if b != nil || err != nil {
+		t.Errorf("missing baseline should return nil, nil; got %v, %v", b, err)
+	}
+
+	path := filepath.Join(dir, "newer.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "findings": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected an error for a baseline from a newer version")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	b := Create([]findings.Finding{
+		{Title: "Public S3 bucket", Category: findings.CategorySecurity, Files: []string{"s3.tf"}},
+		{Title: "Missing resource limits", Category: findings.CategoryInfra, Files: []string{"deploy.yaml"}},
+	})
+
+	run := []findings.Finding{
+		{Title: "Public S3 Bucket", Category: findings.CategorySecurity, Severity: findings.SeverityHigh, Files: []string{"s3.tf"}},
+		{Title: "Unpinned action", Category: findings.CategoryPipeline, Files: []string{"ci.yml"}},
+	}
+	c := b.Classify(run)
+
+	if len(c.Existing) != 1 || c.Existing[0].Title != "Public S3 Bucket" {
+		t.Errorf("unexpected existing findings: %+v", c.Existing)
+	}
+	if len(c.New) != 1 || c.New[0].Title != "Unpinned action" {
+		t.Errorf("unexpected new findings: %+v", c.New)
+	}
+	if len(c.Fixed) != 1 || c.Fixed[0].Title != "Missing resource limits" {
+		t.Errorf("unexpected fixed entries: %+v", c.Fixed)
+	}
+	if run[0].Baseline != findings.BaselineExisting || run[1].Baseline != findings.BaselineNew {
+		t.Errorf("Classify should mark findings in place, got %q and %q", run[0].Baseline, run[1].Baseline)
+	}
+}
@@ -0,0 +1,295 @@
+// Package batch runs a scan over many local repository checkouts and rolls
+// their findings up into one fleet summary.
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/gate"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConcurrency is the number of repositories scanned at once
+const DefaultConcurrency = 4
+
+// Config is the repos.yaml file listing the checkouts to scan
+type Config struct {
+	// Concurrency limits how many repositories are scanned at once
+	Concurrency int `yaml:"concurrency"`
+
+	// Args are extra flags passed to every scan, e.g. ["--no-scanners"]
+	Args []string `yaml:"args"`
+
+	// Repos are individual checkouts
+	Repos []Repo `yaml:"repos"`
+
+	// Dirs are directories whose immediate subdirectories are git checkouts
+	Dirs []string `yaml:"dirs"`
+}
+
+// Repo is a local checkout to scan
+type Repo struct {
+	// Name identifies the repository in output (defaults to the directory name)
+	Name string `yaml:"name"`
+
+	// Path is the checkout, relative to repos.yaml
+	Path string `yaml:"path"`
+
+	// Args are extra flags for this repository's scan
+	Args []string `yaml:"args"`
+}
+
+// LoadConfig reads a repos.yaml file, resolving paths relative to it
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repos file: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	base := filepath.Dir(path)
+	for i := range cfg.Repos {
+		if cfg.Repos[i].Path == "" {
+			return nil, fmt.Errorf("%s: repos[%d] has no path", path, i)
+		}
+		cfg.Repos[i].Path = resolvePath(base, cfg.Repos[i].Path)
+	}
+	for i := range cfg.Dirs {
+		cfg.Dirs[i] = resolvePath(base, cfg.Dirs[i])
+	}
+
+	return &cfg, nil
+}
+
+// resolvePath expands ~ and makes path relative to base absolute
+func resolvePath(base, path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	return filepath.Clean(path)
+}
+
+// DiscoverRepos returns the git checkouts directly inside dir, sorted by name
+func DiscoverRepos(dir string) ([]Repo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var repos []Repo
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+			continue
+		}
+		repos = append(repos, Repo{Name: entry.Name(), Path: path})
+	}
+	return repos, nil
+}
+
+// ResolveRepos expands the configured repos and dirs into a list of checkouts
+// with unique names. Missing checkouts are an error.
+func (c *Config) ResolveRepos() ([]Repo, error) {
+	repos := append([]Repo(nil), c.Repos...)
+	for _, dir := range c.Dirs {
+		found, err := DiscoverRepos(dir)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, found...)
+	}
+
+	names := make(map[string]string)
+	for i := range repos {
+		if repos[i].Name == "" {
+			repos[i].Name = filepath.Base(repos[i].Path)
+		}
+		if info, err := os.Stat(repos[i].Path); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("repository %s not found: %s", repos[i].Name, repos[i].Path)
+		}
+		if other, ok := names[repos[i].Name]; ok {
+			return nil, fmt.Errorf("repository name %q is used by both %s and %s (set name: in repos.yaml)", repos[i].Name, other, repos[i].Path)
+		}
+		names[repos[i].Name] = repos[i].Path
+	}
+	return repos, nil
+}
+
+// Scanner scans one checkout and writes its findings to output. A
+// *gate.ExitError for a failed --fail-on gate or a partial scanner failure
+// still counts as a completed scan.
+type Scanner func(ctx context.Context, repo Repo, output string) error
+
+// Result is the outcome of scanning one repository
+type Result struct {
+	Name     string             `json:"name"`
+	Path     string             `json:"path"`
+	Output   string             `json:"output"`
+	Error    string             `json:"error,omitempty"`
+	ExitCode int                `json:"exit_code,omitempty"`
+	Findings []findings.Finding `json:"-"`
+	Counts   Counts             `json:"counts"`
+}
+
+// Failed reports whether the scan failed
+func (r Result) Failed() bool {
+	return r.Error != ""
+}
+
+// Counts are finding counts by severity and category
+type Counts struct {
+	Total    int            `json:"total"`
+	Severity map[string]int `json:"severity"`
+	Category map[string]int `json:"category"`
+}
+
+// count tallies findings by severity and category
+func count(all []findings.Finding) Counts {
+	return Counts{
+		Total:    len(all),
+		Severity: findings.CountBySeverity(all),
+		Category: findings.CountByCategoryName(all),
+	}
+}
+
+// add accumulates other into c
+func (c *Counts) add(other Counts) {
+	c.Total += other.Total
+	for k, v := range other.Severity {
+		c.Severity[k] += v
+	}
+	for k, v := range other.Category {
+		c.Category[k] += v
+	}
+}
+
+// Run scans every repository with at most concurrency scans at once, writing
+// each repository's findings to <outputDir>/<name>.json. onDone (optional) is
+// called as each scan finishes. Results are returned in repository order.
+func Run(ctx context.Context, repos []Repo, concurrency int, outputDir string, scan Scanner, onDone func(Result)) []Result {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	results := make([]Result, len(repos))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency && w < len(repos); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = scanRepo(ctx, repos[i], outputDir, scan)
+				if onDone != nil {
+					onDone(results[i])
+				}
+			}
+		}()
+	}
+
+	for i := range repos {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// scanRepo scans a single repository and loads the findings it wrote
+func scanRepo(ctx context.Context, repo Repo, outputDir string, scan Scanner) Result {
+	output := filepath.Join(outputDir, repo.Name+".json")
+	result := Result{Name: repo.Name, Path: repo.Path, Output: output}
+
+	if err := scan(ctx, repo, output); err != nil {
+		var exitErr *gate.ExitError
+		if !errors.As(err, &exitErr) || (exitErr.Code != gate.ExitFindings && exitErr.Code != gate.ExitScannerFailed) {
+			result.Error = err.Error()
+			return result
+		}
+		result.ExitCode = exitErr.Code
+	}
+
+	all, err := findings.LoadFile(output)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Findings = all
+	result.Counts = count(all)
+	return result
+}
+
+// Summary rolls the results of a batch up across the fleet
+type Summary struct {
+	Repos  []Result `json:"repos"`
+	Totals Counts   `json:"totals"`
+	Failed int      `json:"failed"`
+}
+
+// Summarize totals the results of the successful scans
+func Summarize(results []Result) Summary {
+	summary := Summary{Repos: results, Totals: Counts{Severity: map[string]int{}, Category: map[string]int{}}}
+	for _, r := range results {
+		if r.Failed() {
+			summary.Failed++
+			continue
+		}
+		summary.Totals.add(r.Counts)
+	}
+	return summary
+}
+
+// ExitCode returns the batch's gate exit code: ExitFindings if any repository
+// failed its gate, otherwise ExitScannerFailed if any had a scanner fail
+func (s Summary) ExitCode() int {
+	code := gate.ExitOK
+	for _, r := range s.Repos {
+		if r.ExitCode == gate.ExitFindings {
+			return gate.ExitFindings
+		}
+		if r.ExitCode == gate.ExitScannerFailed {
+			code = gate.ExitScannerFailed
+		}
+	}
+	return code
+}
+
+// Categories returns the categories with findings, sorted by count then name
+func (c Counts) Categories() []string {
+	names := make([]string, 0, len(c.Category))
+	for name, n := range c.Category {
+		if n > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if c.Category[names[i]] != c.Category[names[j]] {
+			return c.Category[names[i]] > c.Category[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
@@ -0,0 +1,180 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/gate"
+)
+
+// makeCheckout creates an empty git checkout directory
+func makeCheckout(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(path, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigAndResolveRepos(t *testing.T) {
+	dir := t.TempDir()
+	makeCheckout(t, filepath.Join(dir, "checkouts", "api"))
+	makeCheckout(t, filepath.Join(dir, "clones", "web"))
+	makeCheckout(t, filepath.Join(dir, "clones", "db"))
+	os.MkdirAll(filepath.Join(dir, "clones", "not-a-repo"), 0755)
+
+	reposFile := filepath.Join(dir, "repos.yaml")
+	os.WriteFile(reposFile, []byte(`
+concurrency: 2
+args: ["--no-scanners"]
+repos:
+  - path: checkouts/api
+    name: payments-api
+    args: ["--scope", "security"]
+dirs:
+  - clones
+`), 0644)
+
+	cfg, err := LoadConfig(reposFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	if cfg.Concurrency != 2 || len(cfg.Args) != 1 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	repos, err := cfg.ResolveRepos()
+	if err != nil {
+		t.Fatalf("ResolveRepos() error: %v", err)
+	}
+
+	var names []string
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	if got := strings.Join(names, ","); got != "payments-api,db,web" {
+		t.Errorf("repos = %s", got)
+	}
+	if repos[0].Path != filepath.Join(dir, "checkouts", "api") {
+		t.Errorf("repo path should be resolved relative to repos.yaml, got %s", repos[0].Path)
+	}
+}
+
+func TestResolveReposErrors(t *testing.T) {
+	dir := t.TempDir()
+	makeCheckout(t, filepath.Join(dir, "a", "svc"))
+	makeCheckout(t, filepath.Join(dir, "b", "svc"))
+
+	cfg := &Config{Repos: []Repo{{Path: filepath.Join(dir, "a", "svc")}, {Path: filepath.Join(dir, "b", "svc")}}}
+	if _, err := cfg.ResolveRepos(); err == nil || !strings.Contains(err.Error(), "set name:") {
+		t.Errorf("expected duplicate name error, got %v", err)
+	}
+
+	cfg = &Config{Repos: []Repo{{Path: filepath.Join(dir, "missing")}}}
+	if _, err := cfg.ResolveRepos(); err == nil {
+		t.Error("expected error for a missing checkout")
+	}
+}
+
+func TestRunAndSummarize(t *testing.T) {
+	outputDir := t.TempDir()
+	repos := []Repo{{Name: "api"}, {Name: "web"}, {Name: "db"}, {Name: "broken"}}
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	scan := func(ctx context.Context, repo Repo, output string) error {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		switch repo.Name {
+		case "broken":
+			return fmt.Errorf("scan failed")
+		case "api":
+			return findings.SaveFile([]findings.Finding{
+				{Title: "Open security group", Category: findings.CategorySecurity, Severity: findings.SeverityHigh},
+				{Title: "Unpinned module", Category: findings.CategoryInfra, Severity: findings.SeverityLow},
+			}, output)
+		default:
+			return findings.SaveFile([]findings.Finding{
+				{Title: "Unpinned action", Category: findings.CategoryPipeline, Severity: findings.SeverityMedium},
+			}, output)
+		}
+	}
+
+	done := 0
+	results := Run(context.Background(), repos, 2, outputDir, scan, func(Result) { done++ })
+	if peak > 2 {
+		t.Errorf("expected at most 2 concurrent scans, got %d", peak)
+	}
+	if done != len(repos) {
+		t.Errorf("onDone called %d times, want %d", done, len(repos))
+	}
+	if results[0].Name != "api" || results[0].Output != filepath.Join(outputDir, "api.json") {
+		t.Errorf("results should be in repository order, got %+v", results[0])
+	}
+	if !results[3].Failed() {
+		t.Error("expected the broken repository to fail")
+	}
+
+	summary := Summarize(results)
+	if summary.Failed != 1 || summary.Totals.Total != 4 {
+		t.Errorf("unexpected summary: failed=%d total=%d", summary.Failed, summary.Totals.Total)
+	}
+	if summary.Totals.Severity[findings.SeverityMedium] != 2 || summary.Totals.Severity[findings.SeverityHigh] != 1 {
+		t.Errorf("unexpected severity totals: %v", summary.Totals.Severity)
+	}
+	if got := strings.Join(summary.Totals.Categories(), ","); got != "pipeline,infra,security" {
+		t.Errorf("Categories() = %s", got)
+	}
+}
+
+func TestRunGatedScans(t *testing.T) {
+	outputDir := t.TempDir()
+	repos := []Repo{{Name: "clean"}, {Name: "partial"}, {Name: "blocked"}}
+
+	scan := func(ctx context.Context, repo Repo, output string) error {
+		if err := findings.SaveFile([]findings.Finding{
+			{Title: "Open security group", Category: findings.CategorySecurity, Severity: findings.SeverityHigh},
+		}, output); err != nil {
+			return err
+		}
+		switch repo.Name {
+		case "partial":
+			return &gate.ExitError{Code: gate.ExitScannerFailed, Err: fmt.Errorf("trivy failed")}
+		case "blocked":
+			return &gate.ExitError{Code: gate.ExitFindings, Err: fmt.Errorf("gate failed")}
+		}
+		return nil
+	}
+
+	results := Run(context.Background(), repos, 1, outputDir, scan, nil)
+	summary := Summarize(results)
+	if summary.Failed != 0 || summary.Totals.Total != 3 {
+		t.Errorf("gated scans should still count their findings: failed=%d total=%d", summary.Failed, summary.Totals.Total)
+	}
+	if results[1].ExitCode != gate.ExitScannerFailed || results[2].ExitCode != gate.ExitFindings {
+		t.Errorf("unexpected exit codes: %d, %d", results[1].ExitCode, results[2].ExitCode)
+	}
+	if got := summary.ExitCode(); got != gate.ExitFindings {
+		t.Errorf("ExitCode() = %d, want %d", got, gate.ExitFindings)
+	}
+	if got := Summarize(results[:2]).ExitCode(); got != gate.ExitScannerFailed {
+		t.Errorf("ExitCode() = %d, want %d", got, gate.ExitScannerFailed)
+	}
+}
@@ -0,0 +1,58 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Completer is the LLM backend interface wrapped by the cassette
+// (satisfied by copilot.LLMBackend implementations)
+type Completer interface {
+	Name() string
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// Backend records or replays the prompts sent to an LLM backend
+type Backend struct {
+	cassette *Cassette
+	next     Completer
+	name     string
+}
+
+// WrapBackend returns a backend that records through next, or replays from
+// the cassette without calling next at all
+func (c *Cassette) WrapBackend(next Completer) *Backend {
+	return &Backend{cassette: c, next: next, name: next.Name()}
+}
+
+// Name returns the wrapped backend's name
+func (b *Backend) Name() string {
+	return b.name
+}
+
+// Complete answers the prompt from the cassette or records the live response
+func (b *Backend) Complete(ctx context.Context, prompt string) (string, error) {
+	if b.cassette.Replaying() {
+		interaction, ok := b.cassette.findLLM(b.name, prompt)
+		if !ok {
+			return "", fmt.Errorf("no recorded %s response for prompt %s in cassette %s", b.name, promptID(prompt), b.cassette.Dir)
+		}
+		if interaction.Error != "" {
+			return interaction.Response, errors.New(interaction.Error)
+		}
+		return interaction.Response, nil
+	}
+
+	response, err := b.next.Complete(ctx, prompt)
+
+	interaction := LLMInteraction{Backend: b.name, Prompt: prompt, Response: response}
+	if err != nil {
+		interaction.Error = err.Error()
+	}
+	if saveErr := b.cassette.addLLM(interaction); saveErr != nil {
+		return "", saveErr
+	}
+
+	return response, err
+}
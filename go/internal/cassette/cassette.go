@@ -0,0 +1,210 @@
+// Package cassette records LLM prompts, GitHub API exchanges and scanner
+// results to a directory and serves them back, so a run can be reproduced offline.
+package cassette
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cassette modes
+const (
+	ModeRecord = "record"
+	ModeReplay = "replay"
+)
+
+const (
+	llmFile      = "llm.json"
+	httpFile     = "http.json"
+	scannersFile = "scanners.json"
+)
+
+// LLMInteraction is a single prompt and the backend's response
+type LLMInteraction struct {
+	Backend  string `json:"backend"`
+	Prompt   string `json:"prompt"`
+	Response string `json:"response"`
+	Error    string `json:"error,omitempty"`
+}
+
+// HTTPInteraction is a single GitHub API request and its response
+type HTTPInteraction struct {
+	Method       string              `json:"method"`
+	URL          string              `json:"url"`
+	RequestBody  string              `json:"request_body,omitempty"`
+	Status       int                 `json:"status"`
+	Header       map[string][]string `json:"header,omitempty"`
+	ResponseBody string              `json:"response_body"`
+}
+
+// Cassette holds the interactions of one run
+type Cassette struct {
+	Dir  string
+	Mode string
+
+	mu       sync.Mutex
+	llm      []LLMInteraction
+	http     []HTTPInteraction
+	scanners []ScannerRun
+
+	// used marks interactions already served during replay, so identical
+	// requests made more than once are answered in recorded order
+	llmUsed      []bool
+	httpUsed     []bool
+	scannersUsed []bool
+}
+
+// Record creates a cassette that writes interactions to dir as they happen
+func Record(dir string) (*Cassette, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	c := &Cassette{Dir: dir, Mode: ModeRecord}
+
+	// Start from an empty cassette so stale interactions never leak into a new recording
+	for _, file := range []string{llmFile, httpFile, scannersFile} {
+		if err := c.save(file, []struct{}{}); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Replay loads a previously recorded cassette from dir
+func Replay(dir string) (*Cassette, error) {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("cassette directory not found: %s", dir)
+	}
+
+	c := &Cassette{Dir: dir, Mode: ModeReplay}
+
+	if err := readJSON(filepath.Join(dir, llmFile), &c.llm); err != nil {
+		return nil, err
+	}
+	if err := readJSON(filepath.Join(dir, httpFile), &c.http); err != nil {
+		return nil, err
+	}
+	if err := readJSON(filepath.Join(dir, scannersFile), &c.scanners); err != nil {
+		return nil, err
+	}
+
+	c.llmUsed = make([]bool, len(c.llm))
+	c.httpUsed = make([]bool, len(c.http))
+	c.scannersUsed = make([]bool, len(c.scanners))
+	return c, nil
+}
+
+// Replaying reports whether interactions are served from the cassette
+func (c *Cassette) Replaying() bool {
+	return c != nil && c.Mode == ModeReplay
+}
+
+// addLLM records an LLM interaction and persists the cassette
+func (c *Cassette) addLLM(interaction LLMInteraction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.llm = append(c.llm, interaction)
+	return c.save(llmFile, c.llm)
+}
+
+// addHTTP records an HTTP interaction and persists the cassette
+func (c *Cassette) addHTTP(interaction HTTPInteraction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.http = append(c.http, interaction)
+	return c.save(httpFile, c.http)
+}
+
+// findLLM returns the first unused recorded response for the prompt
+func (c *Cassette) findLLM(backend, prompt string) (LLMInteraction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.llm {
+		if c.llmUsed[i] || interaction.Backend != backend || interaction.Prompt != prompt {
+			continue
+		}
+		c.llmUsed[i] = true
+		return interaction, true
+	}
+	return LLMInteraction{}, false
+}
+
+// findHTTP returns the first unused recorded response for the request
+func (c *Cassette) findHTTP(method, url, body string) (HTTPInteraction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.http {
+		if c.httpUsed[i] || interaction.Method != method || interaction.URL != url || interaction.RequestBody != body {
+			continue
+		}
+		c.httpUsed[i] = true
+		return interaction, true
+	}
+	return HTTPInteraction{}, false
+}
+
+// save writes the interactions of one kind to their file, so each
+// interaction rewrites only the file it changed. The caller must hold c.mu.
+func (c *Cassette) save(file string, interactions interface{}) error {
+	return writeJSON(filepath.Join(c.Dir, file), interactions)
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSON replaces path through a temporary file, so a crash mid-write
+// leaves the previous contents rather than a truncated file
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// promptID returns a short stable identifier for a prompt, used in replay errors
+func promptID(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,218 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+type fakeCompleter struct {
+	calls int
+	err   error
+}
+
+func (f *fakeCompleter) Name() string { return "fake" }
+
+func (f *fakeCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	f.calls++
+	return "answer to " + prompt, f.err
+}
+
+func TestBackendRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	rec, err := Record(dir)
+	if err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+	live := &fakeCompleter{}
+	backend := rec.WrapBackend(live)
+
+	for _, prompt := range []string{"first", "second"} {
+		if _, err := backend.Complete(context.Background(), prompt); err != nil {
+			t.Fatalf("Complete(%q) error: %v", prompt, err)
+		}
+	}
+
+	rep, err := Replay(dir)
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	offline := &fakeCompleter{}
+	replayed := rep.WrapBackend(offline)
+
+	// Replayed out of order - prompts are matched by content
+	got, err := replayed.Complete(context.Background(), "second")
+	if err != nil {
+		t.Fatalf("replay error: %v", err)
+	}
+	if got != "answer to second" {
+		t.Errorf("replayed response = %q, want %q", got, "answer to second")
+	}
+	if offline.calls != 0 {
+		t.Errorf("replay called the live backend %d time(s)", offline.calls)
+	}
+	if replayed.Name() != "fake" {
+		t.Errorf("Name() = %q, want %q", replayed.Name(), "fake")
+	}
+
+	if _, err := replayed.Complete(context.Background(), "third"); err == nil {
+		t.Error("expected error for unrecorded prompt")
+	}
+}
+
+func TestBackendReplaysErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	rec, err := Record(dir)
+	if err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+	backend := rec.WrapBackend(&fakeCompleter{err: errors.New("rate limited")})
+	if _, err := backend.Complete(context.Background(), "prompt"); err == nil {
+		t.Fatal("expected recorded call to return the live error")
+	}
+
+	rep, err := Replay(dir)
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	_, err = rep.WrapBackend(&fakeCompleter{}).Complete(context.Background(), "prompt")
+	if err == nil || err.Error() != "rate limited" {
+		t.Errorf("replayed error = %v, want %q", err, "rate limited")
+	}
+}
+
+func TestTransportRecordAndReplay(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"echo":` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rec, err := Record(dir)
+	if err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+
+	client := &http.Client{Transport: rec.WrapTransport(nil)}
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/repos/o/r/issues", strings.NewReader(`{"title":"x"}`))
+	req.Header.Set("Authorization", "token secret-token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("recorded request error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"echo":{"title":"x"}}` {
+		t.Errorf("recorded response body = %q", body)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, httpFile))
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Error("cassette must not contain the Authorization header")
+	}
+	if strings.Contains(string(data), "X-Ratelimit-Remaining") {
+		t.Error("cassette should not contain volatile headers")
+	}
+
+	server.Close()
+
+	rep, err := Replay(dir)
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	client = &http.Client{Transport: rep.WrapTransport(nil)}
+
+	resp, err = client.Post(server.URL+"/repos/o/r/issues", "application/json", strings.NewReader(`{"title":"x"}`))
+	if err != nil {
+		t.Fatalf("replayed request error: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("replayed status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("replayed Content-Type = %q", resp.Header.Get("Content-Type"))
+	}
+	if string(body) != `{"echo":{"title":"x"}}` {
+		t.Errorf("replayed response body = %q", body)
+	}
+	if requests != 1 {
+		t.Errorf("server received %d request(s), want 1", requests)
+	}
+
+	// A second identical request has no recording left to serve
+	if _, err := client.Post(server.URL+"/repos/o/r/issues", "application/json", strings.NewReader(`{"title":"x"}`)); err == nil {
+		t.Error("expected error once the recorded interaction has been used")
+	}
+}
+
+func TestScannersRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	rec, err := Record(dir)
+	if err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+	found := []findings.Finding{{Title: "Public bucket", Severity: findings.SeverityHigh, Rule: "CKV_AWS_18", Source: "checkov"}}
+	if err := rec.RecordScanners("security", found); err != nil {
+		t.Fatalf("RecordScanners() error: %v", err)
+	}
+
+	// Files are replaced through temporary files, which must not be left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, ",") != "http.json,llm.json,scanners.json" {
+		t.Errorf("cassette files = %v, want http.json, llm.json and scanners.json", names)
+	}
+
+	rep, err := Replay(dir)
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	if _, err := rep.ReplayScanners("infra"); err == nil || !strings.Contains(err.Error(), "--no-scanners") {
+		t.Errorf("expected an error for an unrecorded scope, got %v", err)
+	}
+	got, err := rep.ReplayScanners("security")
+	if err != nil {
+		t.Fatalf("ReplayScanners() error: %v", err)
+	}
+	if len(got) != 1 || got[0].Rule != "CKV_AWS_18" || got[0].Source != "checkov" {
+		t.Errorf("replayed findings = %+v, want the recorded checkov finding", got)
+	}
+	if _, err := rep.ReplayScanners("security"); err == nil {
+		t.Error("expected error once the recorded scanner run has been used")
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := Replay(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for a missing cassette directory")
+	}
+}
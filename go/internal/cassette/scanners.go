@@ -0,0 +1,38 @@
+package cassette
+
+import (
+	"fmt"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+// ScannerRun is the findings the external scanners reported for one scope
+type ScannerRun struct {
+	Scope    string             `json:"scope"`
+	Findings []findings.Finding `json:"findings"`
+}
+
+// RecordScanners records the findings of a scanner run and persists the cassette
+func (c *Cassette) RecordScanners(scope string, found []findings.Finding) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scanners = append(c.scanners, ScannerRun{Scope: scope, Findings: found})
+	return c.save(scannersFile, c.scanners)
+}
+
+// ReplayScanners returns the findings of the first unused scanner run
+// recorded for the scope, so replays never run the scanners live
+func (c *Cassette) ReplayScanners(scope string) ([]findings.Finding, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, run := range c.scanners {
+		if c.scannersUsed[i] || run.Scope != scope {
+			continue
+		}
+		c.scannersUsed[i] = true
+		return run.Findings, nil
+	}
+	return nil, fmt.Errorf("no recorded scanner run for scope %s in cassette %s (record it again, or replay with --no-scanners)", scope, c.Dir)
+}
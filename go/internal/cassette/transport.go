@@ -0,0 +1,106 @@
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// recordedHeaders are the response headers kept in the cassette; everything
+// else (rate limits, request IDs, cookies) varies between runs
+var recordedHeaders = []string{"Content-Type", "Link"}
+
+// Transport records or replays GitHub API requests
+type Transport struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+// WrapTransport returns a round tripper that records through next (or
+// http.DefaultTransport if nil), or replays from the cassette without
+// touching the network. Request headers, including Authorization, are never recorded.
+func (c *Cassette) WrapTransport(next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{cassette: c, next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	url := req.URL.String()
+
+	if t.cassette.Replaying() {
+		interaction, ok := t.cassette.findHTTP(req.Method, url, string(requestBody))
+		if !ok {
+			return nil, fmt.Errorf("no recorded response for %s %s in cassette %s", req.Method, url, t.cassette.Dir)
+		}
+		return interaction.response(req), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := HTTPInteraction{
+		Method:       req.Method,
+		URL:          url,
+		RequestBody:  string(requestBody),
+		Status:       resp.StatusCode,
+		Header:       make(map[string][]string),
+		ResponseBody: string(responseBody),
+	}
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			interaction.Header[name] = values
+		}
+	}
+
+	if err := t.cassette.addHTTP(interaction); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// response rebuilds the recorded response for req
+func (i HTTPInteraction) response(req *http.Request) *http.Response {
+	header := make(http.Header)
+	for name, values := range i.Header {
+		for _, value := range values {
+			header.Add(name, value)
+		}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		StatusCode:    i.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(i.ResponseBody))),
+		ContentLength: int64(len(i.ResponseBody)),
+		Request:       req,
+	}
+}
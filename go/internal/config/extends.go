@@ -0,0 +1,520 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtendsCacheTTL is how long a base config fetched from GitHub is reused
+// before it is fetched again
+const ExtendsCacheTTL = time.Hour
+
+// maxExtendsDepth limits how deep base configs may extend other base configs
+const maxExtendsDepth = 10
+
+// remoteRefPattern matches a base config in another repository, e.g.
+// octo-org/platform:autoengineer.yaml@v1
+var remoteRefPattern = regexp.MustCompile(`^([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+):([^@]+)(?:@(.+))?$`)
+
+// appendedLists are the lists that add up across an extends chain instead of
+// being replaced; the rest of the settings are overridden key by key
+var appendedLists = map[string]bool{
+	"scanners.enabled":       true,
+	"scanners.disabled":      true,
+	"ignore.accepted":        true,
+	"ignore.ignore_paths":    true,
+	"ignore.ignore_patterns": true,
+	"ignore.disabled_scopes": true,
+	"ignore.rules":           true,
+	"severity_overrides":     true,
+}
+
+// itemKeyFields name the field identifying the items of a list, by the
+// list's key; items of other lists are identified by their whole value
+var itemKeyFields = map[string]string{
+	"scopes":   "name",
+	"accepted": "title",
+}
+
+// oppositeLists cancel each other: enabling a scanner a base disables
+// removes it from the disabled list, and the other way around
+var oppositeLists = map[string]string{
+	"scanners.enabled":  "disabled",
+	"scanners.disabled": "enabled",
+}
+
+// Extends lists the base configs an autoengineer.yaml builds on. It is
+// written as a single reference or a list.
+type Extends []string
+
+// UnmarshalYAML accepts a single reference as well as a list
+func (e *Extends) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		if value.Tag == "!!null" {
+			*e = nil
+			return nil
+		}
+		var ref string
+		if err := value.Decode(&ref); err != nil {
+			return err
+		}
+		*e = Extends{ref}
+		return nil
+	}
+
+	var refs []string
+	if err := value.Decode(&refs); err != nil {
+		return err
+	}
+	*e = refs
+	return nil
+}
+
+// Provenance maps each effective setting of autoengineer.yaml to the file it
+// came from, by dotted path, e.g. "llm.model" or "profiles.ci.fail_on". Items
+// of lists that add up are keyed by value, name or title, e.g.
+// "scanners.disabled[trivy]" or "scopes[cost]".
+type Provenance map[string]string
+
+// Source returns where the setting at path came from, falling back to its
+// nearest ancestor. Returns "" for settings that aren't configured.
+func (p Provenance) Source(path string) string {
+	for {
+		if source, ok := p[path]; ok {
+			return source
+		}
+		i := strings.LastIndex(path, ".")
+		if i < 0 {
+			return ""
+		}
+		path = path[:i]
+	}
+}
+
+// Item returns where an item of the list at path came from
+func (p Provenance) Item(path, key string) string {
+	if source, ok := p[path+"["+key+"]"]; ok {
+		return source
+	}
+	return p.Source(path)
+}
+
+// clear forgets the sources of path and everything below it
+func (p Provenance) clear(path string) {
+	for key := range p {
+		if key == path || strings.HasPrefix(key, path+".") || strings.HasPrefix(key, path+"[") {
+			delete(p, key)
+		}
+	}
+}
+
+// configLocation is where an autoengineer.yaml lives: a local file, or a
+// file in a GitHub repository when Owner is set
+type configLocation struct {
+	Owner string
+	Repo  string
+	Ref   string
+	Path  string
+}
+
+// String returns the location as it is written in extends
+func (l configLocation) String() string {
+	if l.Owner == "" {
+		return filepath.ToSlash(l.Path)
+	}
+	s := fmt.Sprintf("%s/%s:%s", l.Owner, l.Repo, l.Path)
+	if l.Ref != "" {
+		s += "@" + l.Ref
+	}
+	return s
+}
+
+// resolve returns the location of a base config referenced from l. Relative
+// paths are relative to l's directory, in l's repository for remote configs.
+func (l configLocation) resolve(ref string) (configLocation, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return configLocation{}, fmt.Errorf("empty extends reference")
+	}
+
+	if m := remoteRefPattern.FindStringSubmatch(ref); m != nil {
+		return remoteLocation(m[1], m[2], m[3], m[4])
+	}
+	if l.Owner != "" {
+		if strings.HasPrefix(ref, "/") {
+			return remoteLocation(l.Owner, l.Repo, ref, l.Ref)
+		}
+		return remoteLocation(l.Owner, l.Repo, path.Join(path.Dir(l.Path), ref), l.Ref)
+	}
+
+	ref = filepath.FromSlash(ref)
+	if filepath.IsAbs(ref) {
+		return configLocation{Path: ref}, nil
+	}
+	return configLocation{Path: filepath.Join(filepath.Dir(l.Path), ref)}, nil
+}
+
+// remoteLocation returns the location of a file in a GitHub repository
+func remoteLocation(owner, repo, file, ref string) (configLocation, error) {
+	file = path.Clean(strings.TrimPrefix(file, "/"))
+	if file == "." || file == ".." || strings.HasPrefix(file, "../") {
+		return configLocation{}, fmt.Errorf("invalid path %q in %s/%s", file, owner, repo)
+	}
+	return configLocation{Owner: owner, Repo: repo, Ref: ref, Path: file}, nil
+}
+
+// read returns the file's content, from the cache for recently fetched
+// remote files
+func (l configLocation) read() ([]byte, error) {
+	if l.Owner == "" {
+		return os.ReadFile(l.Path)
+	}
+
+	cachePath, cacheErr := l.cachePath()
+	if cacheErr == nil {
+		if info, err := os.Stat(cachePath); err == nil && time.Since(info.ModTime()) < ExtendsCacheTTL {
+			return os.ReadFile(cachePath)
+		}
+	}
+
+	data, err := fetchRemoteFile(l.Owner, l.Repo, l.Path, l.Ref)
+	if err != nil {
+		// Fall back to the last copy fetched when GitHub can't be reached
+		if cacheErr == nil {
+			if cached, readErr := os.ReadFile(cachePath); readErr == nil {
+				return cached, nil
+			}
+		}
+		return nil, err
+	}
+
+	if cacheErr == nil && os.MkdirAll(filepath.Dir(cachePath), 0755) == nil {
+		_ = os.WriteFile(cachePath, data, 0644)
+	}
+	return data, nil
+}
+
+// cachePath returns where a fetched remote file is cached
+func (l configLocation) cachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	ref := l.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	return filepath.Join(dir, "autoengineer", "extends", l.Owner, l.Repo, filepath.FromSlash(ref), filepath.FromSlash(l.Path)), nil
+}
+
+// fetchRemoteFile fetches a file from a GitHub repository with the gh
+// credentials; an empty ref means the default branch
+var fetchRemoteFile = func(owner, repo, file, ref string) ([]byte, error) {
+	client, err := api.NewRESTClient(api.ClientOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub API client: %w", err)
+	}
+
+	segments := strings.Split(file, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	endpoint := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, strings.Join(segments, "/"))
+	if ref != "" {
+		endpoint += "?ref=" + url.QueryEscape(ref)
+	}
+
+	var content struct {
+		Type     string `json:"type"`
+		Encoding string `json:"encoding"`
+		Content  string `json:"content"`
+	}
+	if err := client.Get(endpoint, &content); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s from %s/%s: %w", file, owner, repo, err)
+	}
+	if content.Type != "file" || content.Encoding != "base64" {
+		return nil, fmt.Errorf("%s in %s/%s is not a file GitHub returns inline", file, owner, repo)
+	}
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+}
+
+// configLayer is one file of an extends chain
+type configLayer struct {
+	Location configLocation
+	Data     []byte
+}
+
+// Source names the layer in provenance and problems
+func (l configLayer) Source() string {
+	return l.Location.String()
+}
+
+// loadConfigLayers reads the config at configPath and every base config it
+// extends, in the order they apply: bases of bases first, the config itself
+// last. A base reached twice is only applied once.
+func loadConfigLayers(configPath string) ([]configLayer, error) {
+	root := configLocation{Path: configPath}
+	data, err := root.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []configLayer
+	err = addConfigLayers(root, data, []string{root.String()}, &layers)
+	return layers, err
+}
+
+// addConfigLayers appends the bases a config extends, then the config itself
+func addConfigLayers(loc configLocation, data []byte, chain []string, layers *[]configLayer) error {
+	var head struct {
+		Extends Extends `yaml:"extends"`
+	}
+	if err := yaml.Unmarshal(data, &head); err != nil {
+		return fmt.Errorf("%s: %w", loc, err)
+	}
+
+	for _, ref := range head.Extends {
+		base, err := loc.resolve(ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", loc, err)
+		}
+		for _, source := range chain {
+			if source == base.String() {
+				return fmt.Errorf("%s: extends cycle: %s → %s", loc, strings.Join(chain, " → "), base)
+			}
+		}
+		if len(chain) > maxExtendsDepth {
+			return fmt.Errorf("%s: extends is nested more than %d levels deep", loc, maxExtendsDepth)
+		}
+
+		baseData, err := base.read()
+		if err != nil {
+			return fmt.Errorf("%s: failed to read base config %s: %w", loc, base, err)
+		}
+		if err := addConfigLayers(base, baseData, append(chain, base.String()), layers); err != nil {
+			return err
+		}
+	}
+
+	for _, layer := range *layers {
+		if layer.Source() == loc.String() {
+			return nil
+		}
+	}
+	*layers = append(*layers, configLayer{Location: loc, Data: data})
+	return nil
+}
+
+// mergeConfigLayers merges the layers of an extends chain into the
+// effective config, recording where each setting came from
+func mergeConfigLayers(layers []configLayer) (*FullConfig, Provenance, error) {
+	merged := make(map[string]interface{})
+	prov := make(Provenance)
+	for i, layer := range layers {
+		// Decoding each layer on its own reports type errors against the right file
+		var typed FullConfig
+		if err := yaml.Unmarshal(layer.Data, &typed); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", layer.Source(), err)
+		}
+
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(layer.Data, &values); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", layer.Source(), err)
+		}
+		if i < len(layers)-1 {
+			if err := rebaseLayer(values, layer.Location); err != nil {
+				return nil, nil, err
+			}
+		}
+		mergeConfigValues(merged, values, "", layer.Source(), prov)
+	}
+
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	var fullConfig FullConfig
+	if err := yaml.Unmarshal(data, &fullConfig); err != nil {
+		return nil, nil, err
+	}
+	if len(layers) > 0 {
+		// The config's own extends, not those of its bases
+		var head struct {
+			Extends Extends `yaml:"extends"`
+		}
+		_ = yaml.Unmarshal(layers[len(layers)-1].Data, &head)
+		fullConfig.Extends = head.Extends
+	}
+	return &fullConfig, prov, nil
+}
+
+// rebaseLayer makes the scope prompt files of a base config relative to the
+// working directory. Remote base configs can't refer to files.
+func rebaseLayer(values map[string]interface{}, loc configLocation) error {
+	scopes, _ := values["scopes"].([]interface{})
+	for i, item := range scopes {
+		scope, _ := item.(map[string]interface{})
+		promptFile, _ := scope["prompt_file"].(string)
+		if promptFile == "" {
+			continue
+		}
+		if loc.Owner != "" {
+			return fmt.Errorf("%s: scopes[%d].prompt_file is not supported in a base config from another repository (use prompt)", loc, i)
+		}
+		if !filepath.IsAbs(promptFile) {
+			scope["prompt_file"] = filepath.Join(filepath.Dir(loc.Path), filepath.FromSlash(promptFile))
+		}
+	}
+	return nil
+}
+
+// mergeConfigValues merges one layer's values onto dst. Mappings merge key
+// by key, appendedLists add up, scopes replace those of the same name and
+// everything else is replaced. Empty values don't override.
+func mergeConfigValues(dst, src map[string]interface{}, prefix, source string, prov Provenance) {
+	for key, value := range src {
+		p := key
+		if prefix != "" {
+			p = prefix + "." + key
+		}
+		if p == "extends" || value == nil {
+			continue
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			existing, ok := dst[key].(map[string]interface{})
+			if !ok {
+				existing = make(map[string]interface{})
+				dst[key] = existing
+				prov.clear(p)
+			}
+			mergeConfigValues(existing, v, p, source, prov)
+
+		case []interface{}:
+			switch {
+			case p == "scopes":
+				dst[key] = mergeNamedItems(dst[key], v, p, source, prov)
+			case appendedLists[p]:
+				dst[key] = appendItems(dst[key], v, p, source, prov)
+				if opposite, ok := oppositeLists[p]; ok && dst[opposite] != nil {
+					dst[opposite] = removeItems(dst[opposite], v)
+				}
+			default:
+				dst[key] = v
+				prov.clear(p)
+				prov[p] = source
+			}
+
+		default:
+			dst[key] = v
+			prov.clear(p)
+			prov[p] = source
+		}
+	}
+}
+
+// ItemKey identifies an item of the list at the dotted path p by its value,
+// or its name or title in scopes and accepted findings
+func ItemKey(p string, item interface{}) string {
+	if m, ok := item.(map[string]interface{}); ok {
+		field := itemKeyFields[p[strings.LastIndex(p, ".")+1:]]
+		if s, ok := m[field].(string); ok && field != "" {
+			return s
+		}
+	}
+	return fmt.Sprint(item)
+}
+
+// appendItems adds the items not already in a list
+func appendItems(existing interface{}, items []interface{}, p, source string, prov Provenance) []interface{} {
+	list, _ := existing.([]interface{})
+	seen := make(map[string]bool)
+	for _, item := range list {
+		seen[ItemKey(p, item)] = true
+	}
+	for _, item := range items {
+		key := ItemKey(p, item)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		list = append(list, item)
+		prov[p+"["+key+"]"] = source
+	}
+	return list
+}
+
+// mergeNamedItems replaces the items of the same name and appends the rest
+func mergeNamedItems(existing interface{}, items []interface{}, p, source string, prov Provenance) []interface{} {
+	list, _ := existing.([]interface{})
+	for _, item := range items {
+		key := ItemKey(p, item)
+		replaced := false
+		for i := range list {
+			if ItemKey(p, list[i]) == key {
+				list[i] = item
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			list = append(list, item)
+		}
+		prov[p+"["+key+"]"] = source
+	}
+	return list
+}
+
+// removeItems drops the items of a list that are among items
+func removeItems(existing interface{}, items []interface{}) interface{} {
+	list, ok := existing.([]interface{})
+	if !ok {
+		return existing
+	}
+	drop := make(map[string]bool)
+	for _, item := range items {
+		drop[ItemKey("", item)] = true
+	}
+	kept := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		if !drop[ItemKey("", item)] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// LoadProvenance returns the base configs .github/autoengineer.yaml extends,
+// in the order they apply, and where each of its effective settings came from
+func LoadProvenance() ([]string, Provenance, error) {
+	configPath := findConfigFile(".github/autoengineer.yaml", ".github/autoengineer.yml")
+	if configPath == "" {
+		return nil, Provenance{}, nil
+	}
+
+	layers, err := loadConfigLayers(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, prov, err := mergeConfigLayers(layers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var bases []string
+	for _, layer := range layers[:len(layers)-1] {
+		bases = append(bases, layer.Source())
+	}
+	return bases, prov, nil
+}
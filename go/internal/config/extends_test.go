@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// inRepo writes files to a temporary repository and makes it the working directory
+func inRepo(t *testing.T, files map[string]string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, files)
+
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	os.Chdir(tmpDir)
+}
+
+func TestExtendsMergesBases(t *testing.T) {
+	inRepo(t, map[string]string{
+		"shared/base.yaml": `scanners:
+  disabled: [trivy, checkov]
+llm:
+  backend: openai
+  model: llama3.1
+scopes:
+  - name: cost
+    prompt: base cost
+  - name: docs
+    prompt_file: docs.md
+defaults:
+  min_severity: medium
+  label: platform
+profiles:
+  ci:
+    scan_only: true
+ignore:
+  ignore_paths: ["examples/**"]
+  rules:
+    - {title: logging, files: ["legacy/"], reason: decommissioned}
+    - {title: logging, files: ["archive/"], reason: read only}
+`,
+		".github/autoengineer.yaml": `extends: ../shared/base.yaml
+scanners:
+  enabled: [checkov]
+llm:
+  model: qwen
+scopes:
+  - name: cost
+    prompt: repo cost
+profiles:
+  ci:
+    fail_on: high
+`,
+		".github/autoengineer-ignore.yaml": "ignore_paths: [\"vendor/**\"]\n",
+	})
+
+	cfg, err := loadFullConfig()
+	if err != nil {
+		t.Fatalf("loadFullConfig: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Scanners.Enabled, []string{"checkov"}) || !reflect.DeepEqual(cfg.Scanners.Disabled, []string{"trivy"}) {
+		t.Errorf("enabling a scanner should cancel the base disabling it, got %+v", cfg.Scanners)
+	}
+	if cfg.LLM.Backend != LLMBackendOpenAI || cfg.LLM.Model != "qwen" {
+		t.Errorf("llm should merge key by key, got %+v", cfg.LLM)
+	}
+	if len(cfg.Scopes) != 2 || cfg.Scopes[0].Prompt != "repo cost" || cfg.Scopes[1].PromptFile != "shared/docs.md" {
+		t.Errorf("scopes should replace by name with base prompt files rebased, got %+v", cfg.Scopes)
+	}
+	if cfg.Profiles["ci"]["scan_only"] != true || cfg.Profiles["ci"]["fail_on"] != "high" || cfg.Defaults["label"] != "platform" {
+		t.Errorf("settings should merge key by key, got defaults %v profiles %v", cfg.Defaults, cfg.Profiles)
+	}
+
+	ignore, err := LoadIgnoreConfig()
+	if err != nil {
+		t.Fatalf("LoadIgnoreConfig: %v", err)
+	}
+	if !reflect.DeepEqual(ignore.IgnorePaths, []string{"examples/**", "vendor/**"}) {
+		t.Errorf("shared ignore paths should come before the repository's, got %v", ignore.IgnorePaths)
+	}
+	if len(ignore.Rules) != 2 {
+		t.Errorf("rules sharing a title should both be kept, got %+v", ignore.Rules)
+	}
+
+	bases, prov, err := LoadProvenance()
+	if err != nil {
+		t.Fatalf("LoadProvenance: %v", err)
+	}
+	if !reflect.DeepEqual(bases, []string{"shared/base.yaml"}) {
+		t.Errorf("unexpected bases %v", bases)
+	}
+	for _, tt := range []struct{ path, item, want string }{
+		{"llm.backend", "", "shared/base.yaml"},
+		{"llm.model", "", ".github/autoengineer.yaml"},
+		{"scanners.disabled", "trivy", "shared/base.yaml"},
+		{"scanners.enabled", "checkov", ".github/autoengineer.yaml"},
+		{"scopes", "cost", ".github/autoengineer.yaml"},
+		{"scopes", "docs", "shared/base.yaml"},
+		{"profiles.ci.scan_only", "", "shared/base.yaml"},
+		{"profiles.ci.fail_on", "", ".github/autoengineer.yaml"},
+		{"verification.mode", "", ""},
+	} {
+		got := prov.Source(tt.path)
+		if tt.item != "" {
+			got = prov.Item(tt.path, tt.item)
+		}
+		if got != tt.want {
+			t.Errorf("source of %s %s = %q, want %q", tt.path, tt.item, got, tt.want)
+		}
+	}
+}
+
+func TestExtendsFetchesAndCachesRemoteBases(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	inRepo(t, map[string]string{
+		".github/autoengineer.yaml": "extends: octo/platform:autoengineer/base.yaml@v1\n",
+	})
+
+	var fetched []string
+	oldFetch := fetchRemoteFile
+	defer func() { fetchRemoteFile = oldFetch }()
+	fetchRemoteFile = func(owner, repo, file, ref string) ([]byte, error) {
+		fetched = append(fetched, fmt.Sprintf("%s/%s:%s@%s", owner, repo, file, ref))
+		switch file {
+		case "autoengineer/base.yaml":
+			return []byte("extends: common.yaml\nllm:\n  model: qwen\n"), nil
+		case "autoengineer/common.yaml":
+			return []byte("llm:\n  backend: openai\n  model: llama3.1\n"), nil
+		}
+		return nil, fmt.Errorf("not found")
+	}
+
+	for i := 0; i < 2; i++ {
+		cfg, err := loadFullConfig()
+		if err != nil {
+			t.Fatalf("loadFullConfig: %v", err)
+		}
+		if cfg.LLM.Backend != LLMBackendOpenAI || cfg.LLM.Model != "qwen" {
+			t.Errorf("unexpected llm config %+v", cfg.LLM)
+		}
+	}
+
+	want := []string{"octo/platform:autoengineer/base.yaml@v1", "octo/platform:autoengineer/common.yaml@v1"}
+	if !reflect.DeepEqual(fetched, want) {
+		t.Errorf("each base should be fetched once, relative to its repository, got %v", fetched)
+	}
+
+	// A stale copy is used when GitHub can't be reached
+	fetchRemoteFile = func(owner, repo, file, ref string) ([]byte, error) {
+		return nil, fmt.Errorf("offline")
+	}
+	cachePath, err := configLocation{Owner: "octo", Repo: "platform", Ref: "v1", Path: "autoengineer/base.yaml"}.cachePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * ExtendsCacheTTL)
+	if err := os.Chtimes(cachePath, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadFullConfig(); err != nil {
+		t.Errorf("expected the stale cache to be used, got %v", err)
+	}
+}
+
+func TestExtendsCycle(t *testing.T) {
+	inRepo(t, map[string]string{
+		".github/autoengineer.yaml": "extends: ../a.yaml\n",
+		"a.yaml":                    "extends: b.yaml\n",
+		"b.yaml":                    "extends: a.yaml\n",
+	})
+
+	_, err := loadFullConfig()
+	if err == nil || !strings.Contains(err.Error(), "extends cycle: .github/autoengineer.yaml → a.yaml → b.yaml → a.yaml") {
+		t.Errorf("expected an extends cycle error, got %v", err)
+	}
+}
+
+func TestValidateChecksBases(t *testing.T) {
+	problems := validateIn(t, map[string]string{
+		".github/autoengineer.yaml": "extends: [../base.yaml, ../missing.yaml]\nllm:\n  backend: openai\n",
+		"base.yaml":                 "llm:\n  model: llama3.1\nscanners:\n  disabled: [trivvy]\n",
+	})
+
+	want := []string{
+		`.github/autoengineer.yaml:1:1: failed to read base config missing.yaml: open missing.yaml: no such file or directory`,
+		`base.yaml:4:14: scanners.disabled[0] must be one of checkov, trivy, aikido`,
+	}
+	if got := strings.Join(problems, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("unexpected problems:\n%s\n\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
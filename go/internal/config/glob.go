@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// NormalizePath returns a file path in the repo-relative form globs match
+// against: slash-separated, without a leading "./" or "/". Scanners report
+// paths like "/modules/s3/main.tf" relative to the directory they scanned.
+func NormalizePath(name string) string {
+	name = filepath.ToSlash(strings.TrimSpace(name))
+	for {
+		switch {
+		case strings.HasPrefix(name, "./"):
+			name = name[2:]
+		case strings.HasPrefix(name, "/"):
+			name = name[1:]
+		default:
+			return name
+		}
+	}
+}
+
+// MatchGlob reports whether a slash-separated relative path matches a
+// gitignore-style glob pattern. Besides the path.Match syntax, a "**" segment
+// matches any number of directories and a pattern without a slash (other
+// than a trailing one) matches at any depth. A leading "/" anchors the
+// pattern to the root, a trailing "/" matches only directories, and a
+// pattern matching a directory matches everything below it.
+func MatchGlob(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "./")
+	name = NormalizePath(name)
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if strings.HasPrefix(pattern, "/") {
+		pattern = strings.TrimPrefix(pattern, "/")
+	} else if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+
+	// Try the path itself (unless only directories match), then each parent
+	patternSegments := strings.Split(pattern, "/")
+	nameSegments := strings.Split(name, "/")
+	end := len(nameSegments)
+	if dirOnly {
+		end--
+	}
+	for i := end; i > 0; i-- {
+		if matchSegments(patternSegments, nameSegments[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchGlobs reports whether a path matches a list of gitignore-style
+// patterns. The last pattern matching the path decides: a pattern starting
+// with "!" re-includes paths an earlier pattern matched. Unlike git, files
+// below an excluded directory can be re-included.
+func MatchGlobs(patterns []string, name string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		// Only patterns that would change the result need matching
+		if matched != negated {
+			continue
+		}
+		if MatchGlob(strings.TrimPrefix(pattern, "!"), name) {
+			matched = !negated
+		}
+	}
+	return matched
+}
+
+// RebaseGlob makes a pattern written relative to dir relative to the root
+// instead. Patterns matching at any depth still do so, but only below dir.
+func RebaseGlob(dir, pattern string) string {
+	negation := ""
+	if strings.HasPrefix(pattern, "!") {
+		negation, pattern = "!", pattern[1:]
+	}
+	pattern = strings.TrimPrefix(pattern, "./")
+
+	switch {
+	case strings.HasPrefix(pattern, "/"):
+		pattern = dir + pattern
+	case !strings.Contains(strings.TrimSuffix(pattern, "/"), "/"):
+		pattern = dir + "/**/" + pattern
+	default:
+		pattern = dir + "/" + pattern
+	}
+	return negation + pattern
+}
+
+// ValidateGlob reports a malformed glob pattern
+func ValidateGlob(pattern string) error {
+	if strings.Trim(strings.TrimPrefix(pattern, "!"), "/") == "" {
+		return fmt.Errorf("invalid glob %q: empty pattern", pattern)
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(pattern, "!"), "/") {
+		if segment == "**" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchSegments matches pattern segments against path segments
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Collapse consecutive ** and try every possible split
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				// A trailing ** matches what is inside a directory, not the directory itself
+				return len(name) > 0
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0
+}
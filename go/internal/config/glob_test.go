@@ -0,0 +1,131 @@
+package config
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.tf", "main.tf", true},
+		{"*.tf", "modules/vpc/main.tf", true},
+		{"modules/*.tf", "modules/main.tf", true},
+		{"modules/*.tf", "modules/vpc/main.tf", false},
+		{"modules/**", "modules/vpc/main.tf", true},
+		{"modules/**/*.tf", "modules/main.tf", true},
+		{"modules/**/*.tf", "modules/a/b/main.tf", true},
+		{"**/testdata/**", "pkg/testdata/x.json", true},
+		{".github/workflows/*.yml", ".github/workflows/ci.yml", true},
+		{".github/workflows/*.yml", "ci.yml", false},
+		{"./charts/**", "charts/app/values.yaml", true},
+		{"Dockerfile", "services/api/Dockerfile", true},
+		{"modules/**/examples/*", "modules/examples/main.tf", true},
+		{"modules/**/examples/*", "modules/vpc/aws/examples/basic/main.tf", true},
+		{"modules/**/examples/*", "modules/vpc/main.tf", false},
+		{"**/testdata/**", "testdata/x.json", true},
+		{"**/testdata/**", "a/b/testdata/c/d.json", true},
+		{"**/testdata/**", "testdata.json", false},
+		{"examples", "examples/basic/main.tf", true},
+		{"examples", "modules/vpc/examples/main.tf", true},
+		{"/examples", "modules/vpc/examples/main.tf", false},
+		{"/examples", "examples/main.tf", true},
+		{"vendor/", "vendor/lib/a.go", true},
+		{"vendor/", "pkg/vendor/a.go", true},
+		{"vendor/", "vendor", false},
+		{"a/**/b", "a/b", true},
+		{"a/**/b", "a/x/y/b", true},
+		{"a/**/b", "a/x/y/bb", false},
+		{"a/**", "a", false},
+		{"a/**", "a/b", true},
+		{"*.tf", "main.tfvars", false},
+		{"modules/**", "/modules/s3/main.tf", true},
+		{"/sg.tf", "/sg.tf", true},
+	}
+
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"/modules/s3/main.tf":  "modules/s3/main.tf",
+		"./modules/s3/main.tf": "modules/s3/main.tf",
+		"/./sg.tf":             "sg.tf",
+		" sg.tf ":              "sg.tf",
+		"sg.tf":                "sg.tf",
+	}
+	for name, want := range tests {
+		if got := NormalizePath(name); got != want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestValidateGlob(t *testing.T) {
+	if err := ValidateGlob("modules/**/*.tf"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateGlob("modules/[.tf"); err == nil {
+		t.Error("expected error for malformed glob")
+	}
+	if err := ValidateGlob("!examples/keep/**"); err != nil {
+		t.Errorf("unexpected error for negated glob: %v", err)
+	}
+	for _, pattern := range []string{"!", "!/", "/"} {
+		if err := ValidateGlob(pattern); err == nil {
+			t.Errorf("expected error for empty glob %q", pattern)
+		}
+	}
+}
+
+func TestMatchGlobs(t *testing.T) {
+	patterns := []string{"examples/**", "!examples/keep/**", "examples/keep/secret.tf", "!*.md", `\!literal`}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"examples/basic/main.tf", true},
+		{"examples/keep/main.tf", false},
+		{"examples/keep/secret.tf", true},
+		{"examples/README.md", false},
+		{"main.tf", false},
+		{"!literal", true},
+	}
+
+	for _, tt := range tests {
+		if got := MatchGlobs(patterns, tt.path); got != tt.want {
+			t.Errorf("MatchGlobs(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+	if MatchGlobs([]string{"!examples/**"}, "examples/a.tf") {
+		t.Error("a negation alone should not match")
+	}
+}
+
+func TestRebaseGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"fixtures/*", "services/api/fixtures/*"},
+		{"./fixtures/*", "services/api/fixtures/*"},
+		{"*.md", "services/api/**/*.md"},
+		{"tmp/", "services/api/**/tmp/"},
+		{"/generated", "services/api/generated"},
+		{"!fixtures/keep.tf", "!services/api/fixtures/keep.tf"},
+		{"!*.md", "!services/api/**/*.md"},
+	}
+
+	for _, tt := range tests {
+		if got := RebaseGlob("services/api", tt.pattern); got != tt.want {
+			t.Errorf("RebaseGlob(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+	if !MatchGlob(RebaseGlob("services/api", "*.md"), "services/api/docs/a.md") || MatchGlob(RebaseGlob("services/api", "*.md"), "docs/a.md") {
+		t.Error("a rebased pattern should match at any depth below its directory only")
+	}
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
-	"path/filepath"
+	"regexp"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -13,6 +15,7 @@ type IgnoreConfig struct {
 	IgnorePaths    []string       `yaml:"ignore_paths"`
 	IgnorePatterns []string       `yaml:"ignore_patterns"`
 	DisabledScopes []string       `yaml:"disabled_scopes"`
+	Rules          []IgnoreRule   `yaml:"rules,omitempty"`
 }
 
 // AcceptedItem represents an accepted finding
@@ -21,24 +24,132 @@ type AcceptedItem struct {
 	Reason       string `yaml:"reason,omitempty"`
 	AcceptedBy   string `yaml:"accepted_by,omitempty"`
 	AcceptedDate string `yaml:"accepted_date,omitempty"`
+
+	// Expires is the last day the acceptance applies; afterwards the
+	// finding is reported again
+	Expires string `yaml:"expires,omitempty"`
 }
 
-// LoadIgnoreConfig loads the ignore configuration from .github/autoengineer-ignore.yaml
-// Returns an empty config if the file doesn't exist
-func LoadIgnoreConfig() (*IgnoreConfig, error) {
-	// Check for both .yaml and .yml extensions
-	paths := []string{
-		".github/autoengineer-ignore.yaml",
-		".github/autoengineer-ignore.yml",
+// DateLayout is the format of accepted_date and expires
+const DateLayout = "2006-01-02"
+
+// ParseDate parses a YYYY-MM-DD date
+func ParseDate(s string) (time.Time, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (must be YYYY-MM-DD)", s)
 	}
+	return t, nil
+}
 
-	var configPath string
-	for _, path := range paths {
-		if _, err := os.Stat(path); err == nil {
-			configPath = path
-			break
+// Validate checks the acceptance's dates
+func (a AcceptedItem) Validate() error {
+	var accepted, expires time.Time
+	var err error
+	if a.AcceptedDate != "" {
+		if accepted, err = ParseDate(a.AcceptedDate); err != nil {
+			return fmt.Errorf("accepted_date: %w", err)
+		}
+	}
+	if a.Expires != "" {
+		if expires, err = ParseDate(a.Expires); err != nil {
+			return fmt.Errorf("expires: %w", err)
 		}
 	}
+	if !accepted.IsZero() && !expires.IsZero() && expires.Before(accepted) {
+		return fmt.Errorf("expires %s is before accepted_date %s", a.Expires, a.AcceptedDate)
+	}
+	return nil
+}
+
+// ExpiresAt returns when the acceptance lapses, the end of its expires day
+// in UTC, and false when it doesn't expire. An expires date that can't be
+// parsed has already lapsed, so a typo doesn't accept a risk forever.
+func (a AcceptedItem) ExpiresAt() (time.Time, bool) {
+	if a.Expires == "" {
+		return time.Time{}, false
+	}
+	day, err := ParseDate(a.Expires)
+	if err != nil {
+		return time.Time{}, true
+	}
+	return day.AddDate(0, 0, 1), true
+}
+
+// Expired reports whether the acceptance has lapsed at now
+func (a AcceptedItem) Expired(now time.Time) bool {
+	at, ok := a.ExpiresAt()
+	return ok && !now.Before(at)
+}
+
+// IgnoreRule suppresses the findings matching all of its conditions
+type IgnoreRule struct {
+	// Title is a regular expression matched case-insensitively against the title
+	Title    string `yaml:"title,omitempty"`
+	Category string `yaml:"category,omitempty"`
+	Severity string `yaml:"severity,omitempty"`
+	// Rule is the scanner check ID, e.g. CKV_AWS_20 or AVD-AWS-0086
+	Rule string `yaml:"rule,omitempty"`
+	// Files are gitignore-style globs; a finding matches when any of its files does
+	Files       []string `yaml:"files,omitempty"`
+	Fingerprint string   `yaml:"fingerprint,omitempty"`
+	Reason      string   `yaml:"reason"`
+	// Strip removes only the matching files from a finding instead of
+	// ignoring it, which happens once no files are left
+	Strip bool `yaml:"strip,omitempty"`
+}
+
+// TitleRegexp compiles the title condition, nil when there is none
+func (r IgnoreRule) TitleRegexp() (*regexp.Regexp, error) {
+	return compileTitle(r.Title)
+}
+
+// compileTitle compiles a case-insensitive title regexp, nil when empty
+func compileTitle(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, fmt.Errorf("invalid title regexp: %w", err)
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// Validate checks that the rule has a reason and at least one valid condition
+func (r IgnoreRule) Validate() error {
+	if err := r.checkConditions(); err != nil {
+		return err
+	}
+	if _, err := r.TitleRegexp(); err != nil {
+		return err
+	}
+	if r.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	return nil
+}
+
+// checkConditions checks that the rule has a condition, and files to strip
+func (r IgnoreRule) checkConditions() error {
+	if r.Title == "" && r.Category == "" && r.Severity == "" && r.Rule == "" && len(r.Files) == 0 && r.Fingerprint == "" {
+		return fmt.Errorf("needs at least one of title, category, severity, rule, files or fingerprint")
+	}
+	if r.Strip && len(r.Files) == 0 {
+		return fmt.Errorf("strip needs files to strip")
+	}
+	return nil
+}
+
+// IgnoreFilePath returns the path of the ignore file, checking both the .yaml
+// and .yml extensions, or "" if there is none
+func IgnoreFilePath() string {
+	return findConfigFile(".github/autoengineer-ignore.yaml", ".github/autoengineer-ignore.yml")
+}
+
+// loadIgnoreFile loads the ignore configuration from .github/autoengineer-ignore.yaml
+// Returns an empty config if the file doesn't exist
+func loadIgnoreFile() (*IgnoreConfig, error) {
+	configPath := IgnoreFilePath()
 
 	// Return empty config if no file found
 	if configPath == "" {
@@ -58,11 +169,43 @@ func LoadIgnoreConfig() (*IgnoreConfig, error) {
 	return &config, nil
 }
 
-// GetAcceptedTitles returns a map of accepted finding titles for quick lookup
+// LoadIgnoreConfig loads the ignore configuration from .github/autoengineer-ignore.yaml,
+// added to the ignore settings shared through the extends of .github/autoengineer.yaml
+// Returns an empty config if neither has any
+func LoadIgnoreConfig() (*IgnoreConfig, error) {
+	config, err := loadIgnoreFile()
+	if err != nil {
+		return nil, err
+	}
+	fullConfig, err := loadFullConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	base := fullConfig.Ignore
+	if base == nil {
+		return config, nil
+	}
+	return &IgnoreConfig{
+		Accepted:       append(append([]AcceptedItem(nil), base.Accepted...), config.Accepted...),
+		IgnorePaths:    append(append([]string(nil), base.IgnorePaths...), config.IgnorePaths...),
+		IgnorePatterns: append(append([]string(nil), base.IgnorePatterns...), config.IgnorePatterns...),
+		DisabledScopes: append(append([]string(nil), base.DisabledScopes...), config.DisabledScopes...),
+		Rules:          append(append([]IgnoreRule(nil), base.Rules...), config.Rules...),
+	}, nil
+}
+
+// GetAcceptedTitles returns a map of accepted finding titles for quick
+// lookup, false for titles whose acceptances have all expired
 func (c *IgnoreConfig) GetAcceptedTitles() map[string]bool {
+	return c.AcceptedTitlesAt(time.Now())
+}
+
+// AcceptedTitlesAt is GetAcceptedTitles at the given time
+func (c *IgnoreConfig) AcceptedTitlesAt(now time.Time) map[string]bool {
 	titles := make(map[string]bool)
 	for _, item := range c.Accepted {
-		titles[item.Title] = true
+		titles[item.Title] = titles[item.Title] || !item.Expired(now)
 	}
 	return titles
 }
@@ -77,29 +220,8 @@ func (c *IgnoreConfig) IsScopeDisabled(scope string) bool {
 	return false
 }
 
-// MatchesPath checks if a file path matches any ignore patterns
+// MatchesPath checks if a file path matches the ignore paths, which are
+// gitignore-style globs where a later "!pattern" re-includes paths
 func (c *IgnoreConfig) MatchesPath(path string) bool {
-	for _, pattern := range c.IgnorePaths {
-		matched, err := filepath.Match(pattern, path)
-		if err == nil && matched {
-			return true
-		}
-		// Also check if pattern matches any parent directory
-		if matchesGlobPattern(path, pattern) {
-			return true
-		}
-	}
-	return false
-}
-
-// matchesGlobPattern handles ** patterns for directory matching
-func matchesGlobPattern(path, pattern string) bool {
-	// Simple implementation for common patterns like "examples/*" or "**/testdata/**"
-	// This is a simplified version - could be enhanced with a proper glob library
-	if len(pattern) > 2 && pattern[0:2] == "**" {
-		// Pattern like **/testdata/**
-		matched, err := filepath.Match(pattern[2:], path)
-		return err == nil && matched
-	}
-	return false
+	return MatchGlobs(c.IgnorePaths, path)
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadIgnoreConfig(t *testing.T) {
@@ -152,3 +153,55 @@ func TestMatchesPath(t *testing.T) {
 		})
 	}
 }
+
+func TestAcceptedItemExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expires string
+		expired bool
+	}{
+		{"", false},
+		{"2026-03-10", false},
+		{"2026-03-09", true},
+		{"10/03/2026", true},
+	}
+	for _, tt := range tests {
+		if got := (AcceptedItem{Expires: tt.expires}).Expired(now); got != tt.expired {
+			t.Errorf("Expired() with expires %q = %v, want %v", tt.expires, got, tt.expired)
+		}
+	}
+
+	cfg := &IgnoreConfig{Accepted: []AcceptedItem{
+		{Title: "Open security group", Expires: "2026-01-01"},
+		{Title: "Public bucket", Expires: "2026-01-01"},
+		{Title: "Public bucket", Expires: "2026-12-31"},
+	}}
+	titles := cfg.AcceptedTitlesAt(now)
+	if active, ok := titles["Open security group"]; !ok || active {
+		t.Error("an expired acceptance should be listed as inactive")
+	}
+	if !titles["Public bucket"] {
+		t.Error("a title with any active acceptance should stay accepted")
+	}
+}
+
+func TestAcceptedItemValidate(t *testing.T) {
+	tests := []struct {
+		item AcceptedItem
+		want string
+	}{
+		{AcceptedItem{AcceptedDate: "2026-01-15", Expires: "2026-07-15"}, ""},
+		{AcceptedItem{AcceptedDate: "15 Jan 2026"}, `accepted_date: invalid date "15 Jan 2026" (must be YYYY-MM-DD)`},
+		{AcceptedItem{Expires: "2026-02-30"}, `expires: invalid date "2026-02-30" (must be YYYY-MM-DD)`},
+		{AcceptedItem{AcceptedDate: "2026-01-15", Expires: "2025-12-31"}, "expires 2025-12-31 is before accepted_date 2026-01-15"},
+	}
+	for _, tt := range tests {
+		got := ""
+		if err := tt.item.Validate(); err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Errorf("Validate(%+v) = %q, want %q", tt.item, got, tt.want)
+		}
+	}
+}
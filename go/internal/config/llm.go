@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// LLM backend names
+const (
+	LLMBackendCopilot = "copilot"
+	LLMBackendOpenAI  = "openai"
+)
+
+const (
+	defaultOpenAIBaseURL   = "https://api.openai.com/v1"
+	defaultOpenAIAPIKeyEnv = "OPENAI_API_KEY"
+	defaultLLMTimeout      = 10 * time.Minute
+)
+
+// LLMConfig selects the language model backend used for analysis and deduplication
+type LLMConfig struct {
+	// Backend is "copilot" (default, uses the copilot CLI) or "openai"
+	// (any OpenAI-compatible chat completions API, e.g. Ollama or vLLM)
+	Backend string `yaml:"backend"`
+
+	// BaseURL is the API root for the openai backend, e.g. http://localhost:11434/v1
+	BaseURL string `yaml:"base_url,omitempty"`
+
+	// Model is the model name sent to the openai backend
+	Model string `yaml:"model,omitempty"`
+
+	// APIKeyEnv names the environment variable holding the API key (optional for local servers)
+	APIKeyEnv string `yaml:"api_key_env,omitempty"`
+
+	// TimeoutSeconds bounds a single request to the openai backend
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+
+	// RepairAttempts is how many times an invalid analysis response is re-prompted
+	// with its validation errors (0 disables repair)
+	RepairAttempts *int `yaml:"repair_attempts,omitempty"`
+}
+
+// LoadLLMConfig loads the llm section from .github/autoengineer.yaml
+// Returns a config selecting the copilot backend if the file or section doesn't exist
+func LoadLLMConfig() (*LLMConfig, error) {
+	fullConfig, err := loadFullConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &LLMConfig{}
+	if fullConfig.LLM != nil {
+		cfg = fullConfig.LLM
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// BackendName returns the configured backend, defaulting to copilot
+func (c *LLMConfig) BackendName() string {
+	if c == nil || c.Backend == "" {
+		return LLMBackendCopilot
+	}
+	return c.Backend
+}
+
+// Validate checks that the backend is known and has the settings it needs
+func (c *LLMConfig) Validate() error {
+	if c.RepairAttempts != nil && *c.RepairAttempts < 0 {
+		return fmt.Errorf("llm.repair_attempts must not be negative")
+	}
+
+	switch c.BackendName() {
+	case LLMBackendCopilot:
+		return nil
+	case LLMBackendOpenAI:
+		if c.Model == "" {
+			return fmt.Errorf("llm.model is required for the %s backend", LLMBackendOpenAI)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown llm.backend %q (must be %s or %s)", c.Backend, LLMBackendCopilot, LLMBackendOpenAI)
+	}
+}
+
+// EffectiveBaseURL returns the API root for the openai backend
+func (c *LLMConfig) EffectiveBaseURL() string {
+	if c.BaseURL == "" {
+		return defaultOpenAIBaseURL
+	}
+	return c.BaseURL
+}
+
+// APIKey returns the API key from the configured environment variable, if set
+func (c *LLMConfig) APIKey() string {
+	env := c.APIKeyEnv
+	if env == "" {
+		env = defaultOpenAIAPIKeyEnv
+	}
+	return os.Getenv(env)
+}
+
+// Timeout returns the per-request timeout for the openai backend
+func (c *LLMConfig) Timeout() time.Duration {
+	if c.TimeoutSeconds <= 0 {
+		return defaultLLMTimeout
+	}
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// MaxRepairAttempts returns the configured repair attempts, or def if unset
+func (c *LLMConfig) MaxRepairAttempts(def int) int {
+	if c == nil || c.RepairAttempts == nil {
+		return def
+	}
+	return *c.RepairAttempts
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadLLMConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	githubDir := filepath.Join(tmpDir, ".github")
+	if err := os.Mkdir(githubDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	t.Run("no config file", func(t *testing.T) {
+		cfg, err := LoadLLMConfig()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.BackendName() != LLMBackendCopilot {
+			t.Errorf("expected default backend %q, got %q", LLMBackendCopilot, cfg.BackendName())
+		}
+	})
+
+	t.Run("openai backend", func(t *testing.T) {
+		content := `
+scanners:
+  disabled:
+    - checkov
+llm:
+  backend: openai
+  base_url: http://localhost:11434/v1
+  model: llama3.1
+  api_key_env: OLLAMA_KEY
+  timeout_seconds: 30
+`
+		if err := os.WriteFile(filepath.Join(githubDir, "autoengineer.yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := LoadLLMConfig()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.BackendName() != LLMBackendOpenAI {
+			t.Errorf("expected backend %q, got %q", LLMBackendOpenAI, cfg.BackendName())
+		}
+		if cfg.EffectiveBaseURL() != "http://localhost:11434/v1" {
+			t.Errorf("unexpected base URL %q", cfg.EffectiveBaseURL())
+		}
+		if cfg.Model != "llama3.1" {
+			t.Errorf("unexpected model %q", cfg.Model)
+		}
+		if cfg.Timeout() != 30*time.Second {
+			t.Errorf("unexpected timeout %v", cfg.Timeout())
+		}
+
+		t.Setenv("OLLAMA_KEY", "local-key")
+		if cfg.APIKey() != "local-key" {
+			t.Errorf("expected API key from OLLAMA_KEY, got %q", cfg.APIKey())
+		}
+
+		// Scanner config must still load from the same file
+		scannerCfg, err := LoadScannerConfig()
+		if err != nil {
+			t.Fatalf("expected no error loading scanner config, got %v", err)
+		}
+		if !scannerCfg.IsDisabled("checkov") {
+			t.Error("expected checkov to be disabled")
+		}
+	})
+
+	t.Run("openai backend without model", func(t *testing.T) {
+		content := "llm:\n  backend: openai\n"
+		if err := os.WriteFile(filepath.Join(githubDir, "autoengineer.yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := LoadLLMConfig(); err == nil {
+			t.Error("expected error for missing model")
+		}
+	})
+}
+
+func TestLLMConfigDefaults(t *testing.T) {
+	cfg := &LLMConfig{Backend: LLMBackendOpenAI, Model: "gpt-4o"}
+
+	if cfg.EffectiveBaseURL() != defaultOpenAIBaseURL {
+		t.Errorf("expected default base URL, got %q", cfg.EffectiveBaseURL())
+	}
+	if cfg.Timeout() != defaultLLMTimeout {
+		t.Errorf("expected default timeout, got %v", cfg.Timeout())
+	}
+
+	var nilCfg *LLMConfig
+	if nilCfg.BackendName() != LLMBackendCopilot {
+		t.Errorf("expected nil config to select copilot, got %q", nilCfg.BackendName())
+	}
+}
+
+func TestVerificationConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *VerificationConfig
+		wantMode string
+		wantErr  bool
+	}{
+		{name: "nil defaults to drop", cfg: nil, wantMode: VerificationModeDrop},
+		{name: "empty defaults to drop", cfg: &VerificationConfig{}, wantMode: VerificationModeDrop},
+		{name: "flag", cfg: &VerificationConfig{Mode: "flag"}, wantMode: VerificationModeFlag},
+		{name: "unknown mode", cfg: &VerificationConfig{Mode: "warn"}, wantErr: true},
+		{name: "similarity out of range", cfg: &VerificationConfig{MinSimilarity: 1.5}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.cfg != nil {
+				err := tt.cfg.Validate()
+				if tt.wantErr {
+					if err == nil {
+						t.Error("expected error, got nil")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if got := tt.cfg.EffectiveMode(); got != tt.wantMode {
+				t.Errorf("EffectiveMode() = %q, want %q", got, tt.wantMode)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigNames are the file names of nested project configuration
+var ProjectConfigNames = []string{"autoengineer.yaml", "autoengineer.yml"}
+
+// ProjectConfig is an autoengineer.yaml below the repository root. It applies
+// to its directory's subtree and builds on the configuration of its ancestors.
+type ProjectConfig struct {
+	// Name attributes findings to the project (defaults to the directory)
+	Name string `yaml:"name"`
+
+	// Inherit controls whether the root and parent project settings apply (default true)
+	Inherit *bool `yaml:"inherit,omitempty"`
+
+	Accepted       []AcceptedItem `yaml:"accepted"`
+	IgnorePaths    []string       `yaml:"ignore_paths"`
+	IgnorePatterns []string       `yaml:"ignore_patterns"`
+	DisabledScopes []string       `yaml:"disabled_scopes"`
+	Rules          []IgnoreRule   `yaml:"rules,omitempty"`
+
+	// EnabledScopes re-enables scopes disabled by an ancestor
+	EnabledScopes []string `yaml:"enabled_scopes"`
+
+	// Instructions and InstructionsFile (relative to the project directory)
+	// add custom instructions for the subtree
+	Instructions     string `yaml:"instructions"`
+	InstructionsFile string `yaml:"instructions_file"`
+}
+
+// Project is a directory with its own configuration, resolved against its ancestors
+type Project struct {
+	Name string
+
+	// Dir is the project directory relative to the repository root
+	Dir string
+
+	// ConfigPath is the project's autoengineer.yaml
+	ConfigPath string
+
+	// Ignore is the effective ignore configuration of the subtree,
+	// with ignore_paths relative to the repository root
+	Ignore *IgnoreConfig
+
+	// Instructions are the custom instructions of the project and its
+	// ancestor projects (the root instructions are not included)
+	Instructions string
+}
+
+// Contains reports whether a root-relative file path is inside the project
+func (p Project) Contains(file string) bool {
+	return strings.HasPrefix(file, p.Dir+"/")
+}
+
+// Projects are the nested project configurations of a repository
+type Projects struct {
+	root     *IgnoreConfig
+	projects []Project
+}
+
+// LoadProjects finds the nested autoengineer.yaml files among the tracked
+// files (slash-separated, relative to the working directory) and resolves
+// each against the root ignore configuration and its parent projects
+func LoadProjects(root *IgnoreConfig, files []string) (*Projects, error) {
+	if root == nil {
+		root = &IgnoreConfig{}
+	}
+
+	var configPaths []string
+	for _, file := range files {
+		dir := path.Dir(file)
+		if dir == "." || dir == ".github" || !isProjectConfigName(path.Base(file)) {
+			continue
+		}
+		configPaths = append(configPaths, file)
+	}
+
+	// Resolve parents before their children
+	sort.Slice(configPaths, func(i, j int) bool {
+		di, dj := strings.Count(configPaths[i], "/"), strings.Count(configPaths[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return configPaths[i] < configPaths[j]
+	})
+
+	p := &Projects{root: root}
+	seen := make(map[string]string)
+	for _, configPath := range configPaths {
+		dir := path.Dir(configPath)
+		if other, ok := seen[dir]; ok {
+			return nil, fmt.Errorf("%s: %s already configures this directory", configPath, other)
+		}
+		seen[dir] = configPath
+
+		var pc ProjectConfig
+		data, err := os.ReadFile(filepath.FromSlash(configPath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read project config: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &pc); err != nil {
+			return nil, fmt.Errorf("%s: %w", configPath, err)
+		}
+
+		project, err := p.resolve(dir, configPath, pc)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range p.projects {
+			if other.Name == project.Name {
+				return nil, fmt.Errorf("%s: project name %q is already used by %s", configPath, project.Name, other.ConfigPath)
+			}
+		}
+		p.projects = append(p.projects, project)
+	}
+
+	sort.Slice(p.projects, func(i, j int) bool { return p.projects[i].Dir < p.projects[j].Dir })
+	return p, nil
+}
+
+// isProjectConfigName reports whether a base name is a project config file name
+func isProjectConfigName(name string) bool {
+	for _, n := range ProjectConfigNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve merges a project config onto its parent (or the root config)
+func (p *Projects) resolve(dir, configPath string, pc ProjectConfig) (Project, error) {
+	project := Project{Name: pc.Name, Dir: dir, ConfigPath: configPath}
+	if project.Name == "" {
+		project.Name = dir
+	}
+
+	// Start from the parent project, the root config, or nothing
+	base := p.root
+	var instructions string
+	if parent := p.owner(dir + "/"); parent != nil {
+		base = parent.Ignore
+		instructions = parent.Instructions
+	}
+	if pc.Inherit != nil && !*pc.Inherit {
+		base = &IgnoreConfig{}
+		instructions = ""
+	}
+
+	ignore := &IgnoreConfig{
+		Accepted:       append(append([]AcceptedItem(nil), base.Accepted...), pc.Accepted...),
+		IgnorePatterns: append(append([]string(nil), base.IgnorePatterns...), pc.IgnorePatterns...),
+		IgnorePaths:    append([]string(nil), base.IgnorePaths...),
+		Rules:          append([]IgnoreRule(nil), base.Rules...),
+	}
+	for _, pattern := range pc.IgnorePaths {
+		ignore.IgnorePaths = append(ignore.IgnorePaths, RebaseGlob(dir, pattern))
+	}
+	for _, rule := range pc.Rules {
+		files := rule.Files
+		rule.Files = nil
+		for _, pattern := range files {
+			rule.Files = append(rule.Files, RebaseGlob(dir, pattern))
+		}
+		ignore.Rules = append(ignore.Rules, rule)
+	}
+
+	enabled := make(map[string]bool)
+	for _, s := range pc.EnabledScopes {
+		enabled[s] = true
+	}
+	for _, s := range append(append([]string(nil), base.DisabledScopes...), pc.DisabledScopes...) {
+		if !enabled[s] && !ignore.IsScopeDisabled(s) {
+			ignore.DisabledScopes = append(ignore.DisabledScopes, s)
+		}
+	}
+	project.Ignore = ignore
+
+	own := strings.TrimSpace(pc.Instructions)
+	if pc.InstructionsFile != "" {
+		data, err := os.ReadFile(filepath.Join(filepath.FromSlash(dir), pc.InstructionsFile))
+		if err != nil {
+			return Project{}, fmt.Errorf("%s: failed to read instructions_file: %w", configPath, err)
+		}
+		own = strings.TrimSpace(own + "\n\n" + string(data))
+	}
+	project.Instructions = strings.TrimSpace(instructions + "\n\n" + own)
+
+	return project, nil
+}
+
+// owner returns the deepest project containing a root-relative file path, or nil
+func (p *Projects) owner(file string) *Project {
+	var best *Project
+	for i := range p.projects {
+		if p.projects[i].Contains(file) && (best == nil || len(p.projects[i].Dir) > len(best.Dir)) {
+			best = &p.projects[i]
+		}
+	}
+	return best
+}
+
+// All returns the projects sorted by directory
+func (p *Projects) All() []Project {
+	if p == nil {
+		return nil
+	}
+	return append([]Project(nil), p.projects...)
+}
+
+// Owner returns the project owning a set of files: the deepest project
+// containing the first file that belongs to one. Returns nil for files
+// outside every project.
+func (p *Projects) Owner(files []string) *Project {
+	if p == nil {
+		return nil
+	}
+	for _, file := range files {
+		if project := p.owner(NormalizePath(file)); project != nil {
+			return project
+		}
+	}
+	return nil
+}
+
+// Ignore returns the effective ignore configuration of the named project,
+// or the root configuration when name is empty or unknown
+func (p *Projects) Ignore(name string) *IgnoreConfig {
+	if p == nil {
+		return &IgnoreConfig{}
+	}
+	for _, project := range p.projects {
+		if name != "" && project.Name == name {
+			return project.Ignore
+		}
+	}
+	return p.root
+}
+
+// Instructions returns the instructions of the projects owning files,
+// formatted for the analysis prompt. Each file is owned by its deepest
+// project, whose instructions already include those it inherits.
+func (p *Projects) Instructions(files []string) string {
+	if p == nil {
+		return ""
+	}
+
+	owners := make(map[string]bool)
+	for _, file := range files {
+		if project := p.owner(file); project != nil {
+			owners[project.Dir] = true
+		}
+	}
+
+	var sb strings.Builder
+	for _, project := range p.projects {
+		if project.Instructions == "" || !owners[project.Dir] {
+			continue
+		}
+		fmt.Fprintf(&sb, "\nFor project %s (files under %s/ that are not in a more specific project):\n%s\n", project.Name, project.Dir, project.Instructions)
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "\n\nPROJECT INSTRUCTIONS:" + sb.String() + "END PROJECT INSTRUCTIONS\n"
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadProjects(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("services/payments/autoengineer.yaml", `
+name: payments
+disabled_scopes: [pipeline]
+ignore_paths: ["fixtures/*"]
+accepted:
+  - title: "Legacy TLS on payment gateway"
+rules:
+  - rule: CKV_AWS_18
+    files: ["fixtures/"]
+    reason: test data
+instructions: "PCI scope: treat card data handling as high severity."
+`)
+	write("services/payments/ledger/autoengineer.yml", `
+enabled_scopes: [pipeline]
+ignore_patterns: ["*sandbox*"]
+instructions_file: NOTES.md
+`)
+	write("services/payments/ledger/NOTES.md", "Ledger writes must be idempotent.")
+	write("services/legacy/autoengineer.yaml", `
+inherit: false
+name: legacy
+`)
+
+	root := &IgnoreConfig{
+		IgnorePaths:    []string{"examples/*"},
+		DisabledScopes: []string{"infra"},
+		Rules:          []IgnoreRule{{Category: "cost", Reason: "reviewed quarterly"}},
+	}
+	files := []string{
+		"README.md",
+		"services/payments/autoengineer.yaml",
+		"services/payments/main.tf",
+		"services/payments/ledger/autoengineer.yml",
+		"services/payments/ledger/NOTES.md",
+		"services/legacy/autoengineer.yaml",
+		"services/web/main.tf",
+	}
+
+	projects, err := LoadProjects(root, files)
+	if err != nil {
+		t.Fatalf("LoadProjects() error: %v", err)
+	}
+
+	var names []string
+	for _, p := range projects.All() {
+		names = append(names, p.Name)
+	}
+	if got := strings.Join(names, ","); got != "legacy,payments,services/payments/ledger" {
+		t.Fatalf("projects = %s", got)
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		if p := projects.Owner([]string{"services/payments/ledger/db.tf"}); p == nil || p.Name != "services/payments/ledger" {
+			t.Errorf("expected the deepest project to own the file, got %+v", p)
+		}
+		if p := projects.Owner([]string{"services/web/main.tf", "services/payments/main.tf"}); p == nil || p.Name != "payments" {
+			t.Errorf("expected the first file inside a project to decide, got %+v", p)
+		}
+		if p := projects.Owner([]string{"/services/payments/ledger/db.tf"}); p == nil || p.Name != "services/payments/ledger" {
+			t.Errorf("expected a scanner path with a leading / to be attributed, got %+v", p)
+		}
+		if p := projects.Owner([]string{"services/web/main.tf"}); p != nil {
+			t.Errorf("expected no project, got %s", p.Name)
+		}
+	})
+
+	t.Run("inheritance", func(t *testing.T) {
+		payments := projects.Ignore("payments")
+		if !payments.IsScopeDisabled("infra") || !payments.IsScopeDisabled("pipeline") {
+			t.Errorf("payments should inherit infra and disable pipeline, got %v", payments.DisabledScopes)
+		}
+		if !payments.MatchesPath("examples/demo.tf") || !payments.MatchesPath("services/payments/fixtures/a.tf") {
+			t.Errorf("payments ignore_paths should be root and project relative, got %v", payments.IgnorePaths)
+		}
+		if payments.MatchesPath("fixtures/a.tf") {
+			t.Error("project ignore_paths should not apply outside the project")
+		}
+		if len(payments.Rules) != 2 || payments.Rules[0].Category != "cost" || !reflect.DeepEqual(payments.Rules[1].Files, []string{"services/payments/**/fixtures/"}) {
+			t.Errorf("payments rules should follow the root's with project relative files, got %+v", payments.Rules)
+		}
+
+		ledger := projects.Ignore("services/payments/ledger")
+		if ledger.IsScopeDisabled("pipeline") || !ledger.IsScopeDisabled("infra") {
+			t.Errorf("ledger should re-enable pipeline only, got %v", ledger.DisabledScopes)
+		}
+		if !ledger.GetAcceptedTitles()["Legacy TLS on payment gateway"] {
+			t.Error("ledger should inherit accepted findings")
+		}
+		if len(ledger.IgnorePatterns) != 1 {
+			t.Errorf("ledger ignore_patterns = %v", ledger.IgnorePatterns)
+		}
+	})
+
+	t.Run("no inherit", func(t *testing.T) {
+		legacy := projects.Ignore("legacy")
+		if legacy.IsScopeDisabled("infra") || legacy.MatchesPath("examples/demo.tf") {
+			t.Errorf("legacy should not inherit the root config, got %+v", legacy)
+		}
+	})
+
+	t.Run("root fallback", func(t *testing.T) {
+		if projects.Ignore("") != root || projects.Ignore("unknown") != root {
+			t.Error("findings outside every project should use the root config")
+		}
+	})
+
+	t.Run("instructions", func(t *testing.T) {
+		text := projects.Instructions([]string{"services/payments/ledger/db.tf"})
+		if !strings.Contains(text, "For project services/payments/ledger (files under services/payments/ledger/ that are not in a more specific project):\nPCI scope: treat card data handling as high severity.\n\nLedger writes must be idempotent.") {
+			t.Errorf("ledger instructions should follow the inherited ones, got:\n%s", text)
+		}
+		if strings.Contains(text, "For project payments ") {
+			t.Error("only the projects owning the files should be included")
+		}
+		if projects.Instructions([]string{"services/web/main.tf"}) != "" {
+			t.Error("files outside every project should get no project instructions")
+		}
+	})
+}
+
+func TestLoadProjectsErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	os.MkdirAll("a", 0755)
+	os.MkdirAll("b", 0755)
+	os.WriteFile("a/autoengineer.yaml", []byte("name: shared\n"), 0644)
+	os.WriteFile("b/autoengineer.yaml", []byte("name: shared\n"), 0644)
+
+	if _, err := LoadProjects(nil, []string{"a/autoengineer.yaml", "b/autoengineer.yaml"}); err == nil {
+		t.Error("expected error for duplicate project names")
+	}
+
+	os.WriteFile("a/autoengineer.yml", []byte("name: other\n"), 0644)
+	if _, err := LoadProjects(nil, []string{"a/autoengineer.yaml", "a/autoengineer.yml"}); err == nil {
+		t.Error("expected error for two configs in one directory")
+	}
+
+	os.WriteFile("b/autoengineer.yaml", []byte("name: [\n"), 0644)
+	if _, err := LoadProjects(nil, []string{"b/autoengineer.yaml"}); err == nil || !strings.Contains(err.Error(), "b/autoengineer.yaml") {
+		t.Errorf("expected parse error naming the file, got %v", err)
+	}
+
+	// The root config and .github are not nested projects
+	projects, err := LoadProjects(nil, []string{"autoengineer.yaml", ".github/autoengineer.yaml"})
+	if err != nil || len(projects.All()) != 0 {
+		t.Errorf("expected no projects, got %v (%v)", projects.All(), err)
+	}
+}
@@ -1,11 +1,5 @@
 package config
 
-import (
-	"os"
-
-	"gopkg.in/yaml.v3"
-)
-
 // ScannerConfig represents the scanner configuration
 type ScannerConfig struct {
 	Enabled  []string          `yaml:"enabled"`
@@ -20,43 +14,37 @@ type AikidoConfig struct {
 
 // FullConfig represents the complete autoengineer.yaml structure
 type FullConfig struct {
-	Scanners *ScannerConfig `yaml:"scanners"`
+	// Extends are base configs this one is merged onto: local paths, or
+	// owner/repo:path[@ref] for a file in another GitHub repository
+	Extends Extends `yaml:"extends,omitempty"`
+
+	Scanners     *ScannerConfig      `yaml:"scanners"`
+	LLM          *LLMConfig          `yaml:"llm"`
+	Verification *VerificationConfig `yaml:"verification"`
+	Scopes       []ScopeConfig       `yaml:"scopes"`
+
+	// Defaults are flag values used when a flag is not given
+	Defaults Settings `yaml:"defaults,omitempty"`
+
+	// Profiles are named sets of settings applied over the defaults with --profile
+	Profiles map[string]Settings `yaml:"profiles,omitempty"`
+
+	// Ignore holds ignore settings shared through extends, added to those
+	// of .github/autoengineer-ignore.yaml
+	Ignore *IgnoreConfig `yaml:"ignore,omitempty"`
+
+	// SeverityOverrides adjust the severity of matching findings
+	SeverityOverrides []SeverityOverride `yaml:"severity_overrides,omitempty"`
 }
 
 // LoadScannerConfig loads the scanner configuration from .github/autoengineer.yaml
 // Returns a default config if the file doesn't exist
 func LoadScannerConfig() (*ScannerConfig, error) {
-	// Check for both .yaml and .yml extensions
-	paths := []string{
-		".github/autoengineer.yaml",
-		".github/autoengineer.yml",
-	}
-
-	var configPath string
-	for _, path := range paths {
-		if _, err := os.Stat(path); err == nil {
-			configPath = path
-			break
-		}
-	}
-
-	// Return default config if no file found
-	if configPath == "" {
-		return &ScannerConfig{}, nil
-	}
-
-	data, err := os.ReadFile(configPath)
+	fullConfig, err := loadFullConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse the full config structure
-	var fullConfig FullConfig
-	
-	if err := yaml.Unmarshal(data, &fullConfig); err != nil {
-		return nil, err
-	}
-
 	// Return scanner config or empty if not present
 	if fullConfig.Scanners != nil {
 		return fullConfig.Scanners, nil
@@ -65,6 +53,26 @@ func LoadScannerConfig() (*ScannerConfig, error) {
 	return &ScannerConfig{}, nil
 }
 
+// loadFullConfig reads and parses .github/autoengineer.yaml, merged onto
+// the base configs it extends
+// Returns an empty config if the file doesn't exist
+func loadFullConfig() (*FullConfig, error) {
+	// Check for both .yaml and .yml extensions
+	configPath := findConfigFile(".github/autoengineer.yaml", ".github/autoengineer.yml")
+
+	// Return empty config if no file found
+	if configPath == "" {
+		return &FullConfig{}, nil
+	}
+
+	layers, err := loadConfigLayers(configPath)
+	if err != nil {
+		return nil, err
+	}
+	fullConfig, _, err := mergeConfigLayers(layers)
+	return fullConfig, err
+}
+
 // IsEnabled checks if a scanner is explicitly enabled
 func (c *ScannerConfig) IsEnabled(name string) bool {
 	for _, enabled := range c.Enabled {
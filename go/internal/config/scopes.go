@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// BuiltinScopes are the analysis scopes that ship with AutoEngineer
+var BuiltinScopes = []string{"security", "pipeline", "infra"}
+
+// scopeNamePattern keeps scope names usable as flags, template names and labels
+var scopeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// ScopeConfig declares a custom analysis scope in autoengineer.yaml
+type ScopeConfig struct {
+	// Name is used with --scope, disabled_scopes and as the prompt template name
+	Name string `yaml:"name"`
+
+	// Title is the display name (defaults to Name)
+	Title string `yaml:"title,omitempty"`
+
+	// Category is assigned to every finding of the scope (defaults to Name)
+	Category string `yaml:"category,omitempty"`
+
+	// Emoji is shown in progress output and summaries
+	Emoji string `yaml:"emoji,omitempty"`
+
+	// Files are glob patterns limiting the files the scope looks at (empty means all files)
+	Files []string `yaml:"files,omitempty"`
+
+	// Prompt describes what the scope should look for
+	Prompt string `yaml:"prompt,omitempty"`
+
+	// PromptFile is a path to a file holding the prompt, as an alternative to Prompt
+	PromptFile string `yaml:"prompt_file,omitempty"`
+}
+
+// LoadScopeConfigs loads the custom scopes from .github/autoengineer.yaml
+func LoadScopeConfigs() ([]ScopeConfig, error) {
+	fullConfig, err := loadFullConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateScopes(fullConfig.Scopes); err != nil {
+		return nil, err
+	}
+
+	return fullConfig.Scopes, nil
+}
+
+// ValidateScopes checks names are valid and unique and every scope has a prompt
+func ValidateScopes(scopes []ScopeConfig) error {
+	seen := make(map[string]bool)
+	for _, name := range BuiltinScopes {
+		seen[name] = true
+	}
+
+	for i, scope := range scopes {
+		if scope.Name == "" {
+			return fmt.Errorf("scopes[%d]: name is required", i)
+		}
+		if !scopeNamePattern.MatchString(scope.Name) || scope.Name == "all" {
+			return fmt.Errorf("scopes[%d]: invalid name %q (use lowercase letters, digits, - and _)", i, scope.Name)
+		}
+		if seen[scope.Name] {
+			return fmt.Errorf("scopes[%d]: duplicate scope name %q", i, scope.Name)
+		}
+		seen[scope.Name] = true
+
+		if (scope.Prompt == "") == (scope.PromptFile == "") {
+			return fmt.Errorf("scope %s: exactly one of prompt or prompt_file is required", scope.Name)
+		}
+		for _, pattern := range scope.Files {
+			if err := ValidateGlob(pattern); err != nil {
+				return fmt.Errorf("scope %s: %w", scope.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EffectiveTitle returns the display name of the scope
+func (s ScopeConfig) EffectiveTitle() string {
+	if s.Title == "" {
+		return s.Name
+	}
+	return s.Title
+}
+
+// EffectiveCategory returns the category assigned to the scope's findings
+func (s ScopeConfig) EffectiveCategory() string {
+	if s.Category == "" {
+		return s.Name
+	}
+	return s.Category
+}
+
+// LoadPrompt returns the scope's prompt text, reading PromptFile if set
+func (s ScopeConfig) LoadPrompt() (string, error) {
+	if s.PromptFile == "" {
+		return s.Prompt, nil
+	}
+
+	data, err := os.ReadFile(s.PromptFile)
+	if err != nil {
+		return "", fmt.Errorf("scope %s: failed to read prompt_file: %w", s.Name, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnvPrefix prefixes the environment variable of each setting, e.g. AUTOENGINEER_MIN_SEVERITY
+const EnvPrefix = "AUTOENGINEER_"
+
+// ProfileEnv selects a profile when --profile is not given
+const ProfileEnv = EnvPrefix + "PROFILE"
+
+// SettingNames are the command-line flags that can be persisted in the
+// defaults and profiles of autoengineer.yaml, by YAML key
+var SettingNames = []string{
+	"scope",
+	"min_severity",
+	"filter",
+	"output",
+	"label",
+	"create_issues",
+	"delegate",
+	"force",
+	"scan_only",
+	"no_scanners",
+	"fast",
+	"instructions",
+	"instructions_text",
+	"use_existing_findings",
+	"shard",
+	"shard_workers",
+	"fail_on",
+	"fail_on_new",
+	"baseline",
+	"new_only",
+	"expiring_within",
+}
+
+// Settings are persisted flag values keyed by setting name
+type Settings map[string]interface{}
+
+// IsSetting reports whether name is a setting
+func IsSetting(name string) bool {
+	for _, s := range SettingNames {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SettingName returns the setting of a flag name, e.g. min-severity → min_severity
+func SettingName(flag string) string {
+	return strings.ReplaceAll(flag, "-", "_")
+}
+
+// EnvName returns the environment variable of a setting, e.g. AUTOENGINEER_MIN_SEVERITY
+func EnvName(setting string) string {
+	return EnvPrefix + strings.ToUpper(setting)
+}
+
+// Value returns a setting formatted as a flag value; lists are comma-separated
+func (s Settings) Value(name string) (string, bool) {
+	v, ok := s[name]
+	if !ok || v == nil {
+		return "", false
+	}
+	if list, ok := v.([]interface{}); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ","), true
+	}
+	return fmt.Sprint(v), true
+}
+
+// LoadSettings loads the defaults and the named profile's settings from
+// .github/autoengineer.yaml. An empty profile has no settings; an unknown
+// profile is an error.
+func LoadSettings(profile string) (defaults, overrides Settings, err error) {
+	fullConfig, err := loadFullConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defaults = fullConfig.Defaults
+	if defaults == nil {
+		defaults = Settings{}
+	}
+	if profile == "" {
+		return defaults, Settings{}, nil
+	}
+
+	overrides, ok := fullConfig.Profiles[profile]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown profile %q (%s)", profile, describeProfiles(fullConfig.Profiles))
+	}
+	if overrides == nil {
+		overrides = Settings{}
+	}
+	return defaults, overrides, nil
+}
+
+// describeProfiles lists the profile names for an error message
+func describeProfiles(profiles map[string]Settings) string {
+	if len(profiles) == 0 {
+		return "no profiles are defined in .github/autoengineer.yaml"
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "defined profiles: " + strings.Join(names, ", ")
+}
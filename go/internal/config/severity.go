@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/severity"
+)
+
+// SeverityOverride sets the severity of the findings matching all of its
+// conditions, e.g. to treat missing tags as high in a regulated account
+type SeverityOverride struct {
+	// Title is a regular expression matched case-insensitively against the title
+	Title    string `yaml:"title,omitempty"`
+	Category string `yaml:"category,omitempty"`
+	// Rule is the scanner check ID, e.g. CKV_AWS_20 or AVD-AWS-0086
+	Rule string `yaml:"rule,omitempty"`
+	// Files are gitignore-style globs; a finding matches when any of its files does
+	Files []string `yaml:"files,omitempty"`
+
+	Severity string `yaml:"severity"`
+	Reason   string `yaml:"reason,omitempty"`
+}
+
+// LoadSeverityOverrides loads the severity overrides from .github/autoengineer.yaml
+func LoadSeverityOverrides() ([]SeverityOverride, error) {
+	fullConfig, err := loadFullConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateSeverityOverrides(fullConfig.SeverityOverrides); err != nil {
+		return nil, err
+	}
+	return fullConfig.SeverityOverrides, nil
+}
+
+// ValidateSeverityOverrides checks each override
+func ValidateSeverityOverrides(overrides []SeverityOverride) error {
+	for i, o := range overrides {
+		if err := o.Validate(); err != nil {
+			return fmt.Errorf("severity_overrides[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// TitleRegexp compiles the title condition, nil when there is none
+func (o SeverityOverride) TitleRegexp() (*regexp.Regexp, error) {
+	return compileTitle(o.Title)
+}
+
+// Validate checks that the override has a condition and a valid severity
+func (o SeverityOverride) Validate() error {
+	if err := o.checkConditions(); err != nil {
+		return err
+	}
+	if _, err := o.TitleRegexp(); err != nil {
+		return err
+	}
+	if severity.Valid(o.Severity) {
+		return nil
+	}
+	return fmt.Errorf("severity must be one of %s", strings.Join(severity.Levels, ", "))
+}
+
+// checkConditions checks that the override has a condition
+func (o SeverityOverride) checkConditions() error {
+	if o.Title == "" && o.Category == "" && o.Rule == "" && len(o.Files) == 0 {
+		return fmt.Errorf("needs at least one of title, category, rule or files")
+	}
+	return nil
+}
@@ -0,0 +1,551 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Published JSON Schemas of the configuration files
+var (
+	//go:embed autoengineer.schema.json
+	configSchemaJSON []byte
+
+	//go:embed autoengineer-ignore.schema.json
+	ignoreSchemaJSON []byte
+
+	//go:embed autoengineer-project.schema.json
+	projectSchemaJSON []byte
+)
+
+var (
+	configSchema  = schema.MustParse(configSchemaJSON)
+	ignoreSchema  = schema.MustParse(ignoreSchemaJSON)
+	projectSchema = schema.MustParse(projectSchemaJSON)
+)
+
+// Configuration file kinds
+const (
+	KindConfig  = "config"
+	KindIgnore  = "ignore"
+	KindProject = "project"
+)
+
+// SchemaKinds lists the configuration file kinds with a schema
+var SchemaKinds = []string{KindConfig, KindIgnore, KindProject}
+
+// Schema returns the published JSON Schema of a configuration file kind, or nil
+func Schema(kind string) []byte {
+	switch kind {
+	case KindConfig:
+		return configSchemaJSON
+	case KindIgnore:
+		return ignoreSchemaJSON
+	case KindProject:
+		return projectSchemaJSON
+	}
+	return nil
+}
+
+// Problem is an error in a configuration file. Line and Column are 1-based,
+// or 0 when the problem has no position.
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// String formats the problem as file:line:column: message
+func (p Problem) String() string {
+	switch {
+	case p.Line > 0 && p.Column > 0:
+		return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+	case p.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+	default:
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+}
+
+// InvalidError reports every problem found by Validate
+type InvalidError struct {
+	Problems []Problem
+}
+
+// Error implements the error interface
+func (e *InvalidError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = p.String()
+	}
+	return fmt.Sprintf("invalid configuration (%d problem(s)):\n  %s", len(e.Problems), strings.Join(lines, "\n  "))
+}
+
+var (
+	yamlSyntaxLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	yamlTypeLine   = regexp.MustCompile(`^line (\d+): (.*)$`)
+)
+
+// Validate checks .github/autoengineer.yaml and the base configs it extends,
+// .github/autoengineer-ignore.yaml and the nested project configs among files (slash-separated, relative to
+// the working directory). Each file is decoded strictly and checked against
+// its JSON Schema, then scope names, scanner names and globs are checked.
+// Returns an *InvalidError listing every problem, or nil.
+func Validate(files []string) error {
+	var problems []Problem
+
+	// Custom scopes are checked first, since disabled_scopes may name them
+	scopes := append([]string(nil), BuiltinScopes...)
+	if configPath := findConfigFile(".github/autoengineer.yaml", ".github/autoengineer.yml"); configPath != "" {
+		var cfg FullConfig
+		before := len(problems)
+		if doc, found := validateFile(configPath, configSchema, &cfg, &problems); found {
+			docs, cfgs := []*document{doc}, []*FullConfig{&cfg}
+			var layers []configLayer
+			if len(cfg.Extends) > 0 {
+				var err error
+				if layers, err = loadConfigLayers(configPath); err != nil {
+					problems = append(problems, doc.problem("/extends", strings.TrimPrefix(err.Error(), configPath+": ")))
+				}
+				// The base configs are checked like the repository's own
+				for _, layer := range layers {
+					if layer.Source() == doc.file {
+						continue
+					}
+					var baseCfg FullConfig
+					if baseDoc, found := validateData(layer.Source(), layer.Data, configSchema, &baseCfg, &problems); found {
+						docs, cfgs = append(docs, baseDoc), append(cfgs, &baseCfg)
+					}
+				}
+			}
+
+			for _, c := range cfgs {
+				for _, s := range c.Scopes {
+					if s.Name != "" {
+						scopes = append(scopes, s.Name)
+					}
+				}
+			}
+			for i := range docs {
+				checkConfig(docs[i], cfgs[i], scopes, &problems)
+			}
+
+			// Section validators see the merged config, since a setting may
+			// depend on one from a base
+			if len(problems) == before {
+				effective := &cfg
+				if len(layers) > 0 {
+					merged, _, err := mergeConfigLayers(layers)
+					if err != nil {
+						problems = append(problems, doc.problem("/extends", err.Error()))
+					}
+					effective = merged
+				}
+				if effective != nil {
+					checkSections(doc, effective, &problems)
+				}
+			}
+		}
+	}
+
+	if ignorePath := IgnoreFilePath(); ignorePath != "" {
+		var cfg IgnoreConfig
+		if doc, found := validateFile(ignorePath, ignoreSchema, &cfg, &problems); found {
+			checkGlobs(doc, "/ignore_paths", cfg.IgnorePaths, &problems)
+			checkScopes(doc, "/disabled_scopes", cfg.DisabledScopes, scopes, &problems)
+			checkRules(doc, "/rules", cfg.Rules, &problems)
+			checkAccepted(doc, "/accepted", cfg.Accepted, &problems)
+		}
+	}
+
+	for _, file := range files {
+		dir := path.Dir(file)
+		if dir == "." || dir == ".github" || !isProjectConfigName(path.Base(file)) {
+			continue
+		}
+		var pc ProjectConfig
+		if doc, found := validateFile(file, projectSchema, &pc, &problems); found {
+			checkGlobs(doc, "/ignore_paths", pc.IgnorePaths, &problems)
+			checkScopes(doc, "/disabled_scopes", pc.DisabledScopes, scopes, &problems)
+			checkRules(doc, "/rules", pc.Rules, &problems)
+			checkAccepted(doc, "/accepted", pc.Accepted, &problems)
+			checkScopes(doc, "/enabled_scopes", pc.EnabledScopes, scopes, &problems)
+			if pc.InstructionsFile != "" {
+				if _, err := os.Stat(filepath.Join(filepath.FromSlash(dir), pc.InstructionsFile)); err != nil {
+					problems = append(problems, doc.problem("/instructions_file", fmt.Sprintf("instructions_file %q not found", pc.InstructionsFile)))
+				}
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+	return &InvalidError{Problems: problems}
+}
+
+// findConfigFile returns the first existing path, or ""
+func findConfigFile(paths ...string) string {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// document is a parsed YAML file with the position of each value by JSON pointer
+type document struct {
+	file      string
+	positions map[string]*yaml.Node
+}
+
+// problem returns a problem positioned at pointer, or at its nearest ancestor
+func (d *document) problem(pointer, message string) Problem {
+	for {
+		if n, ok := d.positions[pointer]; ok {
+			return Problem{File: d.file, Line: n.Line, Column: n.Column, Message: message}
+		}
+		if pointer == "" {
+			return Problem{File: d.file, Message: message}
+		}
+		pointer = pointer[:strings.LastIndex(pointer, "/")]
+	}
+}
+
+// validateFile parses a file, checks it against its schema and decodes it
+// strictly into out. found is false when the file couldn't be read or parsed.
+func validateFile(file string, s *schema.Schema, out interface{}, problems *[]Problem) (*document, bool) {
+	data, err := os.ReadFile(filepath.FromSlash(file))
+	if err != nil {
+		*problems = append(*problems, Problem{File: file, Message: err.Error()})
+		return nil, false
+	}
+	return validateData(file, data, s, out, problems)
+}
+
+// validateData is validateFile for content already read, e.g. a base config
+// fetched from another repository
+func validateData(file string, data []byte, s *schema.Schema, out interface{}, problems *[]Problem) (*document, bool) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		*problems = append(*problems, yamlProblem(file, err.Error(), yamlSyntaxLine))
+		return nil, false
+	}
+
+	doc := &document{file: file, positions: make(map[string]*yaml.Node)}
+	var value interface{}
+	if len(root.Content) > 0 {
+		value = doc.convert(root.Content[0], "")
+	}
+
+	// Schema violations, with suggestions for misspelled keys
+	reported := make(map[int]bool)
+	for _, verr := range s.Validate(value) {
+		message := verr.Message
+		if message == "unknown property" {
+			message = unknownKeyMessage(s, verr.Path)
+		} else if verr.Path != "" {
+			message = fmt.Sprintf("%s %s", pointerName(verr.Path), message)
+		}
+		p := doc.problem(verr.Path, message)
+		reported[p.Line] = true
+		*problems = append(*problems, p)
+	}
+
+	// Strict decoding catches anything the schema allows but the types don't
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(out); err != nil && err != io.EOF {
+		if typeErr, ok := err.(*yaml.TypeError); ok {
+			for _, msg := range typeErr.Errors {
+				p := yamlProblem(file, msg, yamlTypeLine)
+				if !reported[p.Line] {
+					*problems = append(*problems, p)
+				}
+			}
+		} else {
+			*problems = append(*problems, yamlProblem(file, err.Error(), yamlSyntaxLine))
+		}
+	}
+
+	return doc, true
+}
+
+// yamlProblem turns a yaml error message with a "line N:" prefix into a problem
+func yamlProblem(file, msg string, pattern *regexp.Regexp) Problem {
+	if m := pattern.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return Problem{File: file, Line: line, Message: m[2]}
+	}
+	return Problem{File: file, Message: strings.TrimPrefix(msg, "yaml: ")}
+}
+
+// convert turns a YAML node into the JSON-like value the schema validates,
+// recording the position of each value. Mapping values are positioned at
+// their key.
+func (d *document) convert(n *yaml.Node, pointer string) interface{} {
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	if _, ok := d.positions[pointer]; !ok {
+		d.positions[pointer] = n
+	}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) > 0 {
+			return d.convert(n.Content[0], pointer)
+		}
+		return nil
+	case yaml.MappingNode:
+		m := make(map[string]interface{})
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			childPointer := pointer + "/" + strings.ReplaceAll(strings.ReplaceAll(key.Value, "~", "~0"), "/", "~1")
+			d.positions[childPointer] = key
+			m[key.Value] = d.convert(value, childPointer)
+		}
+		return m
+	case yaml.SequenceNode:
+		items := make([]interface{}, len(n.Content))
+		for i, item := range n.Content {
+			items[i] = d.convert(item, pointer+"/"+strconv.Itoa(i))
+		}
+		return items
+	default:
+		switch n.Tag {
+		case "!!null":
+			return nil
+		case "!!bool":
+			var b bool
+			if n.Decode(&b) == nil {
+				return b
+			}
+		case "!!int", "!!float":
+			var f float64
+			if n.Decode(&f) == nil {
+				return f
+			}
+		}
+		return n.Value
+	}
+}
+
+// pointerName renders a JSON pointer as a dotted key, e.g. scanners.disabled[1]
+func pointerName(pointer string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		if _, err := strconv.Atoi(part); err == nil {
+			fmt.Fprintf(&sb, "[%s]", part)
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(".")
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+// unknownKeyMessage describes an unknown key, suggesting the closest known one
+func unknownKeyMessage(s *schema.Schema, pointer string) string {
+	i := strings.LastIndex(pointer, "/")
+	key := strings.ReplaceAll(strings.ReplaceAll(pointer[i+1:], "~1", "/"), "~0", "~")
+	message := fmt.Sprintf("unknown key %q", key)
+	if parent := pointer[:i]; parent != "" {
+		message = fmt.Sprintf("unknown key %q in %s", key, pointerName(parent))
+	}
+
+	if parent := schemaAt(s, pointer[:i]); parent != nil {
+		if suggestion := closest(key, parent.PropertyNames()); suggestion != "" {
+			message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+		}
+	}
+	return message
+}
+
+// schemaAt returns the sub-schema describing the value at a JSON pointer
+func schemaAt(s *schema.Schema, pointer string) *schema.Schema {
+	if pointer == "" {
+		return s
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if s == nil {
+			return nil
+		}
+		if _, err := strconv.Atoi(part); err == nil && s.Items != nil {
+			s = s.Items
+			continue
+		}
+		s = s.Properties[strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")]
+	}
+	return s
+}
+
+// checkConfig checks the main config's scope globs, the scopes its settings
+// name, its shared ignore settings and its severity overrides
+func checkConfig(doc *document, cfg *FullConfig, scopes []string, problems *[]Problem) {
+	for i, s := range cfg.Scopes {
+		checkGlobs(doc, fmt.Sprintf("/scopes/%d/files", i), s.Files, problems)
+	}
+	if cfg.Ignore != nil {
+		checkGlobs(doc, "/ignore/ignore_paths", cfg.Ignore.IgnorePaths, problems)
+		checkScopes(doc, "/ignore/disabled_scopes", cfg.Ignore.DisabledScopes, scopes, problems)
+		checkRules(doc, "/ignore/rules", cfg.Ignore.Rules, problems)
+		checkAccepted(doc, "/ignore/accepted", cfg.Ignore.Accepted, problems)
+	}
+	for i, o := range cfg.SeverityOverrides {
+		at := fmt.Sprintf("/severity_overrides/%d", i)
+		if err := o.checkConditions(); err != nil {
+			*problems = append(*problems, doc.problem(at, err.Error()))
+		}
+		if _, err := o.TitleRegexp(); err != nil {
+			*problems = append(*problems, doc.problem(at+"/title", err.Error()))
+		}
+		checkGlobs(doc, at+"/files", o.Files, problems)
+	}
+
+	// The scope setting also accepts "all"
+	settingScopes := append(append([]string(nil), scopes...), "all")
+	if scope, ok := cfg.Defaults["scope"].(string); ok {
+		checkScope(doc, "/defaults/scope", scope, settingScopes, problems)
+	}
+	for name, settings := range cfg.Profiles {
+		if scope, ok := settings["scope"].(string); ok {
+			checkScope(doc, "/profiles/"+name+"/scope", scope, settingScopes, problems)
+		}
+	}
+}
+
+// checkSections runs the section validators of a config that is otherwise
+// valid (they would repeat schema errors)
+func checkSections(doc *document, cfg *FullConfig, problems *[]Problem) {
+	if err := ValidateScopes(cfg.Scopes); err != nil {
+		*problems = append(*problems, doc.problem("/scopes", err.Error()))
+	}
+	if cfg.LLM != nil {
+		if err := cfg.LLM.Validate(); err != nil {
+			*problems = append(*problems, doc.problem("/llm", err.Error()))
+		}
+	}
+	if cfg.Verification != nil {
+		if err := cfg.Verification.Validate(); err != nil {
+			*problems = append(*problems, doc.problem("/verification", err.Error()))
+		}
+	}
+}
+
+// checkGlobs reports malformed globs in a list
+func checkGlobs(doc *document, pointer string, globs []string, problems *[]Problem) {
+	for i, g := range globs {
+		if err := ValidateGlob(g); err != nil {
+			*problems = append(*problems, doc.problem(fmt.Sprintf("%s/%d", pointer, i), err.Error()))
+		}
+	}
+}
+
+// checkRules reports ignore rules without conditions, stripping without
+// files, or with a malformed title regexp or file glob. A missing reason is
+// left to the schema.
+func checkRules(doc *document, pointer string, rules []IgnoreRule, problems *[]Problem) {
+	for i, r := range rules {
+		at := fmt.Sprintf("%s/%d", pointer, i)
+		if err := r.checkConditions(); err != nil {
+			*problems = append(*problems, doc.problem(at, err.Error()))
+		}
+		if _, err := r.TitleRegexp(); err != nil {
+			*problems = append(*problems, doc.problem(at+"/title", err.Error()))
+		}
+		checkGlobs(doc, at+"/files", r.Files, problems)
+	}
+}
+
+// checkAccepted reports accepted risks with malformed dates
+func checkAccepted(doc *document, pointer string, items []AcceptedItem, problems *[]Problem) {
+	for i, item := range items {
+		if err := item.Validate(); err != nil {
+			*problems = append(*problems, doc.problem(fmt.Sprintf("%s/%d", pointer, i), err.Error()))
+		}
+	}
+}
+
+// checkScopes reports unknown scope names in a list
+func checkScopes(doc *document, pointer string, names, scopes []string, problems *[]Problem) {
+	for i, name := range names {
+		checkScope(doc, fmt.Sprintf("%s/%d", pointer, i), name, scopes, problems)
+	}
+}
+
+// checkScope reports an unknown scope name
+func checkScope(doc *document, pointer, name string, scopes []string, problems *[]Problem) {
+	for _, s := range scopes {
+		if s == name {
+			return
+		}
+	}
+	message := fmt.Sprintf("unknown scope %q (must be one of %s)", name, strings.Join(scopes, ", "))
+	if suggestion := closest(name, scopes); suggestion != "" {
+		message = fmt.Sprintf("unknown scope %q (did you mean %q?)", name, suggestion)
+	}
+	*problems = append(*problems, doc.problem(pointer, message))
+}
+
+// closest returns the candidate nearest to s by edit distance, if it is
+// close enough to be a likely typo
+func closest(s string, candidates []string) string {
+	best, bestDistance := "", 0
+	for _, c := range candidates {
+		d := editDistance(strings.ToLower(s), strings.ToLower(c))
+		if best == "" || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	if best == "" || bestDistance > 2 && bestDistance > len(s)/3 {
+		return ""
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files under dir, keyed by slash-separated path
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// validateIn runs Validate in a temporary repository holding files
+func validateIn(t *testing.T, files map[string]string) []string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, files)
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	var paths []string
+	for name := range files {
+		paths = append(paths, name)
+	}
+
+	err := Validate(paths)
+	if err == nil {
+		return nil
+	}
+	var invalid *InvalidError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected *InvalidError, got %v", err)
+	}
+	problems := make([]string, len(invalid.Problems))
+	for i, p := range invalid.Problems {
+		problems[i] = p.String()
+	}
+	return problems
+}
+
+func TestValidateValidConfig(t *testing.T) {
+	problems := validateIn(t, map[string]string{
+		".github/autoengineer.yaml": `scanners:
+  disabled:
+    - trivy
+llm:
+  backend: openai
+  model: llama3.1
+  base_url: http://localhost:11434/v1
+verification:
+  mode: flag
+scopes:
+  - name: cost
+    files: ["**/*.tf"]
+    prompt: Oversized instances
+`,
+		".github/autoengineer-ignore.yaml": `accepted:
+  - title: "Public subnet"
+    accepted_date: 2025-01-15
+ignore_paths:
+  - "examples/*"
+  - "**/testdata/**"
+disabled_scopes:
+  - pipeline
+  - cost
+`,
+		"services/api/autoengineer.yaml": `name: api
+inherit: false
+enabled_scopes: [pipeline]
+`,
+	})
+	if len(problems) != 0 {
+		t.Errorf("expected no problems, got:\n%s", strings.Join(problems, "\n"))
+	}
+}
+
+func TestValidateReportsProblemsWithPositions(t *testing.T) {
+	problems := validateIn(t, map[string]string{
+		".github/autoengineer.yaml": `scanners:
+  disabled:
+    - checkov
+    - trivvy
+verification:
+  mode: strict
+scopes:
+  - name: cost
+    files: ["[*.tf"]
+    prompt: x
+`,
+		".github/autoengineer-ignore.yaml": `ignore_path:
+  - "examples/*"
+disabled_scopes:
+  - pipline
+  - cots
+`,
+		"services/api/autoengineer.yaml": `name: api
+inherit: sometimes
+instructions_file: missing.md
+`,
+	})
+
+	want := []string{
+		`.github/autoengineer-ignore.yaml:1:1: unknown key "ignore_path" (did you mean "ignore_paths"?)`,
+		`.github/autoengineer-ignore.yaml:4:5: unknown scope "pipline" (did you mean "pipeline"?)`,
+		`.github/autoengineer-ignore.yaml:5:5: unknown scope "cots" (did you mean "cost"?)`,
+		`.github/autoengineer.yaml:4:7: scanners.disabled[1] must be one of checkov, trivy, aikido`,
+		`.github/autoengineer.yaml:6:3: verification.mode must be one of drop, flag, off`,
+		`.github/autoengineer.yaml:9:13: invalid glob "[*.tf": syntax error in pattern`,
+		`services/api/autoengineer.yaml:2:1: inherit expected boolean, got string`,
+		`services/api/autoengineer.yaml:3:1: instructions_file "missing.md" not found`,
+	}
+	if got := strings.Join(problems, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("unexpected problems:\n%s\n\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateSyntaxError(t *testing.T) {
+	problems := validateIn(t, map[string]string{
+		".github/autoengineer.yaml": "scanners:\n  disabled: [checkov\n",
+	})
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], ".github/autoengineer.yaml:") {
+		t.Errorf("expected one positioned syntax error, got %v", problems)
+	}
+}
+
+func TestValidateRunsSectionValidators(t *testing.T) {
+	problems := validateIn(t, map[string]string{
+		".github/autoengineer.yaml": "llm:\n  backend: openai\n",
+	})
+	want := ".github/autoengineer.yaml:1:1: llm.model is required for the openai backend"
+	if len(problems) != 1 || problems[0] != want {
+		t.Errorf("got %v, want %s", problems, want)
+	}
+}
+
+func TestValidateIgnoreRules(t *testing.T) {
+	problems := validateIn(t, map[string]string{
+		".github/autoengineer-ignore.yaml": `rules:
+  - rule: CKV_AWS_18
+    reason: access logs go to the central account
+  - title: "(unclosed"
+    reason: typo
+  - files: ["[*.tf"]
+    reason: bad glob
+  - reason: no conditions
+  - severity: low
+    strip: true
+    reason: nothing to strip
+  - category: security
+`,
+	})
+
+	want := []string{
+		".github/autoengineer-ignore.yaml:4:5: invalid title regexp: error parsing regexp: missing closing ): `(unclosed`",
+		`.github/autoengineer-ignore.yaml:6:13: invalid glob "[*.tf": syntax error in pattern`,
+		`.github/autoengineer-ignore.yaml:8:5: needs at least one of title, category, severity, rule, files or fingerprint`,
+		`.github/autoengineer-ignore.yaml:9:5: strip needs files to strip`,
+		`.github/autoengineer-ignore.yaml:12:5: rules[5].reason is required`,
+	}
+	if got := strings.Join(problems, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("unexpected problems:\n%s\n\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateSeverityOverrides(t *testing.T) {
+	problems := validateIn(t, map[string]string{
+		".github/autoengineer.yaml": `severity_overrides:
+  - title: "missing .*tags"
+    files: ["accounts/regulated/"]
+    severity: high
+  - reason: no conditions
+    severity: high
+  - rule: CKV_AWS_18
+    severity: urgent
+  - title: "(unclosed"
+    severity: low
+`,
+	})
+
+	want := []string{
+		`.github/autoengineer.yaml:5:5: needs at least one of title, category, rule or files`,
+		`.github/autoengineer.yaml:8:5: severity_overrides[2].severity must be one of critical, high, medium, low, info`,
+		".github/autoengineer.yaml:9:5: invalid title regexp: error parsing regexp: missing closing ): `(unclosed`",
+	}
+	if got := strings.Join(problems, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("unexpected problems:\n%s\n\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateNoConfig(t *testing.T) {
+	if problems := validateIn(t, map[string]string{"main.tf": ""}); len(problems) != 0 {
+		t.Errorf("a repository without config should be valid, got %v", problems)
+	}
+}
+
+func TestClosest(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"ignore_path", "ignore_paths"},
+		{"disabled_scope", "disabled_scopes"},
+		{"accept", "accepted"},
+		{"completely_unrelated", ""},
+	}
+	candidates := []string{"accepted", "ignore_paths", "ignore_patterns", "disabled_scopes"}
+	for _, tt := range tests {
+		if got := closest(tt.s, candidates); got != tt.want {
+			t.Errorf("closest(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import "fmt"
+
+// Verification modes for findings that cannot be grounded in the working tree
+const (
+	VerificationModeDrop = "drop"
+	VerificationModeFlag = "flag"
+	VerificationModeOff  = "off"
+)
+
+// VerificationConfig controls the hallucination guard applied to LLM findings
+type VerificationConfig struct {
+	// Mode is "drop" (default) to remove ungrounded findings, "flag" to keep them
+	// tagged as unverified, or "off" to skip verification
+	Mode string `yaml:"mode,omitempty"`
+
+	// MinSimilarity is the fuzzy match score (0-1) a quoted snippet needs
+	MinSimilarity float64 `yaml:"min_similarity,omitempty"`
+}
+
+// LoadVerificationConfig loads the verification section from .github/autoengineer.yaml
+func LoadVerificationConfig() (*VerificationConfig, error) {
+	fullConfig, err := loadFullConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &VerificationConfig{}
+	if fullConfig.Verification != nil {
+		cfg = fullConfig.Verification
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// EffectiveMode returns the configured mode, defaulting to drop
+func (c *VerificationConfig) EffectiveMode() string {
+	if c == nil || c.Mode == "" {
+		return VerificationModeDrop
+	}
+	return c.Mode
+}
+
+// EffectiveMinSimilarity returns the configured threshold, or def if unset
+func (c *VerificationConfig) EffectiveMinSimilarity(def float64) float64 {
+	if c == nil || c.MinSimilarity <= 0 {
+		return def
+	}
+	return c.MinSimilarity
+}
+
+// Validate checks the mode and threshold
+func (c *VerificationConfig) Validate() error {
+	switch c.EffectiveMode() {
+	case VerificationModeDrop, VerificationModeFlag, VerificationModeOff:
+	default:
+		return fmt.Errorf("unknown verification.mode %q (must be %s, %s or %s)", c.Mode, VerificationModeDrop, VerificationModeFlag, VerificationModeOff)
+	}
+
+	if c.MinSimilarity < 0 || c.MinSimilarity > 1 {
+		return fmt.Errorf("verification.min_similarity must be between 0 and 1")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package copilot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+)
+
+// LLMBackend sends a prompt to a language model and returns its raw text response.
+// Parsing, validation and deduplication are handled by Client on top of a backend.
+type LLMBackend interface {
+	// Name returns a short identifier for the backend (e.g. "copilot", "openai")
+	Name() string
+
+	// Complete sends the prompt and returns the model's response text
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// CLIBackend runs prompts through the copilot CLI in non-interactive mode
+type CLIBackend struct {
+	// Path to copilot binary (defaults to "copilot" in PATH)
+	BinaryPath string
+}
+
+// NewCLIBackend creates a backend that shells out to the copilot CLI
+func NewCLIBackend(binaryPath string) *CLIBackend {
+	if binaryPath == "" {
+		binaryPath = "copilot"
+	}
+	return &CLIBackend{BinaryPath: binaryPath}
+}
+
+// Name returns the backend name
+func (b *CLIBackend) Name() string {
+	return config.LLMBackendCopilot
+}
+
+// Complete runs "copilot -p <prompt>" and returns stdout
+func (b *CLIBackend) Complete(ctx context.Context, prompt string) (string, error) {
+	cmd := exec.CommandContext(ctx, b.BinaryPath, "-p", prompt)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("copilot command failed: %w (stderr: %q)", err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// NewBackend creates the backend selected by the llm section of autoengineer.yaml
+func NewBackend(cfg *config.LLMConfig) (LLMBackend, error) {
+	if cfg == nil {
+		return NewCLIBackend(""), nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.BackendName() {
+	case config.LLMBackendOpenAI:
+		return NewOpenAIBackend(cfg.EffectiveBaseURL(), cfg.Model, cfg.APIKey(), cfg.Timeout()), nil
+	default:
+		return NewCLIBackend(""), nil
+	}
+}
@@ -0,0 +1,157 @@
+package copilot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/issues"
+)
+
+// fakeBackend returns canned responses and records the prompts it receives
+type fakeBackend struct {
+	responses []string
+	err       error
+	prompts   []string
+}
+
+func (b *fakeBackend) Name() string {
+	return "fake"
+}
+
+func (b *fakeBackend) Complete(ctx context.Context, prompt string) (string, error) {
+	b.prompts = append(b.prompts, prompt)
+	if b.err != nil {
+		return "", b.err
+	}
+	if len(b.responses) == 0 {
+		return "", nil
+	}
+	response := b.responses[0]
+	if len(b.responses) > 1 {
+		b.responses = b.responses[1:]
+	}
+	return response, nil
+}
+
+func TestRunAnalysisUsesBackend(t *testing.T) {
+	backend := &fakeBackend{
+		responses: []string{"```json\n[{\"category\": \"security\", \"title\": \"Open security group\", \"severity\": \"high\", \"files\": [\"main.tf\"]}]\n```"},
+	}
+	client := NewClientWithBackend(backend)
+
+	results, err := client.RunAnalysis(context.Background(), "analyze")
+	if err != nil {
+		t.Fatalf("RunAnalysis failed: %v", err)
+	}
+
+	if len(backend.prompts) != 1 || backend.prompts[0] != "analyze" {
+		t.Errorf("expected backend to receive the prompt once, got %v", backend.prompts)
+	}
+	if len(results) != 1 || results[0].Title != "Open security group" {
+		t.Errorf("unexpected findings: %+v", results)
+	}
+}
+
+func TestRunAnalysisBackendError(t *testing.T) {
+	client := NewClientWithBackend(&fakeBackend{err: errors.New("connection refused")})
+
+	_, err := client.RunAnalysis(context.Background(), "analyze")
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected backend error to be returned, got %v", err)
+	}
+}
+
+func TestRunDeduplicationBackendError(t *testing.T) {
+	client := NewClientWithBackend(&fakeBackend{err: errors.New("timeout")})
+	original := []findings.Finding{{Title: "A", Category: findings.CategoryInfra, Severity: findings.SeverityLow}}
+
+	result, err := client.RunDeduplication(context.Background(), original, []issues.SearchResult{})
+	if err == nil {
+		t.Fatal("expected error from failing backend")
+	}
+	if !strings.Contains(err.Error(), "fake deduplication failed") {
+		t.Errorf("expected error to name the backend, got %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("expected original findings to be returned on error, got %d", len(result))
+	}
+}
+
+func TestRunDeduplicationRestoresInputFields(t *testing.T) {
+	original := []findings.Finding{
+		{
+			Title: "S3 bucket has no access logging", Category: findings.CategorySecurity, Severity: findings.SeverityMedium,
+			Files: []string{"modules/s3/main.tf"}, Rule: "CKV_AWS_18", Source: "checkov", Tags: []string{findings.TagUnverified},
+			CodeSnippets: []findings.CodeSnippet{{File: "modules/s3/main.tf", StartLine: 4, EndLine: 12, Code: "resource \"aws_s3_bucket\" \"logs\" {"}},
+		},
+		{Title: "Bucket access is not logged", Category: findings.CategoryInfra, Severity: findings.SeverityHigh, Files: []string{"modules/s3/main.tf"}},
+		{Title: "Unpinned action", Category: findings.CategoryPipeline, Severity: findings.SeverityLow, Files: []string{".github/workflows/ci.yml"}},
+	}
+	// The model merges the first two, trims the snippet and leaves out rule, source and tags
+	backend := &fakeBackend{responses: []string{`[{"id": "2", "merged_ids": ["2", "1"], "category": "infra", "title": "S3 access logging disabled", "severity": "high", ` +
+		`"files": ["modules/s3/main.tf"], "code_snippets": [{"file": "modules/s3/main.tf", "start_line": 4, "end_line": 5, "code": "resource"}]}, ` +
+		`{"id": "3", "category": "pipeline", "title": "Unpinned action", "severity": "low", "files": [".github/workflows/ci.yml"]}]`}}
+	client := NewClientWithBackend(backend)
+
+	result, err := client.RunDeduplication(context.Background(), original, []issues.SearchResult{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(backend.prompts[0], `"id": "1"`) || !strings.Contains(backend.prompts[0], `"rule": "CKV_AWS_18"`) {
+		t.Errorf("expected the prompt to give each finding an id, got:\n%s", backend.prompts[0])
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", result)
+	}
+
+	merged := result[0]
+	if merged.Title != "S3 access logging disabled" || merged.Rule != "CKV_AWS_18" || merged.Source != "checkov" {
+		t.Errorf("expected the rule and source of the merged scanner finding, got %+v", merged)
+	}
+	if !merged.HasTag(findings.TagUnverified) {
+		t.Errorf("expected the unverified tag to survive deduplication, got %v", merged.Tags)
+	}
+	if len(merged.CodeSnippets) != 1 || merged.CodeSnippets[0] != original[0].CodeSnippets[0] {
+		t.Errorf("expected the original code snippet, got %+v", merged.CodeSnippets)
+	}
+	if result[1].Rule != "" || result[1].Source != "" || len(result[1].Tags) != 0 {
+		t.Errorf("expected the LLM finding unchanged, got %+v", result[1])
+	}
+}
+
+func TestNewBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *config.LLMConfig
+		wantName string
+		wantErr  bool
+	}{
+		{name: "nil config", cfg: nil, wantName: config.LLMBackendCopilot},
+		{name: "default backend", cfg: &config.LLMConfig{}, wantName: config.LLMBackendCopilot},
+		{name: "openai backend", cfg: &config.LLMConfig{Backend: "openai", Model: "llama3"}, wantName: config.LLMBackendOpenAI},
+		{name: "openai without model", cfg: &config.LLMConfig{Backend: "openai"}, wantErr: true},
+		{name: "unknown backend", cfg: &config.LLMConfig{Backend: "bard"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := NewBackend(tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if backend.Name() != tt.wantName {
+				t.Errorf("Name() = %q, want %q", backend.Name(), tt.wantName)
+			}
+		})
+	}
+}
@@ -9,23 +9,84 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/liam-witterick/autoengineer/go/internal/config"
 	"github.com/liam-witterick/autoengineer/go/internal/findings"
 	"github.com/liam-witterick/autoengineer/go/internal/issues"
+	"github.com/liam-witterick/autoengineer/go/internal/prompts"
 )
 
-// Client wraps interactions with the GitHub Copilot CLI
+// Client wraps interactions with the GitHub Copilot CLI and the configured LLM backend
 type Client struct {
 	// Path to copilot binary (defaults to "copilot" in PATH)
+	// Used for interactive fixes and delegation, which are copilot-specific
 	BinaryPath string
+
+	// Backend answers analysis and deduplication prompts
+	Backend LLMBackend
+
+	// RepairAttempts is how many times an invalid analysis response is sent back
+	// to the model with its validation errors before the analysis fails
+	RepairAttempts int
+
+	// Prompts renders the deduplication prompt (defaults to the embedded templates)
+	Prompts *prompts.Set
+
+	// RepoRoot is used to relativize absolute paths in responses (defaults to the working directory)
+	RepoRoot string
 }
 
-// NewClient creates a new Copilot client
+// DefaultRepairAttempts is the number of repair re-prompts used when none is configured
+const DefaultRepairAttempts = 2
+
+// NewClient creates a new Copilot client backed by the copilot CLI
 func NewClient() *Client {
 	return &Client{
-		BinaryPath: "copilot",
+		BinaryPath:     "copilot",
+		Backend:        NewCLIBackend("copilot"),
+		RepairAttempts: DefaultRepairAttempts,
+	}
+}
+
+// NewClientWithBackend creates a client that sends analysis prompts to the given backend
+func NewClientWithBackend(backend LLMBackend) *Client {
+	return &Client{
+		BinaryPath:     "copilot",
+		Backend:        backend,
+		RepairAttempts: DefaultRepairAttempts,
+	}
+}
+
+// NewClientFromConfig creates a client for the backend and repair policy in the llm config
+func NewClientFromConfig(cfg *config.LLMConfig) (*Client, error) {
+	backend, err := NewBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client := NewClientWithBackend(backend)
+	if cfg != nil {
+		client.RepairAttempts = cfg.MaxRepairAttempts(DefaultRepairAttempts)
+	}
+	return client, nil
+}
+
+// backend returns the configured backend, falling back to the copilot CLI
+func (c *Client) backend() LLMBackend {
+	if c.Backend == nil {
+		return NewCLIBackend(c.BinaryPath)
 	}
+	return c.Backend
+}
+
+// prompts returns the configured prompt templates, falling back to the embedded ones
+func (c *Client) prompts() *prompts.Set {
+	if c.Prompts == nil {
+		return prompts.Default()
+	}
+	return c.Prompts
 }
 
 // Check verifies that the copilot CLI is available
@@ -37,30 +98,43 @@ func (c *Client) Check() error {
 	return nil
 }
 
-// RunAnalysis runs a copilot analysis with the given prompt and parses JSON output
+// RunAnalysis runs an analysis prompt against the backend and parses JSON output.
+// Each response is validated against the findings schema after normalizing fixable
+// fields; invalid responses are sent back with their validation errors up to
+// RepairAttempts times before the analysis fails.
 func (c *Client) RunAnalysis(ctx context.Context, prompt string) ([]findings.Finding, error) {
-	cmd := exec.CommandContext(ctx, c.BinaryPath, "-p", prompt)
+	root := c.repoRoot()
+	currentPrompt := prompt
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	for attempt := 0; ; attempt++ {
+		output, err := c.backend().Complete(ctx, currentPrompt)
+		if err != nil {
+			return nil, err
+		}
 
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("copilot command failed: %w (stderr: %s)", err, stderr.String())
-	}
+		results, problems := parseFindings(output, root)
+		if len(problems) == 0 {
+			return results, nil
+		}
 
-	// Extract JSON from markdown code block
-	jsonStr := extractJSON(stdout.String())
-	if jsonStr == "" {
-		return []findings.Finding{}, nil
-	}
+		if attempt >= c.RepairAttempts {
+			return nil, &ResponseError{Problems: problems}
+		}
 
-	var results []findings.Finding
-	if err := json.Unmarshal([]byte(jsonStr), &results); err != nil {
-		return nil, fmt.Errorf("failed to parse copilot JSON output: %w", err)
+		currentPrompt = buildRepairPrompt(prompt, output, problems)
 	}
+}
 
-	return results, nil
+// repoRoot returns the absolute repository root used for path normalization
+func (c *Client) repoRoot() string {
+	if c.RepoRoot != "" {
+		return c.RepoRoot
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return wd
 }
 
 // RunFix runs copilot interactively to provide local fix suggestions
@@ -102,7 +176,7 @@ func (c *Client) RunDelegate(ctx context.Context, prompt string) error {
 	return nil
 }
 
-// RunDeduplication uses Copilot to intelligently deduplicate findings
+// RunDeduplication uses the LLM backend to intelligently deduplicate findings
 // It merges related findings (even across categories) and filters out findings
 // that match existing tracked issues
 func (c *Client) RunDeduplication(ctx context.Context, newFindings []findings.Finding, existingIssues []issues.SearchResult) ([]findings.Finding, error) {
@@ -112,101 +186,166 @@ func (c *Client) RunDeduplication(ctx context.Context, newFindings []findings.Fi
 	}
 
 	// Build the deduplication prompt
-	prompt := buildDeduplicationPrompt(newFindings, existingIssues)
-
-	// Run copilot analysis with the deduplication prompt
-	cmd := exec.CommandContext(ctx, c.BinaryPath, "-p", prompt)
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	prompt, err := c.DeduplicationPrompt(newFindings, existingIssues)
+	if err != nil {
+		return newFindings, err
+	}
 
-	if err := cmd.Run(); err != nil {
+	// Run the deduplication prompt against the backend
+	output, err := c.backend().Complete(ctx, prompt)
+	if err != nil {
 		// If deduplication fails, return error so caller can use original findings
-		return newFindings, fmt.Errorf("copilot deduplication failed: %w (stderr: %q)", err, stderr.String())
+		return newFindings, fmt.Errorf("%s deduplication failed: %w", c.backend().Name(), err)
 	}
 
 	// Extract JSON from output
-	jsonStr := extractJSON(stdout.String())
+	jsonStr := extractJSON(output)
 	if jsonStr == "" {
-		// If we can't parse output, return error - Copilot may not have understood the prompt
+		// If we can't parse output, return error - the model may not have understood the prompt
 		// Include first 200 chars of output for debugging
-		output := stdout.String()
 		if len(output) > 200 {
 			output = output[:200] + "..."
 		}
-		return newFindings, fmt.Errorf("no JSON output from copilot deduplication (received: %q)", output)
+		return newFindings, fmt.Errorf("no JSON output from %s deduplication (received: %q)", c.backend().Name(), output)
+	}
+
+	// Validate without repair - the caller falls back to the original findings
+	deduplicated, problems := parseFindings(jsonStr, c.repoRoot())
+	if len(problems) > 0 {
+		return newFindings, fmt.Errorf("failed to parse deduplication JSON output: %w", &ResponseError{Problems: problems})
 	}
 
-	var deduplicated []findings.Finding
-	if err := json.Unmarshal([]byte(jsonStr), &deduplicated); err != nil {
-		// If JSON parsing fails, return error so caller can use original findings
-		return newFindings, fmt.Errorf("failed to parse deduplication JSON output: %w", err)
+	// The response only has the fields the model is asked for; the rest
+	// come back from the input findings its ids name
+	var refs []dedupRef
+	if err := json.Unmarshal([]byte(jsonStr), &refs); err == nil && len(refs) == len(deduplicated) {
+		restoreDedupFields(deduplicated, refs, newFindings)
 	}
+	findings.NormalizePaths(deduplicated)
 
 	return deduplicated, nil
 }
 
-// buildDeduplicationPrompt creates the prompt for Copilot deduplication
-func buildDeduplicationPrompt(newFindings []findings.Finding, existingIssues []issues.SearchResult) string {
-	var prompt strings.Builder
+// dedupInput is a finding sent for deduplication, with the id the response refers to it by
+type dedupInput struct {
+	ID string `json:"id"`
+	findings.Finding
+}
 
-	prompt.WriteString("You are a deduplication assistant for infrastructure findings. ")
-	prompt.WriteString("Your task is to intelligently merge duplicate/related findings and filter out findings that match existing tracked issues.\n\n")
+// dedupRef is the part of a deduplicated finding naming its input findings
+type dedupRef struct {
+	ID        string   `json:"id"`
+	MergedIDs []string `json:"merged_ids"`
+}
+
+// dedupID returns the id of the i-th finding sent for deduplication
+func dedupID(i int) string {
+	return strconv.Itoa(i + 1)
+}
+
+// dedupInputs gives each finding its deduplication id
+func dedupInputs(all []findings.Finding) []dedupInput {
+	inputs := make([]dedupInput, len(all))
+	for i, f := range all {
+		inputs[i] = dedupInput{ID: dedupID(i), Finding: f}
+	}
+	return inputs
+}
+
+// restoreDedupFields copies the fields the deduplication response does not
+// carry back from the input findings each result names: the scanner rule and
+// source, the original severity, the tags and the code snippets, which the
+// model may have trimmed or rewritten
+func restoreDedupFields(deduplicated []findings.Finding, refs []dedupRef, inputs []findings.Finding) {
+	byID := make(map[string]findings.Finding, len(inputs))
+	for i, f := range inputs {
+		byID[dedupID(i)] = f
+	}
 
-	// Add existing tracked issues if any
-	if len(existingIssues) > 0 {
-		prompt.WriteString("EXISTING TRACKED ISSUES (already have GitHub issues - remove related findings):\n")
-		for _, issue := range existingIssues {
-			prompt.WriteString(fmt.Sprintf("- \"%s\" (Issue #%d)\n", issue.Title, issue.Number))
+	for i := range deduplicated {
+		var sources []findings.Finding
+		seen := make(map[string]bool)
+		for _, id := range append([]string{refs[i].ID}, refs[i].MergedIDs...) {
+			if f, ok := byID[id]; ok && !seen[id] {
+				seen[id] = true
+				sources = append(sources, f)
+			}
+		}
+		if len(sources) == 0 {
+			continue
+		}
+
+		d := &deduplicated[i]
+		d.OriginalSeverity = sources[0].OriginalSeverity
+
+		var snippets []findings.CodeSnippet
+		snippetSeen := make(map[findings.CodeSnippet]bool)
+		for _, f := range sources {
+			if d.Rule == "" && f.Rule != "" {
+				d.Rule, d.Source = f.Rule, f.Source
+			}
+			for _, tag := range f.Tags {
+				d.AddTag(tag)
+			}
+			for _, snippet := range f.CodeSnippets {
+				if !snippetSeen[snippet] {
+					snippetSeen[snippet] = true
+					snippets = append(snippets, snippet)
+				}
+			}
+		}
+		if d.Source == "" {
+			d.Source = sources[0].Source
+		}
+		if len(snippets) > 0 {
+			d.CodeSnippets = snippets
 		}
-		prompt.WriteString("\n")
 	}
+}
 
-	// Add the findings to deduplicate as JSON
-	prompt.WriteString("NEW FINDINGS TO DEDUPLICATE:\n")
-	findingsJSON, err := json.MarshalIndent(newFindings, "", "  ")
+// DeduplicationPrompt returns the prompt RunDeduplication would send for these inputs
+func (c *Client) DeduplicationPrompt(newFindings []findings.Finding, existingIssues []issues.SearchResult) (string, error) {
+	return buildDeduplicationPrompt(c.prompts(), newFindings, existingIssues)
+}
+
+// buildDeduplicationPrompt renders the deduplication prompt template
+func buildDeduplicationPrompt(set *prompts.Set, newFindings []findings.Finding, existingIssues []issues.SearchResult) (string, error) {
+	findingsJSON, err := json.MarshalIndent(dedupInputs(newFindings), "", "  ")
 	if err != nil {
 		// Fallback to simple representation if JSON marshaling fails
 		// Manually build JSON with proper escaping for each field
-		prompt.WriteString("[\n")
+		var fallback strings.Builder
+		fallback.WriteString("[\n")
 		for i, f := range newFindings {
 			if i > 0 {
-				prompt.WriteString(",\n")
+				fallback.WriteString(",\n")
 			}
 			// Marshal individual fields to ensure proper escaping
 			// json.Marshal for strings always succeeds, so we can ignore errors
 			titleJSON, _ := json.Marshal(f.Title)
 			categoryJSON, _ := json.Marshal(f.Category)
 			severityJSON, _ := json.Marshal(f.Severity)
-			prompt.WriteString(fmt.Sprintf("  {\"title\": %s, \"category\": %s, \"severity\": %s}",
-				titleJSON, categoryJSON, severityJSON))
+			fallback.WriteString(fmt.Sprintf("  {\"id\": %q, \"title\": %s, \"category\": %s, \"severity\": %s}",
+				dedupID(i), titleJSON, categoryJSON, severityJSON))
 		}
-		prompt.WriteString("\n]\n\n")
-	} else {
-		prompt.WriteString(string(findingsJSON))
-		prompt.WriteString("\n\n")
+		fallback.WriteString("\n]")
+		findingsJSON = []byte(fallback.String())
 	}
 
-	// Add deduplication instructions (now preserving code snippets)
-	prompt.WriteString("INSTRUCTIONS:\n")
-	prompt.WriteString("1. Merge findings that describe the same underlying issue, even if they're from different categories (security/pipeline/infra)\n")
-	prompt.WriteString("2. When merging, keep the finding with the highest severity and combine the file lists (remove duplicates)\n")
-	prompt.WriteString("3. Preserve code_snippets from any merged finding; keep up to 2 per result.\n")
-	prompt.WriteString("4. Remove any findings that are duplicates or closely related to the existing tracked issues listed above\n")
-	prompt.WriteString("5. Keep the ID, category, and severity from the highest severity finding when merging\n")
-	prompt.WriteString("6. Combine descriptions and recommendations when merging, separating with '; '\n")
-	prompt.WriteString("7. Return ONLY the deduplicated findings as a JSON array in this exact format:\n")
-	prompt.WriteString("[{\"id\": \"string\", \"category\": \"string\", \"title\": \"string\", \"severity\": \"string\", ")
-	prompt.WriteString("\"description\": \"string\", \"recommendation\": \"string\", \"files\": [\"string\"], ")
-	prompt.WriteString("\"code_snippets\": [{\"file\": \"string\", \"start_line\": 0, \"end_line\": 0, \"code\": \"string\"}]}]\n\n")
-	prompt.WriteString("Output ONLY the JSON array with no explanation or markdown code blocks.\n")
-
-	return prompt.String()
+	return set.Render(prompts.NameDedup, prompts.DedupData{
+		ExistingIssues: existingIssues,
+		Findings:       newFindings,
+		FindingsJSON:   string(findingsJSON),
+	})
 }
 
 // extractJSON extracts JSON content from markdown code blocks
 func extractJSON(output string) string {
+	// An explicit empty result
+	if strings.TrimSpace(output) == "[]" {
+		return "[]"
+	}
+
 	// Try markdown code block first
 	re := regexp.MustCompile("(?s)```json\\s*\n(.*?)\n```")
 	matches := re.FindStringSubmatch(output)
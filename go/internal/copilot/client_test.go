@@ -6,6 +6,7 @@ import (
 
 	"github.com/liam-witterick/autoengineer/go/internal/findings"
 	"github.com/liam-witterick/autoengineer/go/internal/issues"
+	"github.com/liam-witterick/autoengineer/go/internal/prompts"
 )
 
 func TestBuildDeduplicationPrompt(t *testing.T) {
@@ -89,7 +90,7 @@ func TestBuildDeduplicationPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			prompt := buildDeduplicationPrompt(tt.newFindings, tt.existingIssues)
+			prompt := mustBuildDeduplicationPrompt(t, tt.newFindings, tt.existingIssues)
 
 			for _, want := range tt.wantContains {
 				if !contains(prompt, want) {
@@ -118,7 +119,7 @@ func TestBuildDeduplicationPromptFormat(t *testing.T) {
 		},
 	}
 
-	prompt := buildDeduplicationPrompt(newFindings, []issues.SearchResult{})
+	prompt := mustBuildDeduplicationPrompt(t, newFindings, []issues.SearchResult{})
 
 	// Check that prompt contains JSON structure
 	if !contains(prompt, `"category"`) {
@@ -142,7 +143,7 @@ func TestBuildDeduplicationPromptMultipleIssues(t *testing.T) {
 		{Number: 3, Title: "Issue 3"},
 	}
 
-	prompt := buildDeduplicationPrompt([]findings.Finding{}, existingIssues)
+	prompt := mustBuildDeduplicationPrompt(t, []findings.Finding{}, existingIssues)
 
 	// Check all issues are mentioned
 	if !contains(prompt, "Issue #1") {
@@ -166,7 +167,7 @@ func TestBuildDeduplicationPromptMultipleIssues(t *testing.T) {
 }
 
 func TestBuildDeduplicationPromptInstructions(t *testing.T) {
-	prompt := buildDeduplicationPrompt([]findings.Finding{}, []issues.SearchResult{})
+	prompt := mustBuildDeduplicationPrompt(t, []findings.Finding{}, []issues.SearchResult{})
 
 	requiredInstructions := []string{
 		"Merge findings that describe the same underlying issue",
@@ -184,6 +185,16 @@ func TestBuildDeduplicationPromptInstructions(t *testing.T) {
 	}
 }
 
+// mustBuildDeduplicationPrompt renders the embedded deduplication template
+func mustBuildDeduplicationPrompt(t *testing.T, newFindings []findings.Finding, existingIssues []issues.SearchResult) string {
+	t.Helper()
+	prompt, err := buildDeduplicationPrompt(prompts.Default(), newFindings, existingIssues)
+	if err != nil {
+		t.Fatalf("buildDeduplicationPrompt() error: %v", err)
+	}
+	return prompt
+}
+
 // Helper function to check if string contains substring
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
@@ -0,0 +1,120 @@
+package copilot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+)
+
+// maxErrorBodyLength limits how much of an error response body is included in errors
+const maxErrorBodyLength = 500
+
+// OpenAIBackend sends prompts to an OpenAI-compatible chat completions API.
+// Any server implementing POST {BaseURL}/chat/completions works, including
+// local inference servers such as Ollama, vLLM or LM Studio.
+type OpenAIBackend struct {
+	BaseURL    string
+	Model      string
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// NewOpenAIBackend creates a backend for an OpenAI-compatible API
+func NewOpenAIBackend(baseURL, model, apiKey string, timeout time.Duration) *OpenAIBackend {
+	return &OpenAIBackend{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Model:      model,
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the backend name
+func (b *OpenAIBackend) Name() string {
+	return config.LLMBackendOpenAI
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Complete sends the prompt as a single user message and returns the first choice
+func (b *OpenAIBackend) Complete(ctx context.Context, prompt string) (string, error) {
+	reqBody, err := json.Marshal(chatRequest{
+		Model:    b.Model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.BaseURL+"/chat/completions", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to build LLM request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+b.APIKey)
+	}
+
+	client := b.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("LLM request to %s failed: %w", b.BaseURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read LLM response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		snippet := string(body)
+		if len(snippet) > maxErrorBodyLength {
+			snippet = snippet[:maxErrorBodyLength] + "..."
+		}
+		return "", fmt.Errorf("LLM request failed with status %d: %s", resp.StatusCode, snippet)
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse LLM response: %w", err)
+	}
+
+	if parsed.Error != nil {
+		return "", fmt.Errorf("LLM returned an error: %s", parsed.Error.Message)
+	}
+
+	if len(parsed.Choices) == 0 {
+		return "", fmt.Errorf("LLM response contained no choices")
+	}
+
+	return parsed.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,93 @@
+package copilot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenAIBackendComplete(t *testing.T) {
+	var gotRequest chatRequest
+	var gotAuth string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		gotAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&gotRequest); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "[]"}}]}`))
+	}))
+	defer server.Close()
+
+	backend := NewOpenAIBackend(server.URL+"/v1/", "llama3", "secret", time.Minute)
+
+	output, err := backend.Complete(context.Background(), "find issues")
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	if output != "[]" {
+		t.Errorf("expected response content, got %q", output)
+	}
+	if gotRequest.Model != "llama3" {
+		t.Errorf("expected model llama3, got %q", gotRequest.Model)
+	}
+	if len(gotRequest.Messages) != 1 || gotRequest.Messages[0].Content != "find issues" {
+		t.Errorf("expected prompt as single user message, got %+v", gotRequest.Messages)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("expected bearer auth header, got %q", gotAuth)
+	}
+}
+
+func TestOpenAIBackendNoAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("expected no Authorization header, got %q", auth)
+		}
+		w.Write([]byte(`{"choices": [{"message": {"content": "ok"}}]}`))
+	}))
+	defer server.Close()
+
+	backend := NewOpenAIBackend(server.URL, "llama3", "", time.Minute)
+	if _, err := backend.Complete(context.Background(), "hi"); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+}
+
+func TestOpenAIBackendErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "http error", status: http.StatusUnauthorized, body: `{"error": {"message": "bad key"}}`, wantErr: "status 401"},
+		{name: "api error", status: http.StatusOK, body: `{"error": {"message": "model not found"}}`, wantErr: "model not found"},
+		{name: "no choices", status: http.StatusOK, body: `{"choices": []}`, wantErr: "no choices"},
+		{name: "invalid json", status: http.StatusOK, body: `not json`, wantErr: "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			backend := NewOpenAIBackend(server.URL, "llama3", "", time.Minute)
+			_, err := backend.Complete(context.Background(), "hi")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
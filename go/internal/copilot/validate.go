@@ -0,0 +1,248 @@
+package copilot
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/schema"
+)
+
+// findingsSchemaJSON is the JSON Schema every analysis response must satisfy
+//
+//go:embed findings.schema.json
+var findingsSchemaJSON []byte
+
+var findingsSchema = schema.MustParse(findingsSchemaJSON)
+
+// FindingsSchema returns the published JSON Schema for analysis responses
+func FindingsSchema() []byte {
+	return findingsSchemaJSON
+}
+
+// maxTitleLength matches the title limit given to the model in every prompt
+const maxTitleLength = 80
+
+// severityAliases maps severities models commonly return onto the supported levels
+var severityAliases = map[string]string{
+	"error":         findings.SeverityHigh,
+	"moderate":      findings.SeverityMedium,
+	"warning":       findings.SeverityMedium,
+	"informational": findings.SeverityInfo,
+	"note":          findings.SeverityInfo,
+}
+
+// ResponseError reports an LLM response that could not be turned into valid findings
+type ResponseError struct {
+	Problems []string
+}
+
+// Error implements the error interface
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("invalid findings response: %s", strings.Join(e.Problems, "; "))
+}
+
+// parseFindings extracts, normalizes and validates the findings in a model response.
+// root is the absolute repository root used to relativize absolute paths.
+// It returns the findings, or the list of problems the model should be told about.
+func parseFindings(output, root string) ([]findings.Finding, []string) {
+	jsonStr := extractJSON(output)
+	if jsonStr == "" {
+		return nil, []string{"response did not contain a JSON array (return [] if there are no findings)"}
+	}
+
+	var raw interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &raw); err != nil {
+		return nil, []string{fmt.Sprintf("response is not valid JSON: %v", err)}
+	}
+
+	normalizeFindings(raw, root)
+
+	if errs := findingsSchema.Validate(raw); len(errs) > 0 {
+		problems := make([]string, len(errs))
+		for i, e := range errs {
+			problems[i] = e.Error()
+		}
+		return nil, problems
+	}
+
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("failed to re-encode findings: %v", err)}
+	}
+
+	var results []findings.Finding
+	if err := json.Unmarshal(normalized, &results); err != nil {
+		return nil, []string{fmt.Sprintf("failed to decode findings: %v", err)}
+	}
+
+	return results, nil
+}
+
+// normalizeFindings fixes up fields that have an unambiguous correction, in place,
+// so that only genuinely broken responses are sent back for repair
+func normalizeFindings(raw interface{}, root string) {
+	items, ok := raw.([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if severity, ok := obj["severity"].(string); ok {
+			severity = strings.ToLower(strings.TrimSpace(severity))
+			if alias, ok := severityAliases[severity]; ok {
+				severity = alias
+			}
+			obj["severity"] = severity
+		}
+
+		if title, ok := obj["title"].(string); ok {
+			obj["title"] = truncateTitle(strings.TrimSpace(title))
+		}
+
+		// A single file given as a string instead of an array
+		if file, ok := obj["files"].(string); ok {
+			obj["files"] = []interface{}{file}
+		}
+
+		seen := make(map[string]bool)
+		var files []interface{}
+		if list, ok := obj["files"].([]interface{}); ok {
+			for _, f := range list {
+				path, ok := f.(string)
+				if !ok {
+					files = append(files, f)
+					continue
+				}
+				path = normalizePath(path, root)
+				if path == "" || seen[path] {
+					continue
+				}
+				seen[path] = true
+				files = append(files, path)
+			}
+		}
+
+		if snippets, ok := obj["code_snippets"].([]interface{}); ok {
+			for _, s := range snippets {
+				snippet, ok := s.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if path, ok := snippet["file"].(string); ok {
+					path = normalizePath(path, root)
+					snippet["file"] = path
+					// Snippets reference files the finding should list
+					if path != "" && !seen[path] {
+						seen[path] = true
+						files = append(files, path)
+					}
+				}
+				start := normalizeLine(snippet, "start_line")
+				end := normalizeLine(snippet, "end_line")
+				if start > 0 && end > 0 && end < start {
+					snippet["start_line"], snippet["end_line"] = float64(end), float64(start)
+				}
+			}
+		}
+
+		if _, ok := obj["files"]; ok || len(files) > 0 {
+			if files == nil {
+				files = []interface{}{}
+			}
+			obj["files"] = files
+		}
+	}
+}
+
+// normalizePath converts a model-supplied path to a clean path relative to root.
+// Absolute paths outside root are returned unchanged so validation rejects them.
+func normalizePath(path, root string) string {
+	path = strings.TrimSpace(path)
+	path = strings.Trim(path, "`")
+	if path == "" {
+		return ""
+	}
+
+	path = strings.ReplaceAll(path, "\\", "/")
+
+	if filepath.IsAbs(path) {
+		if root == "" {
+			return path
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			return path
+		}
+		path = rel
+	}
+
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// normalizeLine converts a line number given as a string to a number and returns it
+func normalizeLine(snippet map[string]interface{}, key string) int {
+	switch v := snippet[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0
+		}
+		snippet[key] = float64(n)
+		return n
+	}
+	return 0
+}
+
+// truncateTitle shortens overlong titles at a word boundary
+func truncateTitle(title string) string {
+	runes := []rune(title)
+	if len(runes) <= maxTitleLength {
+		return title
+	}
+
+	cut := string(runes[:maxTitleLength-3])
+	if idx := strings.LastIndex(cut, " "); idx > maxTitleLength/2 {
+		cut = cut[:idx]
+	}
+	return strings.TrimRight(cut, " ,.;:-") + "..."
+}
+
+// buildRepairPrompt asks the model to correct a response that failed validation
+func buildRepairPrompt(originalPrompt, previousOutput string, problems []string) string {
+	var prompt strings.Builder
+
+	prompt.WriteString(originalPrompt)
+	prompt.WriteString("\n\nYOUR PREVIOUS RESPONSE FAILED VALIDATION:\n")
+	for _, problem := range problems {
+		prompt.WriteString("- " + problem + "\n")
+	}
+
+	if previousOutput != "" {
+		if len(previousOutput) > maxRepairEchoLength {
+			previousOutput = previousOutput[:maxRepairEchoLength] + "..."
+		}
+		prompt.WriteString("\nPREVIOUS RESPONSE:\n")
+		prompt.WriteString(previousOutput)
+		prompt.WriteString("\n")
+	}
+
+	prompt.WriteString("\nFix every problem listed above. Paths in \"files\" and \"code_snippets\" must be relative to the repository root. ")
+	prompt.WriteString("Return ONLY the corrected JSON array, or [] if there are no findings.\n")
+
+	return prompt.String()
+}
+
+// maxRepairEchoLength limits how much of the invalid response is echoed back
+const maxRepairEchoLength = 4000
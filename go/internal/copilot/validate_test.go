@@ -0,0 +1,196 @@
+package copilot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+func TestParseFindingsNormalizes(t *testing.T) {
+	output := "```json\n" + `[{
+  "category": "security",
+  "title": "  Security group open to the world  ",
+  "severity": "Critical",
+  "files": "/repo/modules/network/main.tf",
+  "code_snippets": [
+    {"file": "./modules/network/sg.tf", "start_line": "20", "end_line": 12, "code": "cidr_blocks = [\"0.0.0.0/0\"]"}
+  ]
+}]` + "\n```"
+
+	results, problems := parseFindings(output, "/repo")
+	if len(problems) > 0 {
+		t.Fatalf("expected no problems, got %v", problems)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(results))
+	}
+
+	f := results[0]
+	if f.Severity != findings.SeverityCritical {
+		t.Errorf("expected Critical to be lowercased, got %q", f.Severity)
+	}
+	if f.Title != "Security group open to the world" {
+		t.Errorf("expected trimmed title, got %q", f.Title)
+	}
+
+	wantFiles := []string{"modules/network/main.tf", "modules/network/sg.tf"}
+	if len(f.Files) != len(wantFiles) {
+		t.Fatalf("expected files %v, got %v", wantFiles, f.Files)
+	}
+	for i, want := range wantFiles {
+		if f.Files[i] != want {
+			t.Errorf("file %d: expected %q, got %q", i, want, f.Files[i])
+		}
+	}
+
+	snippet := f.CodeSnippets[0]
+	if snippet.File != "modules/network/sg.tf" {
+		t.Errorf("expected normalized snippet file, got %q", snippet.File)
+	}
+	if snippet.StartLine != 12 || snippet.EndLine != 20 {
+		t.Errorf("expected swapped line range 12-20, got %d-%d", snippet.StartLine, snippet.EndLine)
+	}
+}
+
+func TestParseFindingsTruncatesLongTitles(t *testing.T) {
+	long := strings.Repeat("word ", 30)
+	output := `[{"title": "` + long + `", "severity": "low", "files": ["a.tf"]}]`
+
+	results, problems := parseFindings(output, "")
+	if len(problems) > 0 {
+		t.Fatalf("expected no problems, got %v", problems)
+	}
+	if n := len([]rune(results[0].Title)); n > maxTitleLength {
+		t.Errorf("expected title of at most %d chars, got %d", maxTitleLength, n)
+	}
+	if !strings.HasSuffix(results[0].Title, "...") {
+		t.Errorf("expected truncated title to end with ..., got %q", results[0].Title)
+	}
+}
+
+func TestParseFindingsProblems(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		wantMsg string
+	}{
+		{
+			name:    "no JSON",
+			output:  "I could not find any issues in this repository.",
+			wantMsg: "did not contain a JSON array",
+		},
+		{
+			name:    "missing title",
+			output:  `[{"severity": "high", "files": ["main.tf"]}]`,
+			wantMsg: "/0/title: is required",
+		},
+		{
+			name:    "unknown severity",
+			output:  `[{"title": "A", "severity": "urgent", "files": ["main.tf"]}]`,
+			wantMsg: "/0/severity: must be one of critical, high, medium, low, info",
+		},
+		{
+			name:    "absolute path outside repo",
+			output:  `[{"title": "A", "severity": "high", "files": ["/etc/passwd"]}]`,
+			wantMsg: "/0/files/0",
+		},
+		{
+			name:    "path escaping repo",
+			output:  `[{"title": "A", "severity": "high", "files": ["../other/main.tf"]}]`,
+			wantMsg: "/0/files/0",
+		},
+		{
+			name:    "no files",
+			output:  `[{"title": "A", "severity": "high", "files": []}]`,
+			wantMsg: "/0/files: must contain at least 1 item",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, problems := parseFindings(tt.output, "/repo")
+			if len(problems) == 0 {
+				t.Fatal("expected problems, got none")
+			}
+			joined := strings.Join(problems, "\n")
+			if !strings.Contains(joined, tt.wantMsg) {
+				t.Errorf("expected problem containing %q, got %v", tt.wantMsg, problems)
+			}
+		})
+	}
+}
+
+func TestParseFindingsEmptyArray(t *testing.T) {
+	for _, output := range []string{"[]", "  []\n", "```json\n[]\n```"} {
+		results, problems := parseFindings(output, "")
+		if len(problems) > 0 {
+			t.Errorf("%q: expected no problems, got %v", output, problems)
+		}
+		if len(results) != 0 {
+			t.Errorf("%q: expected no findings, got %d", output, len(results))
+		}
+	}
+}
+
+func TestRunAnalysisRepairsInvalidResponse(t *testing.T) {
+	backend := &fakeBackend{
+		responses: []string{
+			`[{"title": "Unpinned module", "severity": "urgent", "files": ["main.tf"]}]`,
+			`[{"title": "Unpinned module", "severity": "medium", "files": ["main.tf"]}]`,
+		},
+	}
+	client := NewClientWithBackend(backend)
+
+	results, err := client.RunAnalysis(context.Background(), "ORIGINAL PROMPT")
+	if err != nil {
+		t.Fatalf("expected repaired response to succeed, got %v", err)
+	}
+	if len(results) != 1 || results[0].Severity != findings.SeverityMedium {
+		t.Errorf("unexpected findings: %+v", results)
+	}
+
+	if len(backend.prompts) != 2 {
+		t.Fatalf("expected 2 prompts (original + repair), got %d", len(backend.prompts))
+	}
+	repair := backend.prompts[1]
+	for _, want := range []string{"ORIGINAL PROMPT", "FAILED VALIDATION", "/0/severity", `"urgent"`} {
+		if !strings.Contains(repair, want) {
+			t.Errorf("repair prompt should contain %q", want)
+		}
+	}
+}
+
+func TestRunAnalysisFailsAfterRepairAttempts(t *testing.T) {
+	backend := &fakeBackend{responses: []string{"no json here"}}
+	client := NewClientWithBackend(backend)
+	client.RepairAttempts = 1
+
+	_, err := client.RunAnalysis(context.Background(), "prompt")
+	if err == nil {
+		t.Fatal("expected error after exhausting repair attempts")
+	}
+
+	var responseErr *ResponseError
+	if !errors.As(err, &responseErr) {
+		t.Errorf("expected ResponseError, got %T", err)
+	}
+	if len(backend.prompts) != 2 {
+		t.Errorf("expected 2 prompts with 1 repair attempt, got %d", len(backend.prompts))
+	}
+}
+
+func TestRunAnalysisNoRepair(t *testing.T) {
+	backend := &fakeBackend{responses: []string{"no json here"}}
+	client := NewClientWithBackend(backend)
+	client.RepairAttempts = 0
+
+	if _, err := client.RunAnalysis(context.Background(), "prompt"); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(backend.prompts) != 1 {
+		t.Errorf("expected a single prompt with repair disabled, got %d", len(backend.prompts))
+	}
+}
@@ -0,0 +1,54 @@
+package findings
+
+import (
+	"time"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+)
+
+// AcceptanceReview lists the accepted risks that need attention
+type AcceptanceReview struct {
+	// Expired acceptances no longer ignore their findings
+	Expired []config.AcceptedItem
+
+	// Expiring acceptances lapse within the review window
+	Expiring []config.AcceptedItem
+
+	// Unused acceptances match none of the findings and can be removed
+	Unused []config.AcceptedItem
+}
+
+// Empty reports whether no acceptance needs attention
+func (r AcceptanceReview) Empty() bool {
+	return len(r.Expired) == 0 && len(r.Expiring) == 0 && len(r.Unused) == 0
+}
+
+// ReviewAcceptances checks accepted risks at now against all the findings of
+// a scan, before they are filtered. Acceptances lapsing within the window are
+// expiring; those matching no finding are unused rather than expired.
+func ReviewAcceptances(accepted []config.AcceptedItem, all []Finding, now time.Time, window time.Duration) AcceptanceReview {
+	titles := make(map[string]bool, len(all))
+	for _, f := range all {
+		titles[f.Title] = true
+	}
+
+	var review AcceptanceReview
+	seen := make(map[config.AcceptedItem]bool)
+	for _, item := range accepted {
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+
+		at, expires := item.ExpiresAt()
+		switch {
+		case !titles[item.Title]:
+			review.Unused = append(review.Unused, item)
+		case item.Expired(now):
+			review.Expired = append(review.Expired, item)
+		case expires && at.Before(now.Add(window)):
+			review.Expiring = append(review.Expiring, item)
+		}
+	}
+	return review
+}
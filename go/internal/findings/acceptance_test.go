@@ -0,0 +1,59 @@
+package findings
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+)
+
+func TestReviewAcceptances(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	accepted := []config.AcceptedItem{
+		{Title: "Open security group", Expires: "2026-03-01"},
+		{Title: "Public bucket", Expires: "2026-03-20"},
+		{Title: "Unpinned action", Expires: "2026-06-01"},
+		{Title: "Legacy TLS"},
+		{Title: "Removed finding", Expires: "2026-01-01"},
+		{Title: "Legacy TLS"},
+	}
+	all := []Finding{{Title: "Open security group"}, {Title: "Public bucket"}, {Title: "Unpinned action"}, {Title: "Legacy TLS"}}
+
+	review := ReviewAcceptances(accepted, all, now, 30*24*time.Hour)
+	titles := func(items []config.AcceptedItem) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.Title)
+		}
+		return out
+	}
+	if got := titles(review.Expired); !reflect.DeepEqual(got, []string{"Open security group"}) {
+		t.Errorf("Expired = %v", got)
+	}
+	if got := titles(review.Expiring); !reflect.DeepEqual(got, []string{"Public bucket"}) {
+		t.Errorf("Expiring = %v", got)
+	}
+	if got := titles(review.Unused); !reflect.DeepEqual(got, []string{"Removed finding"}) {
+		t.Errorf("Unused = %v", got)
+	}
+}
+
+func TestFilterReportsExpiredAcceptances(t *testing.T) {
+	cfg := &config.IgnoreConfig{Accepted: []config.AcceptedItem{
+		{Title: "Open security group", Expires: "2000-01-01"},
+		{Title: "Public bucket", Expires: "2999-01-01"},
+	}}
+	all := []Finding{{Title: "Open security group", Tags: []string{TagUnverified}}, {Title: "Public bucket"}}
+
+	filtered, ignoredCount := Filter(all, cfg)
+	if ignoredCount != 1 || len(filtered) != 1 || filtered[0].Title != "Open security group" {
+		t.Fatalf("expected only the finding with an active acceptance to be ignored, got %d ignored, %+v", ignoredCount, filtered)
+	}
+	if !reflect.DeepEqual(filtered[0].Tags, []string{TagUnverified, TagAcceptanceExpired}) {
+		t.Errorf("expected the finding to be tagged %q, got %v", TagAcceptanceExpired, filtered[0].Tags)
+	}
+	if len(all[0].Tags) != 1 {
+		t.Errorf("tagging should not modify the original finding, got %v", all[0].Tags)
+	}
+}
@@ -0,0 +1,81 @@
+package findings
+
+import "sync"
+
+// CategoryInfo describes how a category is displayed
+type CategoryInfo struct {
+	Name  string
+	Title string
+	Emoji string
+}
+
+// defaultCategoryEmoji is used for categories that were never registered
+const defaultCategoryEmoji = "📋"
+
+var (
+	categoriesMu sync.RWMutex
+	categories   = []CategoryInfo{
+		{Name: CategorySecurity, Title: "Security", Emoji: "🔒"},
+		{Name: CategoryPipeline, Title: "Pipeline", Emoji: "⚙️"},
+		{Name: CategoryInfra, Title: "Infrastructure", Emoji: "🏗️"},
+	}
+)
+
+// RegisterCategory adds a category (e.g. from a custom scope). A category
+// that is already known, such as a built-in one reused by a custom scope,
+// keeps its title and emoji.
+func RegisterCategory(info CategoryInfo) {
+	categoriesMu.Lock()
+	defer categoriesMu.Unlock()
+
+	if info.Emoji == "" {
+		info.Emoji = defaultCategoryEmoji
+	}
+	if info.Title == "" {
+		info.Title = info.Name
+	}
+
+	for _, existing := range categories {
+		if existing.Name == info.Name {
+			return
+		}
+	}
+	categories = append(categories, info)
+}
+
+// Categories returns all known categories, built-in ones first
+func Categories() []CategoryInfo {
+	categoriesMu.RLock()
+	defer categoriesMu.RUnlock()
+
+	result := make([]CategoryInfo, len(categories))
+	copy(result, categories)
+	return result
+}
+
+// IsBuiltinCategory reports whether a category ships with AutoEngineer
+func IsBuiltinCategory(name string) bool {
+	return name == CategorySecurity || name == CategoryPipeline || name == CategoryInfra
+}
+
+// CategoryEmoji returns the emoji for a category
+func CategoryEmoji(name string) string {
+	categoriesMu.RLock()
+	defer categoriesMu.RUnlock()
+
+	for _, info := range categories {
+		if info.Name == name {
+			return info.Emoji
+		}
+	}
+	return defaultCategoryEmoji
+}
+
+// CountByCategoryName returns counts of findings for every category present
+func CountByCategoryName(findings []Finding) map[string]int {
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.Category]++
+	}
+	return counts
+}
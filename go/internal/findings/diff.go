@@ -0,0 +1,243 @@
+package findings
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+)
+
+// Diff match methods
+const (
+	MatchFingerprint = "fingerprint"
+	MatchSimilarity  = "similarity"
+)
+
+// Changed fields
+const (
+	ChangedSeverity = "severity"
+	ChangedFiles    = "files"
+)
+
+// Change is a finding present in both sets whose severity or files differ
+type Change struct {
+	Before Finding  `json:"before"`
+	After  Finding  `json:"after"`
+	Fields []string `json:"fields"`
+
+	// Match is how the two findings were paired: fingerprint or similarity
+	Match string `json:"match"`
+}
+
+// Diff compares two sets of findings, e.g. a main-branch scan and a PR scan
+type Diff struct {
+	Added     []Finding `json:"added"`
+	Resolved  []Finding `json:"resolved"`
+	Changed   []Change  `json:"changed"`
+	Unchanged int       `json:"unchanged"`
+}
+
+// DiffFindings pairs findings from before and after, first by fingerprint and
+// then, for the rest, by the similarity used to merge duplicates (best pairs
+// first). Unpaired findings are added or resolved.
+func DiffFindings(before, after []Finding) Diff {
+	d := Diff{Added: []Finding{}, Resolved: []Finding{}, Changed: []Change{}}
+	pairedBefore := make(map[int]bool)
+	pairedAfter := make(map[int]bool)
+
+	pair := func(i, j int, match string) {
+		pairedBefore[i] = true
+		pairedAfter[j] = true
+		if fields := changedFields(before[i], after[j]); len(fields) > 0 {
+			d.Changed = append(d.Changed, Change{Before: before[i], After: after[j], Fields: fields, Match: match})
+		} else {
+			d.Unchanged++
+		}
+	}
+
+	// Exact matches by fingerprint, in order
+	byFingerprint := make(map[string][]int)
+	for i, f := range before {
+		fp := Fingerprint(f)
+		byFingerprint[fp] = append(byFingerprint[fp], i)
+	}
+	for j, f := range after {
+		fp := Fingerprint(f)
+		if candidates := byFingerprint[fp]; len(candidates) > 0 {
+			pair(candidates[0], j, MatchFingerprint)
+			byFingerprint[fp] = candidates[1:]
+		}
+	}
+
+	// Similarity fallback for reworded findings
+	type candidate struct {
+		i, j  int
+		score float64
+	}
+	var candidates []candidate
+	for i := range before {
+		if pairedBefore[i] {
+			continue
+		}
+		for j := range after {
+			if pairedAfter[j] || !shouldMerge(before[i], after[j]) {
+				continue
+			}
+			candidates = append(candidates, candidate{i, j, calculateSimilarity(before[i], after[j])})
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool { return candidates[a].score > candidates[b].score })
+	for _, c := range candidates {
+		if !pairedBefore[c.i] && !pairedAfter[c.j] {
+			pair(c.i, c.j, MatchSimilarity)
+		}
+	}
+
+	for i, f := range before {
+		if !pairedBefore[i] {
+			d.Resolved = append(d.Resolved, f)
+		}
+	}
+	for j, f := range after {
+		if !pairedAfter[j] {
+			d.Added = append(d.Added, f)
+		}
+	}
+
+	sort.Stable(BySeverity(d.Added))
+	sort.Stable(BySeverity(d.Resolved))
+	sort.SliceStable(d.Changed, func(a, b int) bool {
+		return SeverityRank(d.Changed[a].After.Severity) < SeverityRank(d.Changed[b].After.Severity)
+	})
+	return d
+}
+
+// changedFields returns the fields that differ between paired findings
+func changedFields(a, b Finding) []string {
+	var fields []string
+	if a.Severity != b.Severity {
+		fields = append(fields, ChangedSeverity)
+	}
+	if !sameFiles(a.Files, b.Files) {
+		fields = append(fields, ChangedFiles)
+	}
+	return fields
+}
+
+// sameFiles compares file lists ignoring order and a leading ./ or /
+func sameFiles(a, b []string) bool {
+	set := make(map[string]bool)
+	for _, f := range a {
+		set[config.NormalizePath(f)] = true
+	}
+	other := make(map[string]bool)
+	for _, f := range b {
+		f = config.NormalizePath(f)
+		if !set[f] {
+			return false
+		}
+		other[f] = true
+	}
+	return len(set) == len(other)
+}
+
+// describeChange summarizes a change, e.g. "severity medium → high; files +b.tf −a.tf"
+func describeChange(c Change) string {
+	var parts []string
+	for _, field := range c.Fields {
+		switch field {
+		case ChangedSeverity:
+			parts = append(parts, fmt.Sprintf("severity %s → %s", c.Before.Severity, c.After.Severity))
+		case ChangedFiles:
+			added, removed := fileDelta(c.Before.Files, c.After.Files)
+			var delta []string
+			for _, f := range added {
+				delta = append(delta, "+"+f)
+			}
+			for _, f := range removed {
+				delta = append(delta, "−"+f)
+			}
+			parts = append(parts, "files "+strings.Join(delta, " "))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// fileDelta returns the files only in after and only in before
+func fileDelta(before, after []string) (added, removed []string) {
+	inBefore := make(map[string]bool)
+	for _, f := range before {
+		inBefore[config.NormalizePath(f)] = true
+	}
+	inAfter := make(map[string]bool)
+	for _, f := range after {
+		f = config.NormalizePath(f)
+		inAfter[f] = true
+		if !inBefore[f] {
+			added = append(added, f)
+		}
+	}
+	for _, f := range before {
+		if f = config.NormalizePath(f); !inAfter[f] {
+			removed = append(removed, f)
+		}
+	}
+	return added, removed
+}
+
+// WriteDiffText writes a diff for the terminal
+func WriteDiffText(w io.Writer, d Diff) {
+	fmt.Fprintf(w, "Diff: ➕ %d added  ✅ %d resolved  🔀 %d changed  (%d unchanged)\n", len(d.Added), len(d.Resolved), len(d.Changed), d.Unchanged)
+
+	if len(d.Added) > 0 {
+		fmt.Fprintln(w, "\n➕ Added:")
+		for _, f := range d.Added {
+			fmt.Fprintf(w, "   %s %s (%s)\n", SeverityEmoji(f.Severity), f.Title, joinFiles(f.Files))
+		}
+	}
+	if len(d.Resolved) > 0 {
+		fmt.Fprintln(w, "\n✅ Resolved:")
+		for _, f := range d.Resolved {
+			fmt.Fprintf(w, "   %s %s (%s)\n", SeverityEmoji(f.Severity), f.Title, joinFiles(f.Files))
+		}
+	}
+	if len(d.Changed) > 0 {
+		fmt.Fprintln(w, "\n🔀 Changed:")
+		for _, c := range d.Changed {
+			fmt.Fprintf(w, "   %s %s: %s\n", SeverityEmoji(c.After.Severity), c.After.Title, describeChange(c))
+		}
+	}
+}
+
+// WriteDiffMarkdown writes a diff as markdown, e.g. for a pull request comment
+func WriteDiffMarkdown(w io.Writer, d Diff) {
+	fmt.Fprintln(w, "## AutoEngineer findings diff")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "**%d added**, **%d resolved**, **%d changed** (%d unchanged)\n", len(d.Added), len(d.Resolved), len(d.Changed), d.Unchanged)
+
+	writeTable := func(heading string, all []Finding) {
+		if len(all) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n### %s\n\n| Severity | Finding | Files |\n|----------|---------|-------|\n", heading)
+		for _, f := range all {
+			fmt.Fprintf(w, "| %s %s | %s | %s |\n", SeverityEmoji(f.Severity), f.Severity, markdownCell(f.Title), markdownCell(joinFiles(f.Files)))
+		}
+	}
+	writeTable("➕ Added", d.Added)
+	writeTable("✅ Resolved", d.Resolved)
+
+	if len(d.Changed) > 0 {
+		fmt.Fprintf(w, "\n### 🔀 Changed\n\n| Severity | Finding | Change |\n|----------|---------|--------|\n")
+		for _, c := range d.Changed {
+			fmt.Fprintf(w, "| %s %s | %s | %s |\n", SeverityEmoji(c.After.Severity), c.After.Severity, markdownCell(c.After.Title), markdownCell(describeChange(c)))
+		}
+	}
+}
+
+// markdownCell escapes text for a markdown table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", "\\|"), "\n", " ")
+}
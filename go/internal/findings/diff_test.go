@@ -0,0 +1,75 @@
+package findings
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiffFindings(t *testing.T) {
+	before := []Finding{
+		{Category: CategorySecurity, Title: "S3 bucket allows public access", Severity: SeverityMedium, Files: []string{"s3.tf"}},
+		{Category: CategoryInfra, Title: "Missing resource limits on deployment", Severity: SeverityLow, Files: []string{"deploy.yaml"},
+			Description: "Containers in the deployment have no CPU or memory limits"},
+		{Category: CategoryPipeline, Title: "Unpinned GitHub action", Severity: SeverityMedium, Files: []string{"ci.yml"}},
+		{Category: CategoryInfra, Title: "Terraform state stored locally", Severity: SeverityHigh, Files: []string{"backend.tf"}},
+	}
+	after := []Finding{
+		// Same fingerprint, severity raised
+		{Category: CategorySecurity, Title: "S3 bucket allows public access", Severity: SeverityHigh, Files: []string{"s3.tf"}},
+		// Reworded: paired by similarity, files changed
+		{Category: CategoryInfra, Title: "Deployment is missing resource limits", Severity: SeverityLow, Files: []string{"deploy.yaml", "worker.yaml"},
+			Description: "Containers in the deployment have no CPU or memory limits"},
+		// Unchanged
+		{Category: CategoryPipeline, Title: "Unpinned GitHub action", Severity: SeverityMedium, Files: []string{"./ci.yml"}},
+		// New
+		{Category: CategorySecurity, Title: "Security group open to the world", Severity: SeverityHigh, Files: []string{"sg.tf"}},
+	}
+
+	d := DiffFindings(before, after)
+
+	if len(d.Added) != 1 || d.Added[0].Title != "Security group open to the world" {
+		t.Errorf("unexpected added: %+v", d.Added)
+	}
+	if len(d.Resolved) != 1 || d.Resolved[0].Title != "Terraform state stored locally" {
+		t.Errorf("unexpected resolved: %+v", d.Resolved)
+	}
+	if d.Unchanged != 1 {
+		t.Errorf("expected 1 unchanged finding, got %d", d.Unchanged)
+	}
+	if len(d.Changed) != 2 {
+		t.Fatalf("expected 2 changed findings, got %+v", d.Changed)
+	}
+	severity, files := d.Changed[0], d.Changed[1]
+	if severity.Match != MatchFingerprint || strings.Join(severity.Fields, ",") != ChangedSeverity {
+		t.Errorf("unexpected severity change: %+v", severity)
+	}
+	if files.Match != MatchSimilarity || strings.Join(files.Fields, ",") != ChangedFiles {
+		t.Errorf("unexpected files change: %+v", files)
+	}
+	if got := describeChange(files); got != "files +worker.yaml" {
+		t.Errorf("describeChange() = %q", got)
+	}
+}
+
+func TestWriteDiff(t *testing.T) {
+	d := DiffFindings(
+		[]Finding{{Category: CategoryInfra, Title: "Old | issue", Severity: SeverityLow, Files: []string{"a.tf"}}},
+		[]Finding{{Category: CategorySecurity, Title: "New issue", Severity: SeverityHigh, Files: []string{"b.tf"}}},
+	)
+
+	var text bytes.Buffer
+	WriteDiffText(&text, d)
+	if !strings.Contains(text.String(), "➕ 1 added  ✅ 1 resolved") || !strings.Contains(text.String(), "New issue (b.tf)") {
+		t.Errorf("unexpected text diff:\n%s", text.String())
+	}
+
+	var md bytes.Buffer
+	WriteDiffMarkdown(&md, d)
+	if !strings.Contains(md.String(), "### ➕ Added") || !strings.Contains(md.String(), `Old \| issue`) {
+		t.Errorf("unexpected markdown diff:\n%s", md.String())
+	}
+	if strings.Contains(md.String(), "Changed") {
+		t.Error("empty sections should be omitted")
+	}
+}
@@ -49,10 +49,21 @@ func DisplayFindings(findings []Finding, opts DisplayOptions) {
 	for i := 0; i < maxDisplay; i++ {
 		f := findings[i]
 		emoji := SeverityEmoji(f.Severity)
-		fmt.Printf("%d. %s %s\n", i+1, emoji, f.Title)
+		fmt.Printf("%d. %s %s%s%s\n", i+1, emoji, f.Title, FormatTags(f.Tags), baselineMarker(f))
 		
 		if opts.ShowCategory {
 			fmt.Printf("   Category: %s\n", f.Category)
+			if f.Rule != "" {
+				fmt.Printf("   Rule: %s\n", f.Rule)
+			}
+			fmt.Printf("   Fingerprint: %s\n", Fingerprint(f))
+			if f.OriginalSeverity != "" {
+				fmt.Printf("   Severity: %s (overridden from %s)\n", f.Severity, f.OriginalSeverity)
+			}
+		}
+		
+		if f.Project != "" {
+			fmt.Printf("   Project: %s\n", f.Project)
 		}
 		
 		fmt.Printf("   Files: %s\n", joinFiles(f.Files))
@@ -81,13 +92,23 @@ func DisplayFindings(findings []Finding, opts DisplayOptions) {
 
 // DisplaySummary displays a summary of findings by severity and category
 func DisplaySummary(findings []Finding) {
-	high, medium, low := CountBySeverity(findings)
+	severities := CountBySeverity(findings)
 	security, pipeline, infra := CountByCategory(findings)
 	total := len(findings)
 
-	fmt.Printf("Summary: 🔴 High: %d  🟡 Medium: %d  🟢 Low: %d  (Total: %d)\n", high, medium, low, total)
+	// Custom scope categories are listed after the built-in ones
+	var custom []CategoryInfo
+	counts := CountByCategoryName(findings)
+	for _, info := range Categories() {
+		if !IsBuiltinCategory(info.Name) && counts[info.Name] > 0 {
+			custom = append(custom, info)
+		}
+	}
+
+	fmt.Printf("Summary: 🚨 Critical: %d  🔴 High: %d  🟡 Medium: %d  🟢 Low: %d  🔵 Info: %d  (Total: %d)\n",
+		severities[SeverityCritical], severities[SeverityHigh], severities[SeverityMedium], severities[SeverityLow], severities[SeverityInfo], total)
 
-	if security+pipeline+infra > 0 {
+	if security+pipeline+infra > 0 || len(custom) > 0 {
 		fmt.Println()
 		if security > 0 {
 			fmt.Printf("🔒 Security:       %d finding(s)\n", security)
@@ -98,23 +119,46 @@ func DisplaySummary(findings []Finding) {
 		if infra > 0 {
 			fmt.Printf("🏗️  Infrastructure: %d finding(s)\n", infra)
 		}
+		for _, info := range custom {
+			fmt.Printf("%s %-16s%d finding(s)\n", info.Emoji, info.Title+":", counts[info.Name])
+		}
 	}
 }
 
 // SeverityEmoji returns the emoji for a severity level
 func SeverityEmoji(severity string) string {
 	switch severity {
+	case SeverityCritical:
+		return "🚨"
 	case SeverityHigh:
 		return "🔴"
 	case SeverityMedium:
 		return "🟡"
 	case SeverityLow:
 		return "🟢"
+	case SeverityInfo:
+		return "🔵"
 	default:
 		return "⚪"
 	}
 }
 
+// baselineMarker flags findings that are not in the baseline
+func baselineMarker(f Finding) string {
+	if f.Baseline == BaselineNew {
+		return " 🆕"
+	}
+	return ""
+}
+
+// FormatTags renders tags as a suffix, e.g. " [unverified]"
+func FormatTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(tags, ", ") + "]"
+}
+
 // joinFiles joins file paths with commas
 func joinFiles(files []string) string {
 	return strings.Join(files, ", ")
@@ -0,0 +1,411 @@
+package findings
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression is a compiled filter expression over finding fields, e.g.
+//
+//	severity >= medium && category == "security" && file =~ "^modules/"
+//
+// Comparisons are joined with && and ||, negated with ! and grouped with
+// parentheses. Values are double-quoted strings or bare words.
+type Expression struct {
+	src  string
+	root node
+}
+
+// ExpressionError reports an invalid filter expression
+type ExpressionError struct {
+	// Column is the 1-based position of the problem in the expression
+	Column int
+	Msg    string
+}
+
+// Error implements the error interface
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+// SourceLLM is the filter source of findings not reported by a scanner
+const SourceLLM = "llm"
+
+// expressionField reads the values of a finding field
+type expressionField struct {
+	values  func(f Finding) []string
+	ordered bool
+}
+
+func single(get func(f Finding) string) func(f Finding) []string {
+	return func(f Finding) []string { return []string{get(f)} }
+}
+
+// expressionFields are the fields an expression can compare; files and
+// tags match when any of the finding's values does
+var expressionFields = map[string]expressionField{
+	"title":             {values: single(func(f Finding) string { return f.Title })},
+	"description":       {values: single(func(f Finding) string { return f.Description })},
+	"recommendation":    {values: single(func(f Finding) string { return f.Recommendation })},
+	"category":          {values: single(func(f Finding) string { return f.Category })},
+	"severity":          {values: single(func(f Finding) string { return f.Severity }), ordered: true},
+	"original_severity": {values: single(func(f Finding) string { return f.OriginalSeverity }), ordered: true},
+	"rule":              {values: single(func(f Finding) string { return f.Rule })},
+	"source":            {values: single(func(f Finding) string { return f.SourceName() })},
+	"project":           {values: single(func(f Finding) string { return f.Project })},
+	"baseline":          {values: single(func(f Finding) string { return f.Baseline })},
+	"fingerprint":       {values: single(Fingerprint)},
+	"file":              {values: func(f Finding) []string { return f.Files }},
+	"tag":               {values: func(f Finding) []string { return f.Tags }},
+}
+
+// ExpressionFields returns the names of the fields an expression can compare
+func ExpressionFields() []string {
+	names := make([]string, 0, len(expressionFields))
+	for name := range expressionFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SourceName returns the scanner that reported the finding, or SourceLLM
+func (f Finding) SourceName() string {
+	if f.Source == "" {
+		return SourceLLM
+	}
+	return f.Source
+}
+
+// ParseExpression compiles a filter expression
+func ParseExpression(src string) (*Expression, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, &ExpressionError{Column: t.pos + 1, Msg: fmt.Sprintf("unexpected %s", t)}
+	}
+	return &Expression{src: src, root: root}, nil
+}
+
+// String returns the source of the expression
+func (e *Expression) String() string {
+	return e.src
+}
+
+// Match reports whether a finding satisfies the expression
+func (e *Expression) Match(f Finding) bool {
+	return e.root.match(f)
+}
+
+// FilterByExpression returns the findings matching an expression, or all
+// findings when expr is nil
+func FilterByExpression(all []Finding, expr *Expression) []Finding {
+	if expr == nil {
+		return all
+	}
+	filtered := make([]Finding, 0, len(all))
+	for _, f := range all {
+		if expr.Match(f) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// node is a node of a parsed expression
+type node interface {
+	match(f Finding) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) match(f Finding) bool { return n.left.match(f) && n.right.match(f) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) match(f Finding) bool { return n.left.match(f) || n.right.match(f) }
+
+type notNode struct{ operand node }
+
+func (n notNode) match(f Finding) bool { return !n.operand.match(f) }
+
+// comparison compares a field with a value. The negated operators != and !~
+// hold when no value of the field matches.
+type comparison struct {
+	field expressionField
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+func (c comparison) match(f Finding) bool {
+	negated := c.op == "!=" || c.op == "!~"
+	for _, v := range c.field.values(f) {
+		if c.test(v) {
+			return !negated
+		}
+	}
+	return negated
+}
+
+// test reports whether one value satisfies the comparison, ignoring negation
+func (c comparison) test(v string) bool {
+	switch c.op {
+	case "==", "!=":
+		return v == c.value
+	case "=~", "!~":
+		return c.re.MatchString(v)
+	}
+
+	// Ordered comparisons rank severities, most severe highest; empty values never match
+	if v == "" {
+		return false
+	}
+	have, want := -SeverityRank(v), -SeverityRank(c.value)
+	switch c.op {
+	case "<":
+		return have < want
+	case "<=":
+		return have <= want
+	case ">":
+		return have > want
+	default:
+		return have >= want
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// operators are the comparison operators, longest first
+var operators = []string{"==", "!=", "=~", "!~", "<=", ">=", "<", ">"}
+
+// lex splits an expression into tokens
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case strings.HasPrefix(src[i:], "&&"):
+			tokens = append(tokens, token{tokenAnd, "&&", i})
+			i += 2
+		case strings.HasPrefix(src[i:], "||"):
+			tokens = append(tokens, token{tokenOr, "||", i})
+			i += 2
+		case c == '"':
+			// Only \" and \\ are escapes, so regexps keep their backslashes
+			var sb strings.Builder
+			end := i + 1
+			for ; end < len(src) && src[end] != '"'; end++ {
+				if src[end] == '\\' && end+1 < len(src) && (src[end+1] == '"' || src[end+1] == '\\') {
+					end++
+				}
+				sb.WriteByte(src[end])
+			}
+			if end >= len(src) {
+				return nil, &ExpressionError{Column: i + 1, Msg: "unterminated string"}
+			}
+			tokens = append(tokens, token{tokenString, sb.String(), i})
+			i = end + 1
+		default:
+			if op := matchOperator(src[i:]); op != "" {
+				tokens = append(tokens, token{tokenOp, op, i})
+				i += len(op)
+				continue
+			}
+			if c == '!' {
+				tokens = append(tokens, token{tokenNot, "!", i})
+				i++
+				continue
+			}
+			end := i
+			for end < len(src) && isWordByte(src[end]) {
+				end++
+			}
+			if end == i {
+				return nil, &ExpressionError{Column: i + 1, Msg: fmt.Sprintf("unexpected character %q", src[i])}
+			}
+			tokens = append(tokens, token{tokenWord, src[i:end], i})
+			i = end
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(src)}), nil
+}
+
+// matchOperator returns the comparison operator at the start of s, if any
+func matchOperator(s string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// isWordByte reports whether c can be part of a bare word
+func isWordByte(c byte) bool {
+	r := rune(c)
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-./:*", r)
+}
+
+// parser is a recursive descent parser over tokens:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" or ")" | comparison
+//	comparison = field op value
+type parser struct {
+	tokens []token
+	i      int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch t := p.peek(); t.kind {
+	case tokenNot:
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case tokenLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, &ExpressionError{Column: closing.pos + 1, Msg: fmt.Sprintf("expected \")\", found %s", closing)}
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	name := p.next()
+	if name.kind != tokenWord {
+		return nil, &ExpressionError{Column: name.pos + 1, Msg: fmt.Sprintf("expected a field name, found %s", name)}
+	}
+	field, ok := expressionFields[name.text]
+	if !ok {
+		return nil, &ExpressionError{Column: name.pos + 1, Msg: fmt.Sprintf("unknown field %q (must be one of %s)", name.text, strings.Join(ExpressionFields(), ", "))}
+	}
+
+	op := p.next()
+	if op.kind != tokenOp {
+		return nil, &ExpressionError{Column: op.pos + 1, Msg: fmt.Sprintf("expected an operator after %s, found %s", name.text, op)}
+	}
+	ordered := op.text[0] == '<' || op.text[0] == '>'
+	if ordered && !field.ordered {
+		return nil, &ExpressionError{Column: op.pos + 1, Msg: fmt.Sprintf("%s only compares severities; use == or =~ for %s", op.text, name.text)}
+	}
+
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, &ExpressionError{Column: value.pos + 1, Msg: fmt.Sprintf("expected a value after %s, found %s", op.text, value)}
+	}
+
+	c := comparison{field: field, op: op.text, value: value.text}
+	switch {
+	case op.text == "=~" || op.text == "!~":
+		re, err := regexp.Compile(value.text)
+		if err != nil {
+			return nil, &ExpressionError{Column: value.pos + 1, Msg: fmt.Sprintf("invalid regexp: %v", err)}
+		}
+		c.re = re
+	case field.ordered && (ordered || value.text != "") && !ValidateSeverity(value.text):
+		return nil, &ExpressionError{Column: value.pos + 1, Msg: fmt.Sprintf("unknown severity %q (must be one of %s)", value.text, strings.Join(Severities, ", "))}
+	}
+	return c, nil
+}
@@ -0,0 +1,79 @@
+package findings
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpressionMatch(t *testing.T) {
+	all := []Finding{
+		{Title: "Public bucket", Category: CategorySecurity, Severity: SeverityCritical, Rule: "AVD-AWS-0086", Source: "trivy", Files: []string{"modules/s3/main.tf"}},
+		{Title: "Unpinned action", Category: CategoryPipeline, Severity: SeverityMedium, Files: []string{".github/workflows/ci.yml"}, Tags: []string{TagUnverified}},
+		{Title: "Open ingress", Category: CategorySecurity, Severity: SeverityLow, Rule: "CKV_AWS_260", Source: "checkov", Files: []string{"envs/prod/sg.tf", "modules/sg/main.tf"}},
+		{Title: "Missing tags", Category: CategoryInfra, Severity: SeverityInfo, Files: []string{"envs/dev/vpc.tf"}, OriginalSeverity: SeverityLow},
+	}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{`severity >= medium && category == "security" && file =~ "^modules/"`, []string{"Public bucket"}},
+		{`source == trivy`, []string{"Public bucket"}},
+		{`source == llm`, []string{"Unpinned action", "Missing tags"}},
+		{`severity < medium`, []string{"Open ingress", "Missing tags"}},
+		{`severity <= low && severity > info`, []string{"Open ingress"}},
+		{`file =~ "^modules/"`, []string{"Public bucket", "Open ingress"}},
+		{`file !~ "^modules/"`, []string{"Unpinned action", "Missing tags"}},
+		{`!(category == security) || rule =~ "^CKV_"`, []string{"Unpinned action", "Open ingress", "Missing tags"}},
+		{`category == security && (severity == critical || source == checkov)`, []string{"Public bucket", "Open ingress"}},
+		{`tag == unverified`, []string{"Unpinned action"}},
+		{`original_severity != ""`, []string{"Missing tags"}},
+		{`original_severity >= info`, []string{"Missing tags"}},
+		{`title =~ "(?i)^public"`, []string{"Public bucket"}},
+		{`file =~ "\.yml$"`, []string{"Unpinned action"}},
+		{`rule == "CKV_AWS_260"`, []string{"Open ingress"}},
+	}
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.expr)
+		if err != nil {
+			t.Errorf("ParseExpression(%q): %v", tt.expr, err)
+			continue
+		}
+		var titles []string
+		for _, f := range FilterByExpression(all, expr) {
+			titles = append(titles, f.Title)
+		}
+		if strings.Join(titles, ", ") != strings.Join(tt.want, ", ") {
+			t.Errorf("%s matched %v, want %v", tt.expr, titles, tt.want)
+		}
+	}
+
+	if got := FilterByExpression(all, nil); len(got) != len(all) {
+		t.Errorf("expected a nil expression to keep all findings, got %d", len(got))
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{`sevrity == high`, `column 1: unknown field "sevrity" (must be one of baseline, category, description`},
+		{`severity >= urgent`, `column 13: unknown severity "urgent" (must be one of critical, high, medium, low, info)`},
+		{`category > security`, `column 10: > only compares severities; use == or =~ for category`},
+		{`file =~ "(unclosed"`, "column 9: invalid regexp: error parsing regexp: missing closing ): `(unclosed`"},
+		{`title == "open`, `column 10: unterminated string`},
+		{`severity == high &&`, `column 20: expected a field name, found end of expression`},
+		{`severity high`, `column 10: expected an operator after severity, found "high"`},
+		{`(severity == high`, `column 18: expected ")", found end of expression`},
+		{`severity == high category == security`, `column 18: unexpected "category"`},
+		{`title = "x"`, `column 7: unexpected character '='`},
+		{``, `column 1: expected a field name, found end of expression`},
+	}
+	for _, tt := range tests {
+		_, err := ParseExpression(tt.expr)
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("ParseExpression(%q) error = %v, want %q", tt.expr, err, tt.want)
+		}
+	}
+}
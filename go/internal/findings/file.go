@@ -0,0 +1,43 @@
+package findings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SaveFile writes findings to path as an indented JSON array
+func SaveFile(all []Finding, path string) error {
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadFile reads findings saved by SaveFile. Files written before critical
+// and info were added only hold high, medium and low, which are still valid;
+// severities are lowercased and paths normalized for files edited by hand.
+func LoadFile(path string) ([]Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("findings file not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to read findings file: %w", err)
+	}
+
+	var all []Finding
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse findings file: %w", err)
+	}
+	for i := range all {
+		all[i].Severity = strings.ToLower(strings.TrimSpace(all[i].Severity))
+		all[i].OriginalSeverity = strings.ToLower(strings.TrimSpace(all[i].OriginalSeverity))
+	}
+	NormalizePaths(all)
+
+	return all, nil
+}
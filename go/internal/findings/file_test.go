@@ -0,0 +1,34 @@
+package findings
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestLoadFileSeverities(t *testing.T) {
+	// A findings.json written before critical and info were added, edited by hand
+	path := filepath.Join(t.TempDir(), "findings.json")
+	data := `[
+  {"id": "1", "category": "infra", "title": "Missing tags", "severity": "low", "files": ["vpc.tf"]},
+  {"id": "2", "category": "security", "title": "Public bucket", "severity": "High", "files": ["s3.tf"], "original_severity": "Medium"},
+  {"id": "3", "category": "security", "title": "Root login", "severity": "critical", "files": ["iam.tf"]}
+]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all[1].Severity != SeverityHigh || all[1].OriginalSeverity != SeverityMedium {
+		t.Errorf("expected lowercased severities, got %q and %q", all[1].Severity, all[1].OriginalSeverity)
+	}
+
+	sort.Stable(BySeverity(all))
+	if all[0].Title != "Root login" || all[2].Title != "Missing tags" {
+		t.Errorf("unexpected order %+v", all)
+	}
+}
@@ -1,6 +1,7 @@
 package findings
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/liam-witterick/autoengineer/go/internal/config"
@@ -8,14 +9,37 @@ import (
 
 // Filter filters findings based on ignore configuration
 func Filter(findings []Finding, cfg *config.IgnoreConfig) (filtered []Finding, ignoredCount int) {
-	acceptedTitles := cfg.GetAcceptedTitles()
+	return FilterWith(findings, func(Finding) *config.IgnoreConfig { return cfg })
+}
+
+// FilterWith filters each finding with the ignore configuration returned for
+// it, e.g. the configuration of the project owning the finding. Findings whose
+// accepted risks have all expired are kept and tagged TagAcceptanceExpired.
+func FilterWith(findings []Finding, cfgFor func(Finding) *config.IgnoreConfig) (filtered []Finding, ignoredCount int) {
+	acceptedTitles := make(map[*config.IgnoreConfig]map[string]bool)
+	rules := make(map[*config.IgnoreConfig][]ignoreRule)
 	filtered = make([]Finding, 0, len(findings))
 
 	for _, finding := range findings {
-		if shouldIgnore(finding, cfg, acceptedTitles) {
+		cfg := cfgFor(finding)
+		if _, ok := acceptedTitles[cfg]; !ok {
+			acceptedTitles[cfg] = cfg.GetAcceptedTitles()
+			rules[cfg] = compileRules(cfg.Rules)
+		}
+
+		if shouldIgnore(finding, cfg, acceptedTitles[cfg]) {
+			ignoredCount++
+			continue
+		}
+		finding, ignored := applyRules(finding, rules[cfg])
+		if ignored {
 			ignoredCount++
 			continue
 		}
+		if active, ok := acceptedTitles[cfg][finding.Title]; ok && !active {
+			finding.Tags = append([]string(nil), finding.Tags...)
+			finding.AddTag(TagAcceptanceExpired)
+		}
 		filtered = append(filtered, finding)
 	}
 
@@ -25,8 +49,8 @@ func Filter(findings []Finding, cfg *config.IgnoreConfig) (filtered []Finding, i
 // FilterBySeverity filters findings by minimum severity level
 // Returns only findings at or above the specified minimum severity
 func FilterBySeverity(findings []Finding, minSeverity string) []Finding {
-	if minSeverity == "" || minSeverity == SeverityLow {
-		// No filtering needed - low includes all severities
+	if minSeverity == "" || minSeverity == SeverityInfo {
+		// No filtering needed - info includes all severities
 		return findings
 	}
 
@@ -36,17 +60,11 @@ func FilterBySeverity(findings []Finding, minSeverity string) []Finding {
 		return findings
 	}
 
-	severityOrder := map[string]int{
-		SeverityHigh:   3,
-		SeverityMedium: 2,
-		SeverityLow:    1,
-	}
-
-	minLevel := severityOrder[minSeverity]
+	minRank := SeverityRank(minSeverity)
 	filtered := make([]Finding, 0, len(findings))
 
 	for _, finding := range findings {
-		if severityOrder[finding.Severity] >= minLevel {
+		if SeverityRank(finding.Severity) <= minRank {
 			filtered = append(filtered, finding)
 		}
 	}
@@ -56,7 +74,7 @@ func FilterBySeverity(findings []Finding, minSeverity string) []Finding {
 
 // ValidateSeverity checks if a severity string is valid
 func ValidateSeverity(severity string) bool {
-	return severity == SeverityHigh || severity == SeverityMedium || severity == SeverityLow
+	return SeverityRank(severity) < len(Severities)
 }
 
 // shouldIgnore determines if a finding should be ignored based on config
@@ -95,12 +113,134 @@ func shouldIgnore(finding Finding, cfg *config.IgnoreConfig, acceptedTitles map[
 		}
 	}
 
-	// Check if any file matches ignore paths
+	// Check if any file, or the file of any code snippet, matches ignore paths
 	for _, file := range finding.Files {
 		if cfg.MatchesPath(file) {
 			return true
 		}
 	}
+	for _, snippet := range finding.CodeSnippets {
+		if snippet.File != "" && cfg.MatchesPath(snippet.File) {
+			return true
+		}
+	}
 
 	return false
 }
+
+// conditions are the parts of a finding a rule matches, all of which must
+// hold; empty conditions match anything
+type conditions struct {
+	title       *regexp.Regexp
+	category    string
+	severity    string
+	rule        string
+	files       []string
+	fingerprint string
+}
+
+// matches reports whether a finding meets all the conditions, given its fingerprint
+func (c conditions) matches(f Finding, fingerprint string) bool {
+	if c.title != nil && !c.title.MatchString(f.Title) {
+		return false
+	}
+	if c.category != "" && !strings.EqualFold(c.category, f.Category) {
+		return false
+	}
+	if c.severity != "" && !strings.EqualFold(c.severity, f.Severity) {
+		return false
+	}
+	if c.rule != "" && !strings.EqualFold(c.rule, f.Rule) {
+		return false
+	}
+	if c.fingerprint != "" && !strings.EqualFold(c.fingerprint, fingerprint) {
+		return false
+	}
+	if len(c.files) == 0 {
+		return true
+	}
+	for _, file := range f.Files {
+		if config.MatchGlobs(c.files, file) {
+			return true
+		}
+	}
+	for _, snippet := range f.CodeSnippets {
+		if snippet.File != "" && config.MatchGlobs(c.files, snippet.File) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoreRule is an ignore rule with its conditions compiled
+type ignoreRule struct {
+	conditions
+	stripFiles bool
+}
+
+// compileRules compiles the conditions of ignore rules, skipping invalid
+// rules (config validate reports them)
+func compileRules(rules []config.IgnoreRule) []ignoreRule {
+	compiled := make([]ignoreRule, 0, len(rules))
+	for _, r := range rules {
+		title, err := r.TitleRegexp()
+		if err != nil {
+			continue
+		}
+		compiled = append(compiled, ignoreRule{
+			conditions: conditions{
+				title:       title,
+				category:    r.Category,
+				severity:    r.Severity,
+				rule:        r.Rule,
+				files:       r.Files,
+				fingerprint: r.Fingerprint,
+			},
+			stripFiles: r.Strip,
+		})
+	}
+	return compiled
+}
+
+// strip returns a copy of a finding without the files, and their code
+// snippets, that match the rule
+func (r ignoreRule) strip(f Finding) Finding {
+	files := make([]string, 0, len(f.Files))
+	for _, file := range f.Files {
+		if !config.MatchGlobs(r.files, file) {
+			files = append(files, file)
+		}
+	}
+	var snippets []CodeSnippet
+	for _, snippet := range f.CodeSnippets {
+		if snippet.File == "" || !config.MatchGlobs(r.files, snippet.File) {
+			snippets = append(snippets, snippet)
+		}
+	}
+	f.Files, f.CodeSnippets = files, snippets
+	return f
+}
+
+// applyRules applies ignore rules to a finding, returning it without the
+// files stripped by rules and whether a rule ignores it. Fingerprints are
+// matched against the finding as reported, before any files are stripped.
+func applyRules(f Finding, rules []ignoreRule) (Finding, bool) {
+	if len(rules) == 0 {
+		return f, false
+	}
+	fingerprint := Fingerprint(f)
+	for _, r := range rules {
+		if !r.matches(f, fingerprint) {
+			continue
+		}
+		if !r.stripFiles {
+			return f, true
+		}
+		// A finding with no files left has no location to report
+		f = r.strip(f)
+		if len(f.Files) == 0 {
+			return f, true
+		}
+	}
+	return f, false
+}
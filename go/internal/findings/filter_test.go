@@ -1,6 +1,7 @@
 package findings
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/liam-witterick/autoengineer/go/internal/config"
@@ -62,6 +63,34 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestFilterWith(t *testing.T) {
+	findings := []Finding{
+		{Title: "Open security group", Files: []string{"services/api/main.tf"}, Project: "api"},
+		{Title: "Open security group", Files: []string{"services/web/main.tf"}, Project: "web"},
+		{Title: "Unpinned action", Files: []string{".github/workflows/ci.yml"}},
+	}
+
+	root := &config.IgnoreConfig{}
+	byProject := map[string]*config.IgnoreConfig{
+		"api": {Accepted: []config.AcceptedItem{{Title: "Open security group"}}},
+		"web": {},
+	}
+
+	filtered, ignored := FilterWith(findings, func(f Finding) *config.IgnoreConfig {
+		if cfg, ok := byProject[f.Project]; ok {
+			return cfg
+		}
+		return root
+	})
+
+	if ignored != 1 || len(filtered) != 2 {
+		t.Fatalf("expected 1 ignored and 2 kept, got %d ignored and %d kept", ignored, len(filtered))
+	}
+	if filtered[0].Project != "web" {
+		t.Errorf("the accepted finding should only be ignored in its own project, kept %+v", filtered[0])
+	}
+}
+
 func TestShouldIgnore(t *testing.T) {
 	cfg := &config.IgnoreConfig{
 		Accepted: []config.AcceptedItem{
@@ -207,14 +236,44 @@ func TestFilterBySeverity(t *testing.T) {
 	}
 }
 
+func TestFilterBySeverityCriticalAndInfo(t *testing.T) {
+	all := []Finding{
+		{Title: "a", Severity: SeverityInfo},
+		{Title: "b", Severity: SeverityCritical},
+		{Title: "c", Severity: SeverityLow},
+		{Title: "d", Severity: SeverityHigh},
+	}
+
+	tests := []struct {
+		minSeverity string
+		want        []string
+	}{
+		{SeverityInfo, []string{"a", "b", "c", "d"}},
+		{SeverityLow, []string{"b", "c", "d"}},
+		{SeverityHigh, []string{"b", "d"}},
+		{SeverityCritical, []string{"b"}},
+	}
+	for _, tt := range tests {
+		var titles []string
+		for _, f := range FilterBySeverity(all, tt.minSeverity) {
+			titles = append(titles, f.Title)
+		}
+		if !reflect.DeepEqual(titles, tt.want) {
+			t.Errorf("FilterBySeverity(%s) = %v, want %v", tt.minSeverity, titles, tt.want)
+		}
+	}
+}
+
 func TestValidateSeverity(t *testing.T) {
 	tests := []struct {
 		severity string
 		expected bool
 	}{
+		{SeverityCritical, true},
 		{SeverityHigh, true},
 		{SeverityMedium, true},
 		{SeverityLow, true},
+		{SeverityInfo, true},
 		{"invalid", false},
 		{"", false},
 		{"HIGH", false}, // case-sensitive
@@ -229,3 +288,77 @@ func TestValidateSeverity(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterIgnoresSnippetFiles(t *testing.T) {
+	findings := []Finding{
+		{
+			Title:        "Hardcoded credentials",
+			Severity:     SeverityHigh,
+			Files:        []string{"main.tf"},
+			CodeSnippets: []CodeSnippet{{File: "modules/vpc/examples/basic/main.tf", Code: "password = \"x\""}},
+		},
+		{
+			Title:        "Open security group",
+			Severity:     SeverityHigh,
+			CodeSnippets: []CodeSnippet{{File: "modules/vpc/examples/keep/main.tf", Code: "0.0.0.0/0"}},
+		},
+	}
+	cfg := &config.IgnoreConfig{IgnorePaths: []string{"modules/**/examples/*", "!**/examples/keep/"}}
+
+	filtered, ignoredCount := Filter(findings, cfg)
+	if ignoredCount != 1 || len(filtered) != 1 || filtered[0].Title != "Open security group" {
+		t.Errorf("expected only the finding with an ignored snippet file to be ignored, got %d ignored, %+v", ignoredCount, filtered)
+	}
+}
+
+func TestFilterRules(t *testing.T) {
+	sg := Finding{Title: "Open security group", Category: CategorySecurity, Severity: SeverityHigh, Files: []string{"vpc/main.tf"}, Rule: "CKV_AWS_24"}
+	logs := Finding{Title: "S3 bucket access logging disabled", Category: CategorySecurity, Severity: SeverityLow, Files: []string{"s3/main.tf"}, Rule: "CKV_AWS_18"}
+	ci := Finding{Title: "Unpinned action", Category: CategoryPipeline, Severity: SeverityMedium, Files: []string{".github/workflows/ci.yml"}}
+	multi := Finding{
+		Title:        "Unencrypted volume",
+		Category:     CategoryInfra,
+		Severity:     SeverityMedium,
+		Files:        []string{"examples/basic/main.tf", "ec2/main.tf"},
+		CodeSnippets: []CodeSnippet{{File: "examples/basic/main.tf", Code: "encrypted = false"}, {File: "ec2/main.tf", Code: "encrypted = false"}},
+	}
+	// A snippet without a file does not keep a finding whose files are all stripped
+	examples := Finding{Title: "Public AMI", Category: CategorySecurity, Severity: SeverityLow, Files: []string{"examples/ami/main.tf"}, CodeSnippets: []CodeSnippet{{Code: "owners = [\"all\"]"}}}
+
+	tests := []struct {
+		name  string
+		rule  config.IgnoreRule
+		kept  []string
+		files []string
+	}{
+		{"title regexp", config.IgnoreRule{Title: "^s3 .*logging"}, []string{sg.Title, ci.Title, multi.Title, examples.Title}, nil},
+		{"conditions are combined", config.IgnoreRule{Category: CategorySecurity, Severity: SeverityLow}, []string{sg.Title, ci.Title, multi.Title}, nil},
+		{"scanner rule", config.IgnoreRule{Rule: "ckv_aws_24", Files: []string{"vpc/"}}, []string{logs.Title, ci.Title, multi.Title, examples.Title}, nil},
+		{"fingerprint", config.IgnoreRule{Fingerprint: Fingerprint(ci)}, []string{sg.Title, logs.Title, multi.Title, examples.Title}, nil},
+		{"files drop the whole finding", config.IgnoreRule{Files: []string{"examples/"}}, []string{sg.Title, logs.Title, ci.Title}, nil},
+		{"strip removes only ignored files", config.IgnoreRule{Files: []string{"examples/"}, Strip: true}, []string{sg.Title, logs.Title, ci.Title, multi.Title}, []string{"ec2/main.tf"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Reason = "test"
+			filtered, ignoredCount := Filter([]Finding{sg, logs, ci, multi, examples}, &config.IgnoreConfig{Rules: []config.IgnoreRule{tt.rule}})
+
+			var kept []string
+			for _, f := range filtered {
+				kept = append(kept, f.Title)
+				if f.Title == multi.Title && tt.files != nil {
+					if !reflect.DeepEqual(f.Files, tt.files) || len(f.CodeSnippets) != 1 || f.CodeSnippets[0].File != tt.files[0] {
+						t.Errorf("expected files %v and their snippets to be kept, got %+v", tt.files, f)
+					}
+				}
+			}
+			if !reflect.DeepEqual(kept, tt.kept) || ignoredCount != 5-len(tt.kept) {
+				t.Errorf("kept %v (%d ignored), want %v", kept, ignoredCount, tt.kept)
+			}
+		})
+	}
+
+	if len(multi.Files) != 2 || len(multi.CodeSnippets) != 2 {
+		t.Errorf("stripping should not modify the original finding, got %+v", multi)
+	}
+}
@@ -0,0 +1,41 @@
+package findings
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+)
+
+// Fingerprint identifies a finding across runs. It hashes the category, the
+// normalized words of the title and the files, so it survives changes in
+// severity, description, word order and letter case.
+func Fingerprint(f Finding) string {
+	words := normalizeTokens(tokenize(strings.ToLower(f.Title)))
+	sort.Strings(words)
+	words = uniqueSorted(words)
+
+	files := make([]string, 0, len(f.Files))
+	for _, file := range f.Files {
+		files = append(files, config.NormalizePath(file))
+	}
+	sort.Strings(files)
+	files = uniqueSorted(files)
+
+	key := strings.ToLower(f.Category) + "\n" + strings.Join(words, " ") + "\n" + strings.Join(files, "\n")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// uniqueSorted removes adjacent duplicates from a sorted slice
+func uniqueSorted(values []string) []string {
+	out := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package findings
+
+import "testing"
+
+func TestFingerprint(t *testing.T) {
+	base := Finding{
+		Category: CategorySecurity,
+		Title:    "S3 bucket allows public access",
+		Severity: SeverityHigh,
+		Files:    []string{"infra/s3.tf", "infra/iam.tf"},
+	}
+	fp := Fingerprint(base)
+	if len(fp) != 16 {
+		t.Fatalf("expected a 16 character fingerprint, got %q", fp)
+	}
+
+	same := base
+	same.Title = "s3 Bucket allows public access."
+	same.Severity = SeverityMedium
+	same.Description = "Reworded"
+	same.Files = []string{"./infra/iam.tf", "infra/s3.tf"}
+	if got := Fingerprint(same); got != fp {
+		t.Errorf("case, punctuation, severity and file order should not change the fingerprint: %s != %s", got, fp)
+	}
+
+	// Scanners report paths relative to the scanned directory with a leading /
+	scanner := base
+	scanner.Files = []string{"/infra/s3.tf", "/infra/iam.tf"}
+	if got := Fingerprint(scanner); got != fp {
+		t.Errorf("a leading / should not change the fingerprint: %s != %s", got, fp)
+	}
+
+	for name, f := range map[string]Finding{
+		"category": {Category: CategoryInfra, Title: base.Title, Files: base.Files},
+		"title":    {Category: base.Category, Title: "S3 bucket lacks encryption", Files: base.Files},
+		"files":    {Category: base.Category, Title: base.Title, Files: []string{"infra/s3.tf"}},
+	} {
+		if Fingerprint(f) == fp {
+			t.Errorf("changing the %s should change the fingerprint", name)
+		}
+	}
+}
@@ -13,6 +13,7 @@ func Merge(findingArrays ...[]Finding) []Finding {
 	for _, findings := range findingArrays {
 		all = append(all, findings...)
 	}
+	NormalizePaths(all)
 
 	// Deduplicate by title similarity
 	deduplicated := deduplicate(all)
@@ -257,7 +258,7 @@ func mergeFindings(a, b Finding) Finding {
 	// Use the finding with higher severity as the base
 	base := a
 	other := b
-	if severityValue(b.Severity) < severityValue(a.Severity) {
+	if SeverityRank(b.Severity) < SeverityRank(a.Severity) {
 		base = b
 		other = a
 	}
@@ -316,6 +317,22 @@ func mergeFindings(a, b Finding) Finding {
 		}
 	}
 
+	// Merge tags (deduplicate, base tags first)
+	var mergedTags []string
+	tagSet := make(map[string]bool)
+	for _, tag := range append(append([]string{}, base.Tags...), other.Tags...) {
+		if !tagSet[tag] {
+			tagSet[tag] = true
+			mergedTags = append(mergedTags, tag)
+		}
+	}
+
+	// Keep the scanner rule and source of whichever finding has one
+	rule, source := base.Rule, base.Source
+	if rule == "" {
+		rule, source = other.Rule, other.Source
+	}
+
 	return Finding{
 		Category:       base.Category,
 		Title:          base.Title,
@@ -324,6 +341,9 @@ func mergeFindings(a, b Finding) Finding {
 		Recommendation: mergedRec,
 		Files:          mergedFiles,
 		CodeSnippets:   mergedSnippets,
+		Tags:           mergedTags,
+		Rule:           rule,
+		Source:         source,
 	}
 }
 
@@ -332,33 +352,18 @@ func snippetKey(snippet CodeSnippet) string {
 	return fmt.Sprintf("%s:%d-%d", snippet.File, snippet.StartLine, snippet.EndLine)
 }
 
-// severityValue returns a numeric value for severity (lower is more severe)
-func severityValue(severity string) int {
-	switch severity {
-	case SeverityHigh:
-		return 0
-	case SeverityMedium:
-		return 1
-	case SeverityLow:
-		return 2
-	default:
-		return 3
-	}
-}
-
 // CountBySeverity returns counts of findings by severity level
-func CountBySeverity(findings []Finding) (high, medium, low int) {
+func CountBySeverity(findings []Finding) map[string]int {
+	counts := make(map[string]int, len(Severities))
+	for _, s := range Severities {
+		counts[s] = 0
+	}
 	for _, f := range findings {
-		switch f.Severity {
-		case SeverityHigh:
-			high++
-		case SeverityMedium:
-			medium++
-		case SeverityLow:
-			low++
+		if _, ok := counts[f.Severity]; ok {
+			counts[f.Severity]++
 		}
 	}
-	return
+	return counts
 }
 
 // CountByCategory returns counts of findings by category
@@ -1,7 +1,10 @@
 package findings
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
 )
 
 func TestMerge(t *testing.T) {
@@ -50,6 +53,35 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestMergeNormalizesPaths(t *testing.T) {
+	scanner := []Finding{{
+		Title:        "Ingress from 0.0.0.0/0 to port 80",
+		Category:     CategorySecurity,
+		Severity:     SeverityMedium,
+		Files:        []string{"/modules/sg/main.tf"},
+		CodeSnippets: []CodeSnippet{{File: "/modules/sg/main.tf", StartLine: 3, Code: "ingress {"}},
+		Rule:         "CKV_AWS_260",
+		Source:       "checkov",
+	}}
+	llm := Finding{Title: scanner[0].Title, Category: CategorySecurity, Files: []string{"modules/sg/main.tf"}}
+
+	merged := Merge(scanner)
+	if !reflect.DeepEqual(merged[0].Files, []string{"modules/sg/main.tf"}) || merged[0].CodeSnippets[0].File != "modules/sg/main.tf" {
+		t.Errorf("expected repo-relative paths, got %v and %q", merged[0].Files, merged[0].CodeSnippets[0].File)
+	}
+	if scanner[0].Files[0] != "/modules/sg/main.tf" {
+		t.Errorf("Merge should not modify its input, got %v", scanner[0].Files)
+	}
+	if Fingerprint(merged[0]) != Fingerprint(llm) {
+		t.Error("expected the scanner finding to have the fingerprint of the same LLM finding")
+	}
+
+	rules := &config.IgnoreConfig{Rules: []config.IgnoreRule{{Rule: "CKV_AWS_260", Files: []string{"modules/sg/"}, Reason: "test"}}}
+	if filtered, ignored := Filter(merged, rules); len(filtered) != 0 || ignored != 1 {
+		t.Errorf("expected the rule's files to match the scanner finding, kept %+v", filtered)
+	}
+}
+
 func TestDeduplicate(t *testing.T) {
 	findings := []Finding{
 		{Title: "Security issue in production environment configuration is insecure", Category: CategorySecurity},
@@ -71,18 +103,15 @@ func TestCountBySeverity(t *testing.T) {
 		{Severity: SeverityHigh},
 		{Severity: SeverityMedium},
 		{Severity: SeverityLow},
+		{Severity: SeverityCritical},
+		{Severity: "unknown"},
 	}
 
-	high, medium, low := CountBySeverity(findings)
+	counts := CountBySeverity(findings)
 
-	if high != 2 {
-		t.Errorf("expected 2 high severity, got %d", high)
-	}
-	if medium != 1 {
-		t.Errorf("expected 1 medium severity, got %d", medium)
-	}
-	if low != 1 {
-		t.Errorf("expected 1 low severity, got %d", low)
+	want := map[string]int{SeverityCritical: 1, SeverityHigh: 2, SeverityMedium: 1, SeverityLow: 1, SeverityInfo: 0}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("expected %v, got %v", want, counts)
 	}
 }
 
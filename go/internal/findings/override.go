@@ -0,0 +1,54 @@
+package findings
+
+import "github.com/liam-witterick/autoengineer/go/internal/config"
+
+// severityOverride is a severity override with its conditions compiled
+type severityOverride struct {
+	conditions
+	severity string
+}
+
+// ApplySeverityOverrides sets the severity of each finding from the last
+// override it matches, keeping the severity it was reported with in
+// OriginalSeverity. Findings loaded from a file are overridden from their
+// original severity again. It returns the number of findings changed.
+func ApplySeverityOverrides(all []Finding, overrides []config.SeverityOverride) int {
+	compiled := make([]severityOverride, 0, len(overrides))
+	for _, o := range overrides {
+		title, err := o.TitleRegexp()
+		if err != nil {
+			continue
+		}
+		compiled = append(compiled, severityOverride{
+			conditions: conditions{title: title, category: o.Category, rule: o.Rule, files: o.Files},
+			severity:   o.Severity,
+		})
+	}
+
+	changed := 0
+	for i := range all {
+		f := &all[i]
+		reported := f.Severity
+		if f.OriginalSeverity != "" {
+			reported = f.OriginalSeverity
+		}
+
+		severity := reported
+		for _, o := range compiled {
+			if o.matches(*f, "") {
+				severity = o.severity
+			}
+		}
+		if severity == f.Severity {
+			continue
+		}
+
+		f.Severity = severity
+		f.OriginalSeverity = ""
+		if severity != reported {
+			f.OriginalSeverity = reported
+		}
+		changed++
+	}
+	return changed
+}
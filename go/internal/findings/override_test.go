@@ -0,0 +1,45 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+)
+
+func TestApplySeverityOverrides(t *testing.T) {
+	all := []Finding{
+		{Title: "Missing resource tags", Category: CategoryInfra, Severity: SeverityLow, Files: []string{"accounts/regulated/main.tf"}},
+		{Title: "Missing resource tags", Category: CategoryInfra, Severity: SeverityLow, Files: []string{"accounts/sandbox/main.tf"}},
+		{Title: "S3 bucket logging disabled", Category: CategorySecurity, Severity: SeverityHigh, Rule: "CKV_AWS_18"},
+		{Title: "Unpinned action", Category: CategoryPipeline, Severity: SeverityMedium},
+	}
+	overrides := []config.SeverityOverride{
+		{Title: "missing .*tags", Severity: SeverityMedium},
+		{Title: "missing .*tags", Files: []string{"accounts/regulated/"}, Severity: SeverityHigh},
+		{Rule: "ckv_aws_18", Severity: SeverityLow},
+		{Category: CategoryPipeline, Severity: SeverityMedium},
+	}
+
+	if changed := ApplySeverityOverrides(all, overrides); changed != 3 {
+		t.Errorf("expected 3 findings to change, got %d", changed)
+	}
+	want := []struct{ severity, original string }{
+		{SeverityHigh, SeverityLow},
+		{SeverityMedium, SeverityLow},
+		{SeverityLow, SeverityHigh},
+		{SeverityMedium, ""},
+	}
+	for i, w := range want {
+		if all[i].Severity != w.severity || all[i].OriginalSeverity != w.original {
+			t.Errorf("finding %d: severity %s (original %q), want %s (original %q)", i, all[i].Severity, all[i].OriginalSeverity, w.severity, w.original)
+		}
+	}
+
+	// Findings loaded again are overridden from their original severity
+	if changed := ApplySeverityOverrides(all, overrides[2:]); changed != 2 {
+		t.Errorf("expected the findings no longer overridden to change back, got %d changes", changed)
+	}
+	if all[0].Severity != SeverityLow || all[0].OriginalSeverity != "" || all[2].Severity != SeverityLow {
+		t.Errorf("unexpected findings after removing overrides: %+v", all)
+	}
+}
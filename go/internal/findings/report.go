@@ -0,0 +1,44 @@
+package findings
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// WriteMarkdown writes findings as a markdown report, most severe first
+func WriteMarkdown(w io.Writer, all []Finding) {
+	sorted := append([]Finding(nil), all...)
+	sort.Stable(BySeverity(sorted))
+
+	severities := CountBySeverity(sorted)
+	fmt.Fprintln(w, "## AutoEngineer findings")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "🚨 **%d critical**, 🔴 **%d high**, 🟡 **%d medium**, 🟢 **%d low**, 🔵 **%d info** (%d total)\n",
+		severities[SeverityCritical], severities[SeverityHigh], severities[SeverityMedium], severities[SeverityLow], severities[SeverityInfo], len(sorted))
+	if len(sorted) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n| Severity | Category | Finding | Files |\n|----------|----------|---------|-------|\n")
+	for _, f := range sorted {
+		title := f.Title
+		if f.Baseline == BaselineNew {
+			title += " 🆕"
+		}
+		fmt.Fprintf(w, "| %s %s | %s | %s | %s |\n", SeverityEmoji(f.Severity), f.Severity, f.Category, markdownCell(title), markdownCell(joinFiles(f.Files)))
+	}
+
+	for _, f := range sorted {
+		if f.Description == "" && f.Recommendation == "" {
+			continue
+		}
+		fmt.Fprintf(w, "\n### %s %s\n", SeverityEmoji(f.Severity), f.Title)
+		if f.Description != "" {
+			fmt.Fprintf(w, "\n%s\n", f.Description)
+		}
+		if f.Recommendation != "" {
+			fmt.Fprintf(w, "\n**Recommendation:** %s\n", f.Recommendation)
+		}
+	}
+}
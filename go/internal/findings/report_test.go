@@ -0,0 +1,32 @@
+package findings
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	WriteMarkdown(&buf, []Finding{
+		{Title: "Missing tags", Category: CategoryInfra, Severity: SeverityLow, Files: []string{"vpc.tf"}},
+		{Title: "Public | bucket", Category: CategorySecurity, Severity: SeverityHigh, Files: []string{"s3.tf"},
+			Description: "Anyone can read it", Recommendation: "Block public access", Baseline: BaselineNew},
+	})
+	out := buf.String()
+
+	if !strings.Contains(out, "🚨 **0 critical**, 🔴 **1 high**, 🟡 **0 medium**, 🟢 **1 low**, 🔵 **0 info** (2 total)") {
+		t.Errorf("missing summary:\n%s", out)
+	}
+	high := strings.Index(out, `| 🔴 high | security | Public \| bucket 🆕 | s3.tf |`)
+	low := strings.Index(out, "| 🟢 low | infra | Missing tags | vpc.tf |")
+	if high < 0 || low < 0 || high > low {
+		t.Errorf("expected escaped rows, most severe first:\n%s", out)
+	}
+	if !strings.Contains(out, "**Recommendation:** Block public access") {
+		t.Errorf("missing details:\n%s", out)
+	}
+	if strings.Contains(out, "### 🟢 Missing tags") {
+		t.Error("findings without a description or recommendation should have no details section")
+	}
+}
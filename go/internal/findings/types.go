@@ -1,6 +1,11 @@
 package findings
 
-import "time"
+import (
+	"time"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/severity"
+)
 
 // CodeSnippet represents a piece of code related to a finding
 type CodeSnippet struct {
@@ -19,15 +24,43 @@ type Finding struct {
 	Recommendation string        `json:"recommendation"`
 	Files          []string      `json:"files"`
 	CodeSnippets   []CodeSnippet `json:"code_snippets,omitempty"`
+	Tags           []string      `json:"tags,omitempty"`
+
+	// Rule is the ID of the scanner check that reported the finding
+	Rule string `json:"rule,omitempty"`
+
+	// Source is the scanner that reported the finding (empty for LLM findings)
+	Source string `json:"source,omitempty"`
+
+	// OriginalSeverity is the severity the finding was reported with, when
+	// a severity override changed it
+	OriginalSeverity string `json:"original_severity,omitempty"`
+
+	// Project is the nested autoengineer.yaml project owning the finding's files
+	Project string `json:"project,omitempty"`
+
+	// Baseline is "new" or "existing" when the run was compared to a baseline
+	Baseline string `json:"baseline,omitempty"`
 }
 
-// Severity levels
+// Severity levels, defined by the severity package shared with the configuration
 const (
-	SeverityHigh   = "high"
-	SeverityMedium = "medium"
-	SeverityLow    = "low"
+	SeverityCritical = severity.Critical
+	SeverityHigh     = severity.High
+	SeverityMedium   = severity.Medium
+	SeverityLow      = severity.Low
+	SeverityInfo     = severity.Info
 )
 
+// Severities lists the severity levels from most to least severe
+var Severities = severity.Levels
+
+// SeverityRank returns the position of a severity in Severities (lower is
+// more severe). Unknown severities rank after info.
+func SeverityRank(s string) int {
+	return severity.Rank(s)
+}
+
 // Categories
 const (
 	CategorySecurity = "security"
@@ -35,6 +68,64 @@ const (
 	CategoryInfra    = "infra"
 )
 
+// Baseline statuses
+const (
+	BaselineNew      = "new"
+	BaselineExisting = "existing"
+)
+
+// Tags
+const (
+	// TagUnverified marks a finding whose files or code snippets could not be grounded in the working tree
+	TagUnverified = "unverified"
+
+	// TagAcceptanceExpired marks a finding reported again because its accepted risk expired
+	TagAcceptanceExpired = "acceptance expired"
+)
+
+// HasTag reports whether the finding carries the given tag
+func (f Finding) HasTag(tag string) bool {
+	for _, t := range f.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag adds a tag to the finding if it is not already present
+func (f *Finding) AddTag(tag string) {
+	if !f.HasTag(tag) {
+		f.Tags = append(f.Tags, tag)
+	}
+}
+
+// NormalizePaths rewrites the files of findings, and of their code snippets,
+// in repo-relative form, so scanner and LLM findings for the same file get
+// the same fingerprint and match the same globs and project directories.
+// The file lists are copied, so findings sharing them are left alone.
+func NormalizePaths(all []Finding) {
+	for i := range all {
+		if all[i].Files != nil {
+			files := make([]string, len(all[i].Files))
+			for j, file := range all[i].Files {
+				files[j] = config.NormalizePath(file)
+			}
+			all[i].Files = files
+		}
+		if all[i].CodeSnippets != nil {
+			snippets := make([]CodeSnippet, len(all[i].CodeSnippets))
+			for j, snippet := range all[i].CodeSnippets {
+				if snippet.File != "" {
+					snippet.File = config.NormalizePath(snippet.File)
+				}
+				snippets[j] = snippet
+			}
+			all[i].CodeSnippets = snippets
+		}
+	}
+}
+
 // AcceptedFinding represents an accepted risk in the ignore config
 type AcceptedFinding struct {
 	Title        string    `yaml:"title"`
@@ -49,10 +140,5 @@ type BySeverity []Finding
 func (a BySeverity) Len() int      { return len(a) }
 func (a BySeverity) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a BySeverity) Less(i, j int) bool {
-	severityOrder := map[string]int{
-		SeverityHigh:   0,
-		SeverityMedium: 1,
-		SeverityLow:    2,
-	}
-	return severityOrder[a[i].Severity] < severityOrder[a[j].Severity]
+	return SeverityRank(a[i].Severity) < SeverityRank(a[j].Severity)
 }
@@ -148,3 +148,34 @@ func TestCodeSnippetOmitEmptyFields(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisterCategory(t *testing.T) {
+	RegisterCategory(CategoryInfo{Name: "cost", Title: "Cost", Emoji: "💰"})
+
+	if got := CategoryEmoji("cost"); got != "💰" {
+		t.Errorf("CategoryEmoji(cost) = %q, want 💰", got)
+	}
+
+	// Custom scopes reusing a known category don't change how it is displayed
+	RegisterCategory(CategoryInfo{Name: CategorySecurity, Title: "Secrets", Emoji: "🔑"})
+	RegisterCategory(CategoryInfo{Name: "cost", Title: "Spend", Emoji: "💸"})
+	for _, info := range Categories() {
+		if info.Name == CategorySecurity && (info.Title != "Security" || info.Emoji != "🔒") {
+			t.Errorf("built-in security category changed to %+v", info)
+		}
+		if info.Name == "cost" && (info.Title != "Cost" || info.Emoji != "💰") {
+			t.Errorf("registered cost category changed to %+v", info)
+		}
+	}
+	if got := CategoryEmoji("unknown"); got != defaultCategoryEmoji {
+		t.Errorf("CategoryEmoji(unknown) = %q, want %q", got, defaultCategoryEmoji)
+	}
+	if IsBuiltinCategory("cost") || !IsBuiltinCategory(CategoryInfra) {
+		t.Error("IsBuiltinCategory() should only accept built-in categories")
+	}
+
+	counts := CountByCategoryName([]Finding{{Category: "cost"}, {Category: "cost"}, {Category: CategoryInfra}})
+	if counts["cost"] != 2 || counts[CategoryInfra] != 1 {
+		t.Errorf("CountByCategoryName() = %v", counts)
+	}
+}
@@ -0,0 +1,134 @@
+// Package gate decides whether a run should fail a CI check and maps the
+// outcome to a process exit code.
+package gate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/scanner"
+)
+
+// Exit codes. Findings over the threshold take precedence over a partial
+// scanner failure, so a blocked merge is never reported as a flaky scanner.
+const (
+	// ExitOK means the run completed and nothing crossed the threshold
+	ExitOK = 0
+
+	// ExitToolError means the tool itself failed (bad flags, config, analysis error)
+	ExitToolError = 1
+
+	// ExitFindings means findings at or above --fail-on (new ones only with --fail-on-new)
+	ExitFindings = 2
+
+	// ExitScannerFailed means the run completed but at least one scanner failed
+	ExitScannerFailed = 3
+)
+
+// Policy is the CI gate selected by --fail-on and --fail-on-new
+type Policy struct {
+	// FailOn is the minimum severity that fails the run ("" disables it)
+	FailOn string
+
+	// FailOnNew only counts findings that are not already known
+	FailOnNew bool
+}
+
+// Enabled reports whether the run is gated at all
+func (p Policy) Enabled() bool {
+	return p.FailOn != "" || p.FailOnNew
+}
+
+// threshold returns the minimum failing severity; --fail-on-new alone fails on any new finding
+func (p Policy) threshold() string {
+	if p.FailOn == "" {
+		return findings.SeverityInfo
+	}
+	return p.FailOn
+}
+
+// Validate checks the --fail-on severity
+func (p Policy) Validate() error {
+	if p.FailOn != "" && !findings.ValidateSeverity(p.FailOn) {
+		return fmt.Errorf("invalid --fail-on: %s (must be info, low, medium, high, or critical)", p.FailOn)
+	}
+	return nil
+}
+
+// Result is the outcome of evaluating a policy against a run
+type Result struct {
+	Policy Policy
+
+	// Failing are the findings that crossed the threshold
+	Failing []findings.Finding
+
+	// FailedScanners are the scanners that errored during the run
+	FailedScanners []string
+}
+
+// Evaluate applies the policy to the run's findings and scanner statuses.
+// known reports whether a finding is already tracked; it is only consulted
+// with FailOnNew and may be nil.
+func Evaluate(p Policy, all []findings.Finding, known func(findings.Finding) bool, statuses []scanner.ScannerStatus) Result {
+	result := Result{Policy: p}
+	if !p.Enabled() {
+		return result
+	}
+
+	for _, f := range findings.FilterBySeverity(all, p.threshold()) {
+		if p.FailOnNew && known != nil && known(f) {
+			continue
+		}
+		result.Failing = append(result.Failing, f)
+	}
+
+	for _, status := range statuses {
+		if status.Error != nil {
+			result.FailedScanners = append(result.FailedScanners, status.Name)
+		}
+	}
+	return result
+}
+
+// Code returns the exit code for the result
+func (r Result) Code() int {
+	switch {
+	case len(r.Failing) > 0:
+		return ExitFindings
+	case len(r.FailedScanners) > 0:
+		return ExitScannerFailed
+	default:
+		return ExitOK
+	}
+}
+
+// Err returns an *ExitError describing a failing result, or nil
+func (r Result) Err() error {
+	switch r.Code() {
+	case ExitFindings:
+		which := "finding(s)"
+		if r.Policy.FailOnNew {
+			which = "new finding(s)"
+		}
+		return &ExitError{Code: ExitFindings, Err: fmt.Errorf("%d %s at or above %s severity", len(r.Failing), which, r.Policy.threshold())}
+	case ExitScannerFailed:
+		return &ExitError{Code: ExitScannerFailed, Err: fmt.Errorf("scanner(s) failed: %s", strings.Join(r.FailedScanners, ", "))}
+	default:
+		return nil
+	}
+}
+
+// ExitError is an error that exits the process with a specific code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
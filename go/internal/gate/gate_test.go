@@ -0,0 +1,73 @@
+package gate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/scanner"
+)
+
+func TestEvaluate(t *testing.T) {
+	all := []findings.Finding{
+		{Title: "Public S3 bucket", Severity: findings.SeverityHigh},
+		{Title: "Unencrypted volume", Severity: findings.SeverityHigh},
+		{Title: "Unpinned action", Severity: findings.SeverityMedium},
+		{Title: "Missing tags", Severity: findings.SeverityLow},
+	}
+	known := func(f findings.Finding) bool { return f.Title == "Public S3 bucket" }
+	failedTrivy := []scanner.ScannerStatus{{Name: "checkov", Ran: true}, {Name: "trivy", Error: fmt.Errorf("boom")}}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		statuses []scanner.ScannerStatus
+		failing  int
+		code     int
+	}{
+		{"disabled", Policy{}, failedTrivy, 0, ExitOK},
+		{"fail on high", Policy{FailOn: findings.SeverityHigh}, nil, 2, ExitFindings},
+		{"fail on medium", Policy{FailOn: findings.SeverityMedium}, nil, 3, ExitFindings},
+		{"fail on new high", Policy{FailOn: findings.SeverityHigh, FailOnNew: true}, nil, 1, ExitFindings},
+		{"fail on any new", Policy{FailOnNew: true}, nil, 3, ExitFindings},
+		{"findings beat scanner failure", Policy{FailOn: findings.SeverityHigh}, failedTrivy, 2, ExitFindings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Evaluate(tt.policy, all, known, tt.statuses)
+			if len(result.Failing) != tt.failing {
+				t.Errorf("expected %d failing finding(s), got %d", tt.failing, len(result.Failing))
+			}
+			if result.Code() != tt.code {
+				t.Errorf("Code() = %d, want %d", result.Code(), tt.code)
+			}
+		})
+	}
+}
+
+func TestEvaluateScannerFailure(t *testing.T) {
+	statuses := []scanner.ScannerStatus{{Name: "checkov", Ran: true}, {Name: "trivy", Error: fmt.Errorf("boom")}}
+	result := Evaluate(Policy{FailOn: findings.SeverityHigh}, []findings.Finding{{Title: "Unpinned action", Severity: findings.SeverityMedium}}, nil, statuses)
+
+	if result.Code() != ExitScannerFailed {
+		t.Fatalf("Code() = %d, want %d", result.Code(), ExitScannerFailed)
+	}
+	var exitErr *ExitError
+	if err := result.Err(); !errors.As(err, &exitErr) || exitErr.Code != ExitScannerFailed {
+		t.Errorf("Err() = %v, want an ExitError with code %d", err, ExitScannerFailed)
+	}
+	if err := (Result{}).Err(); err != nil {
+		t.Errorf("passing result should have no error, got %v", err)
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	if err := (Policy{FailOn: "urgent"}).Validate(); err == nil {
+		t.Error("expected an invalid --fail-on to be rejected")
+	}
+	if err := (Policy{FailOn: findings.SeverityMedium}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
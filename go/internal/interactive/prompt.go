@@ -46,6 +46,11 @@ func NewSession(findings []findings.Finding, owner, repo, label string) (*Intera
 		return nil, fmt.Errorf("failed to create issues client: %w", err)
 	}
 
+	return NewSessionWithClient(findings, owner, repo, label, client), nil
+}
+
+// NewSessionWithClient creates an interactive session that uses an existing issues client
+func NewSessionWithClient(findings []findings.Finding, owner, repo, label string, client *issues.Client) *InteractiveSession {
 	return &InteractiveSession{
 		findings:      findings,
 		owner:         owner,
@@ -54,7 +59,7 @@ func NewSession(findings []findings.Finding, owner, repo, label string) (*Intera
 		issuesClient:  client,
 		copilotClient: copilot.NewClient(),
 		reader:        bufio.NewReader(os.Stdin),
-	}, nil
+	}
 }
 
 // Run starts the interactive prompt loop
@@ -144,7 +149,7 @@ func (s *InteractiveSession) displayAllItems(allItems []ActionableItem) {
 				emoji := findings.SeverityEmoji(item.Finding.Severity)
 				files := strings.Join(item.Finding.Files, ", ")
 				
-				fmt.Printf("%d. %s %s\n", i+1, emoji, item.Finding.Title)
+				fmt.Printf("%d. %s %s%s\n", i+1, emoji, item.Finding.Title, findings.FormatTags(item.Finding.Tags))
 				
 				if files != "" {
 					fmt.Printf("   Files: %s\n", files)
@@ -131,6 +131,11 @@ func TestSeverityEmoji(t *testing.T) {
 		severity string
 		want     string
 	}{
+		{
+			name:     "critical severity",
+			severity: findings.SeverityCritical,
+			want:     "🚨",
+		},
 		{
 			name:     "high severity",
 			severity: findings.SeverityHigh,
@@ -146,6 +151,11 @@ func TestSeverityEmoji(t *testing.T) {
 			severity: findings.SeverityLow,
 			want:     "🟢",
 		},
+		{
+			name:     "info severity",
+			severity: findings.SeverityInfo,
+			want:     "🔵",
+		},
 		{
 			name:     "unknown severity",
 			severity: "unknown",
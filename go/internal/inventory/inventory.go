@@ -0,0 +1,319 @@
+// Package inventory detects which infrastructure and pipeline technologies a
+// repository uses, so analysis scopes and scanners with nothing to look at can be skipped.
+package inventory
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Technologies
+const (
+	Terraform      = "terraform"
+	Helm           = "helm"
+	Kubernetes     = "kubernetes"
+	Dockerfile     = "dockerfile"
+	CloudFormation = "cloudformation"
+	GitHubActions  = "github_actions"
+	Compose        = "compose"
+	Ansible        = "ansible"
+)
+
+// All lists every detected technology in display order
+var All = []string{Terraform, Helm, Kubernetes, Dockerfile, CloudFormation, GitHubActions, Compose, Ansible}
+
+// titles are the display names of technologies
+var titles = map[string]string{
+	Terraform:      "Terraform",
+	Helm:           "Helm",
+	Kubernetes:     "Kubernetes",
+	Dockerfile:     "Dockerfiles",
+	CloudFormation: "CloudFormation",
+	GitHubActions:  "GitHub Actions",
+	Compose:        "Docker Compose",
+	Ansible:        "Ansible",
+}
+
+// Title returns the display name of a technology
+func Title(tech string) string {
+	if title, ok := titles[tech]; ok {
+		return title
+	}
+	return tech
+}
+
+// maxSniffBytes limits how much of a YAML/JSON file is read to classify it
+const maxSniffBytes = 64 * 1024
+
+var (
+	k8sKindPattern       = regexp.MustCompile(`(?m)^kind:\s*\S`)
+	k8sAPIVersionPattern = regexp.MustCompile(`(?m)^apiVersion:\s*\S`)
+	cfnPattern           = regexp.MustCompile(`AWSTemplateFormatVersion|"?Type"?\s*:\s*"?AWS::`)
+	ansiblePlayPattern   = regexp.MustCompile(`(?m)^-\s+(?:name:.*\n\s+)?hosts:`)
+)
+
+// Entry is a detected technology and the files that use it
+type Entry struct {
+	Technology string   `json:"technology"`
+	Files      []string `json:"files"`
+}
+
+// Count returns the number of files using the technology
+func (e Entry) Count() int {
+	return len(e.Files)
+}
+
+// Inventory lists the technologies present in a repository
+type Inventory struct {
+	// Entries are the detected technologies in display order
+	Entries []Entry `json:"entries"`
+
+	// TotalFiles is the number of files inspected
+	TotalFiles int `json:"total_files"`
+}
+
+// Has reports whether a technology was detected
+func (inv Inventory) Has(tech string) bool {
+	return inv.Count(tech) > 0
+}
+
+// Count returns the number of files using a technology
+func (inv Inventory) Count(tech string) int {
+	for _, e := range inv.Entries {
+		if e.Technology == tech {
+			return e.Count()
+		}
+	}
+	return 0
+}
+
+// HasAny reports whether any of the technologies was detected
+func (inv Inventory) HasAny(techs ...string) bool {
+	for _, tech := range techs {
+		if inv.Has(tech) {
+			return true
+		}
+	}
+	return false
+}
+
+// Technologies returns the detected technologies in display order
+func (inv Inventory) Technologies() []string {
+	techs := make([]string, 0, len(inv.Entries))
+	for _, e := range inv.Entries {
+		techs = append(techs, e.Technology)
+	}
+	return techs
+}
+
+// Subset returns the inventory restricted to files, e.g. those of one shard
+func (inv Inventory) Subset(files []string) Inventory {
+	in := make(map[string]bool, len(files))
+	for _, file := range files {
+		in[file] = true
+	}
+
+	sub := Inventory{TotalFiles: len(files)}
+	for _, e := range inv.Entries {
+		var matched []string
+		for _, file := range e.Files {
+			if in[file] {
+				matched = append(matched, file)
+			}
+		}
+		if len(matched) > 0 {
+			sub.Entries = append(sub.Entries, Entry{Technology: e.Technology, Files: matched})
+		}
+	}
+	return sub
+}
+
+// Summary renders the inventory on one line, e.g. "Terraform (12 files), GitHub Actions (3 files)"
+func (inv Inventory) Summary() string {
+	parts := make([]string, 0, len(inv.Entries))
+	for _, e := range inv.Entries {
+		parts = append(parts, fmt.Sprintf("%s (%d %s)", Title(e.Technology), e.Count(), plural(e.Count(), "file", "files")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// Detect classifies files (slash-separated, relative to root) by technology.
+// YAML and JSON files are partially read from root to tell Kubernetes
+// manifests, CloudFormation templates and Ansible playbooks apart.
+func Detect(root string, files []string) Inventory {
+	byTech := make(map[string][]string)
+
+	// Files inside a Helm chart belong to the chart, not to plain Kubernetes
+	chartDirs := make(map[string]bool)
+	for _, file := range files {
+		if path.Base(file) == "Chart.yaml" {
+			chartDirs[path.Dir(file)] = true
+		}
+	}
+
+	for _, file := range files {
+		if tech := classify(root, file, chartDirs); tech != "" {
+			byTech[tech] = append(byTech[tech], file)
+		}
+	}
+
+	inv := Inventory{TotalFiles: len(files)}
+	for _, tech := range All {
+		if matched := byTech[tech]; len(matched) > 0 {
+			inv.Entries = append(inv.Entries, Entry{Technology: tech, Files: matched})
+		}
+	}
+	return inv
+}
+
+// classify returns the technology of a single file, or "" if it has none
+func classify(root, file string, chartDirs map[string]bool) string {
+	base := path.Base(file)
+	lowerBase := strings.ToLower(base)
+	ext := path.Ext(lowerBase)
+
+	switch {
+	case ext == ".tf" || strings.HasSuffix(lowerBase, ".tf.json") || ext == ".tfvars":
+		return Terraform
+	case base == "Dockerfile" || base == "Containerfile" || strings.HasPrefix(base, "Dockerfile.") || ext == ".dockerfile":
+		return Dockerfile
+	case inChart(file, chartDirs) && (ext == ".yaml" || ext == ".yml" || ext == ".tpl" || ext == ".json"):
+		return Helm
+	case lowerBase == "ansible.cfg":
+		return Ansible
+	}
+
+	if ext != ".yml" && ext != ".yaml" && ext != ".json" && ext != ".template" {
+		return ""
+	}
+
+	isYAML := ext == ".yml" || ext == ".yaml"
+	switch {
+	case isYAML && strings.HasPrefix(file, ".github/workflows/"):
+		return GitHubActions
+	case isYAML && (strings.TrimSuffix(lowerBase, ext) == "action") && strings.HasPrefix(file, ".github/"):
+		return GitHubActions
+	case isYAML && isComposeFile(lowerBase, ext):
+		return Compose
+	case isYAML && isAnsibleRolePath(file):
+		return Ansible
+	}
+
+	content := sniff(root, file)
+	if content == nil {
+		return ""
+	}
+
+	switch {
+	case cfnPattern.Match(content):
+		return CloudFormation
+	case isYAML && k8sAPIVersionPattern.Match(content) && k8sKindPattern.Match(content):
+		return Kubernetes
+	case isYAML && ansiblePlayPattern.Match(content):
+		return Ansible
+	}
+	return ""
+}
+
+// inChart reports whether file lives inside a Helm chart directory
+func inChart(file string, chartDirs map[string]bool) bool {
+	for dir := path.Dir(file); ; dir = path.Dir(dir) {
+		if chartDirs[dir] {
+			return true
+		}
+		if dir == "." || dir == "/" {
+			return false
+		}
+	}
+}
+
+// isComposeFile matches docker-compose.yml, compose.yaml, docker-compose.prod.yml and similar
+func isComposeFile(lowerBase, ext string) bool {
+	name := strings.TrimSuffix(lowerBase, ext)
+	return name == "compose" || name == "docker-compose" ||
+		strings.HasPrefix(name, "docker-compose.") || strings.HasPrefix(name, "compose.")
+}
+
+// isAnsibleRolePath matches YAML under roles/<role>/{tasks,handlers,defaults,vars,meta}/
+func isAnsibleRolePath(file string) bool {
+	parts := strings.Split(file, "/")
+	for i := 0; i+2 < len(parts); i++ {
+		if parts[i] != "roles" {
+			continue
+		}
+		switch parts[i+2] {
+		case "tasks", "handlers", "defaults", "vars", "meta":
+			return true
+		}
+	}
+	return false
+}
+
+// sniff returns the start of a file, or nil if it can't be read
+func sniff(root, file string) []byte {
+	f, err := os.Open(filepath.Join(root, filepath.FromSlash(file)))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSniffBytes))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// ListFiles returns the files tracked by git under root, falling back to
+// walking the directory (skipping .git) when git is unavailable
+func ListFiles(root string) ([]string, error) {
+	cmd := exec.Command("git", "ls-files")
+	cmd.Dir = root
+	if out, err := cmd.Output(); err == nil {
+		var files []string
+		for _, line := range bytes.Split(out, []byte("\n")) {
+			if line := strings.TrimSpace(string(line)); line != "" {
+				files = append(files, line)
+			}
+		}
+		return files, nil
+	}
+
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository files: %w", err)
+	}
+	return files, nil
+}
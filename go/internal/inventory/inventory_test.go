@@ -0,0 +1,141 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files under dir and returns their slash-separated paths
+func writeFiles(t *testing.T, dir string, files map[string]string) []string {
+	t.Helper()
+
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, name)
+	}
+	return paths
+}
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	files := writeFiles(t, dir, map[string]string{
+		"main.tf":                           `resource "aws_s3_bucket" "b" {}`,
+		"envs/prod.tfvars":                  `region = "eu-west-1"`,
+		"Dockerfile":                        "FROM alpine",
+		"svc/Dockerfile.dev":                "FROM alpine",
+		"charts/app/Chart.yaml":             "apiVersion: v2\nname: app",
+		"charts/app/templates/deploy.yaml":  "apiVersion: apps/v1\nkind: Deployment",
+		"charts/app/templates/_helpers.tpl": `{{- define "app.name" -}}`,
+		"k8s/service.yaml":                  "apiVersion: v1\nkind: Service\nmetadata:\n  name: web",
+		"cfn/stack.yaml":                    "AWSTemplateFormatVersion: '2010-09-09'\nResources: {}",
+		"cfn/stack.json":                    `{"Resources": {"B": {"Type": "AWS::S3::Bucket"}}}`,
+		".github/workflows/ci.yml":          "on: push",
+		".github/actions/setup/action.yml":  "runs:\n  using: composite",
+		"docker-compose.yml":                "services: {}",
+		"compose.prod.yaml":                 "services: {}",
+		"site.yml":                          "- hosts: all\n  tasks: []",
+		"roles/web/tasks/main.yml":          "- name: install\n  apt: name=nginx",
+		"config/app.yaml":                   "debug: true",
+		"README.md":                         "# readme",
+	})
+
+	inv := Detect(dir, files)
+
+	want := map[string]int{
+		Terraform:      2,
+		Dockerfile:     2,
+		Helm:           3,
+		Kubernetes:     1,
+		CloudFormation: 2,
+		GitHubActions:  2,
+		Compose:        2,
+		Ansible:        2,
+	}
+	for tech, count := range want {
+		if got := inv.Count(tech); got != count {
+			t.Errorf("Count(%s) = %d, want %d", tech, got, count)
+		}
+	}
+
+	if inv.TotalFiles != len(files) {
+		t.Errorf("TotalFiles = %d, want %d", inv.TotalFiles, len(files))
+	}
+	if got := strings.Join(inv.Technologies(), ","); got != strings.Join(All, ",") {
+		t.Errorf("Technologies() should follow display order, got %s", got)
+	}
+}
+
+func TestDetectEmpty(t *testing.T) {
+	dir := t.TempDir()
+	files := writeFiles(t, dir, map[string]string{
+		"main.go":   "package main",
+		"README.md": "# readme",
+	})
+
+	inv := Detect(dir, files)
+	if len(inv.Entries) != 0 {
+		t.Errorf("expected no technologies, got %v", inv.Technologies())
+	}
+	if inv.HasAny(All...) {
+		t.Error("HasAny() should be false for an empty inventory")
+	}
+	if inv.Summary() != "" {
+		t.Errorf("Summary() = %q, want empty", inv.Summary())
+	}
+}
+
+func TestSummary(t *testing.T) {
+	inv := Inventory{Entries: []Entry{
+		{Technology: Terraform, Files: []string{"a.tf", "b.tf"}},
+		{Technology: GitHubActions, Files: []string{".github/workflows/ci.yml"}},
+	}}
+
+	if got := inv.Summary(); got != "Terraform (2 files), GitHub Actions (1 file)" {
+		t.Errorf("Summary() = %q", got)
+	}
+	if !inv.Has(Terraform) || inv.Has(Helm) {
+		t.Error("Has() should only report detected technologies")
+	}
+}
+
+func TestSubset(t *testing.T) {
+	inv := Inventory{Entries: []Entry{
+		{Technology: Terraform, Files: []string{"infra/main.tf", "app/main.tf"}},
+		{Technology: Dockerfile, Files: []string{"app/Dockerfile"}},
+		{Technology: GitHubActions, Files: []string{".github/workflows/ci.yml"}},
+	}}
+
+	sub := inv.Subset([]string{"app/main.tf", "app/Dockerfile", "app/go.mod"})
+	if got := sub.Summary(); got != "Terraform (1 file), Dockerfiles (1 file)" {
+		t.Errorf("Subset().Summary() = %q", got)
+	}
+	if sub.TotalFiles != 3 {
+		t.Errorf("TotalFiles = %d, want 3", sub.TotalFiles)
+	}
+}
+
+func TestListFilesWalkFallback(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.tf":     "",
+		"mod/vars.tf": "",
+		".git/HEAD":   "ref: refs/heads/main",
+	})
+
+	files, err := ListFiles(dir)
+	if err != nil {
+		t.Fatalf("ListFiles() error: %v", err)
+	}
+	if got := strings.Join(files, ","); got != "main.tf,mod/vars.tf" {
+		t.Errorf("ListFiles() = %s", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"strings"
 
@@ -15,8 +16,23 @@ import (
 const (
 	// DelegatedLabel is the label name used to mark issues that have been delegated to Copilot coding agent
 	DelegatedLabel = "delegated"
+
+	// ProjectLabelPrefix prefixes the label attributing an issue to a nested autoengineer.yaml project
+	ProjectLabelPrefix = "project:"
+
+	// SeverityLabelPrefix prefixes the label carrying an issue's severity
+	SeverityLabelPrefix = "severity:"
 )
 
+// severityLabelColors are the colors of the severity labels
+var severityLabelColors = map[string]string{
+	findings.SeverityCritical: "b60205",
+	findings.SeverityHigh:     "d93f0b",
+	findings.SeverityMedium:   "fbca04",
+	findings.SeverityLow:      "0e8a16",
+	findings.SeverityInfo:     "1d76db",
+}
+
 // Client handles GitHub issue operations
 type Client struct {
 	apiClient     *api.RESTClient
@@ -28,13 +44,19 @@ type Client struct {
 
 // NewClient creates a new GitHub issues client
 func NewClient(owner, repo, label string) (*Client, error) {
-	client, err := api.DefaultRESTClient()
+	return NewClientWithOptions(owner, repo, label, api.ClientOptions{})
+}
+
+// NewClientWithOptions creates a GitHub issues client with custom API options,
+// e.g. a Transport that records or replays requests
+func NewClientWithOptions(owner, repo, label string, opts api.ClientOptions) (*Client, error) {
+	client, err := api.NewRESTClient(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitHub API client: %w", err)
 	}
 
 	// Create GraphQL client for operations that require it (like assigning Copilot)
-	gqlClient, err := api.DefaultGraphQLClient()
+	gqlClient, err := api.NewGraphQLClient(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitHub GraphQL client: %w", err)
 	}
@@ -55,7 +77,7 @@ func (c *Client) ensureLabelExists(ctx context.Context, name, description, color
 		Name string `json:"name"`
 	}
 
-	err := c.apiClient.Get(fmt.Sprintf("repos/%s/%s/labels/%s", c.owner, c.repo, name), &label)
+	err := c.apiClient.Get(fmt.Sprintf("repos/%s/%s/labels/%s", c.owner, c.repo, url.PathEscape(name)), &label)
 	if err == nil {
 		// Label exists
 		return nil
@@ -279,10 +301,24 @@ func (c *Client) CreateIssue(ctx context.Context, finding findings.Finding) (int
 
 	body := formatIssueBody(finding)
 
+	labels := []string{c.label}
+	if color, ok := severityLabelColors[finding.Severity]; ok {
+		severityLabel := SeverityLabel(finding.Severity)
+		// Label creation is not critical; the issue is still created without it
+		_ = c.ensureLabelExists(ctx, severityLabel, "AutoEngineer findings of "+finding.Severity+" severity", color)
+		labels = append(labels, severityLabel)
+	}
+	if finding.Project != "" {
+		projectLabel := ProjectLabel(finding.Project)
+		// Label creation is not critical; the issue is still created without it
+		_ = c.ensureLabelExists(ctx, projectLabel, "AutoEngineer findings for project "+finding.Project, "c5def5")
+		labels = append(labels, projectLabel)
+	}
+
 	issueData := map[string]interface{}{
 		"title":  title,
 		"body":   body,
-		"labels": []string{c.label},
+		"labels": labels,
 	}
 
 	bodyBytes, err := json.Marshal(issueData)
@@ -302,16 +338,33 @@ func (c *Client) CreateIssue(ctx context.Context, finding findings.Finding) (int
 	return result.Number, nil
 }
 
+// ProjectLabel returns the label attributing an issue to a project
+func ProjectLabel(project string) string {
+	return ProjectLabelPrefix + project
+}
+
+// SeverityLabel returns the label carrying an issue's severity
+func SeverityLabel(severity string) string {
+	return SeverityLabelPrefix + severity
+}
+
 // formatIssueBody formats the issue body from a finding
 func formatIssueBody(finding findings.Finding) string {
 	priority := "Unknown"
 	switch finding.Severity {
+	case findings.SeverityCritical:
+		priority = "Critical"
 	case findings.SeverityHigh:
 		priority = "High"
 	case findings.SeverityMedium:
 		priority = "Medium"
 	case findings.SeverityLow:
 		priority = "Low"
+	case findings.SeverityInfo:
+		priority = "Info"
+	}
+	if finding.OriginalSeverity != "" {
+		priority += fmt.Sprintf(" (overridden from %s by the repository's severity policy)", finding.OriginalSeverity)
 	}
 
 	filesStr := ""
@@ -336,6 +389,10 @@ func formatIssueBody(finding findings.Finding) string {
 		filesStr,
 	)
 
+	if finding.Project != "" {
+		body += fmt.Sprintf("\n## Project\n%s\n", finding.Project)
+	}
+
 	// Add code snippets if available
 	if len(finding.CodeSnippets) > 0 {
 		body += "\n\n## Code References\n"
@@ -357,6 +414,11 @@ func formatIssueBody(finding findings.Finding) string {
 		}
 	}
 
+	// Surface tags such as "unverified" so reviewers know to double-check
+	if len(finding.Tags) > 0 {
+		body += "\n> **Tags:** " + strings.Join(finding.Tags, ", ") + "\n"
+	}
+
 	body += "\n---\n*Generated by [AutoEngineer](https://github.com/liam-witterick/autoengineer)*"
 	
 	return body
@@ -415,12 +477,16 @@ func detectLanguage(filename string) string {
 // severityEmoji returns the emoji for a severity level
 func severityEmoji(severity string) string {
 	switch severity {
+	case findings.SeverityCritical:
+		return "🚨"
 	case findings.SeverityHigh:
 		return "🔴"
 	case findings.SeverityMedium:
 		return "🟡"
 	case findings.SeverityLow:
 		return "🟢"
+	case findings.SeverityInfo:
+		return "🔵"
 	default:
 		return "⚪"
 	}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
 )
 
 // SearchResult represents a search match
@@ -29,6 +31,16 @@ func extractLabelNames(labels []labelStruct) []string {
 	return labelNames
 }
 
+// ListLabels returns the names of the repository's labels (up to 100)
+func (c *Client) ListLabels(ctx context.Context) ([]string, error) {
+	var labels []labelStruct
+	err := c.apiClient.Get(fmt.Sprintf("repos/%s/%s/labels?per_page=100", c.owner, c.repo), &labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	return extractLabelNames(labels), nil
+}
+
 // FindByTitle searches for an issue by title similarity
 func (c *Client) FindByTitle(ctx context.Context, title string) (*SearchResult, error) {
 	// Use first 50 chars for fuzzy matching
@@ -153,3 +165,38 @@ func (c *Client) ListOpenIssues(ctx context.Context) ([]SearchResult, error) {
 
 	return issues, nil
 }
+
+// Tracks reports whether one of the issues was created for a finding
+func Tracks(existing []SearchResult, finding findings.Finding) bool {
+	_, ok := TrackingIssue(existing, finding)
+	return ok
+}
+
+// TrackingIssue returns the issue created for a finding, matching the issue
+// title without its severity emoji against the finding title
+func TrackingIssue(existing []SearchResult, finding findings.Finding) (SearchResult, bool) {
+	title := strings.ToLower(strings.TrimSpace(finding.Title))
+	if title == "" {
+		return SearchResult{}, false
+	}
+	for _, issue := range existing {
+		issueTitle := strings.TrimSpace(issue.Title)
+		for _, severity := range append(append([]string(nil), findings.Severities...), "") {
+			issueTitle = strings.TrimSpace(strings.TrimPrefix(issueTitle, severityEmoji(severity)))
+		}
+		if strings.ToLower(issueTitle) == title {
+			return issue, true
+		}
+	}
+	return SearchResult{}, false
+}
+
+// IsDelegated reports whether the issue carries the delegated label
+func (r SearchResult) IsDelegated() bool {
+	for _, label := range r.Labels {
+		if label == DelegatedLabel {
+			return true
+		}
+	}
+	return false
+}
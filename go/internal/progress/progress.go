@@ -132,6 +132,7 @@ type ScopeTracker struct {
 	mu      sync.Mutex
 	output  io.Writer
 	enabled bool
+	emojis  map[string]string
 }
 
 // NewScopeTracker creates a tracker for multiple scopes
@@ -141,6 +142,7 @@ func NewScopeTracker(scopes []string) *ScopeTracker {
 		total:   len(scopes),
 		output:  os.Stderr,
 		enabled: true,
+		emojis:  make(map[string]string),
 	}
 
 	for _, scope := range scopes {
@@ -150,6 +152,22 @@ func NewScopeTracker(scopes []string) *ScopeTracker {
 	return st
 }
 
+// SetScopeEmoji sets the emoji shown for a scope, e.g. one declared in autoengineer.yaml
+func (st *ScopeTracker) SetScopeEmoji(scope, emoji string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.emojis[scope] = emoji
+}
+
+// scopeEmoji returns the configured emoji for a scope, falling back to the built-in ones
+func (st *ScopeTracker) scopeEmoji(scope string) string {
+	if emoji, ok := st.emojis[scope]; ok && emoji != "" {
+		return emoji
+	}
+	return getScopeEmoji(scope)
+}
+
 // StartScope starts progress tracking for a specific scope
 func (st *ScopeTracker) StartScope(scope string) {
 	if !st.enabled {
@@ -179,7 +197,7 @@ func (st *ScopeTracker) CompleteScope(scope string, count int) {
 	st.done++
 	
 	// Print result
-	emoji := getScopeEmoji(scope)
+	emoji := st.scopeEmoji(scope)
 	fmt.Fprintf(st.output, "   ✅ %s %s: %d finding(s)\n", emoji, scope, count)
 }
 
@@ -198,7 +216,7 @@ func (st *ScopeTracker) FailScope(scope string, err error) {
 
 	st.done++
 	
-	emoji := getScopeEmoji(scope)
+	emoji := st.scopeEmoji(scope)
 	fmt.Fprintf(st.output, "   ❌ %s %s: failed (%v)\n", emoji, scope, err)
 }
 
@@ -2,6 +2,7 @@ package progress
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 )
 
@@ -145,3 +146,18 @@ func TestGetScopeEmoji(t *testing.T) {
 		})
 	}
 }
+
+func TestScopeTrackerCustomEmoji(t *testing.T) {
+	tracker := NewScopeTracker([]string{"cost"})
+	tracker.SetScopeEmoji("cost", "💰")
+
+	var buf bytes.Buffer
+	tracker.output = &buf
+
+	tracker.StartScope("cost")
+	tracker.CompleteScope("cost", 2)
+
+	if !strings.Contains(buf.String(), "💰 cost: 2 finding(s)") {
+		t.Errorf("Expected custom emoji in output, got %q", buf.String())
+	}
+}
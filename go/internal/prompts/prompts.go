@@ -0,0 +1,231 @@
+// Package prompts renders the LLM prompts from text/template files. The
+// defaults are embedded in the binary and any of them can be overridden by a
+// file of the same name in .github/autoengineer/prompts/.
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+	"github.com/liam-witterick/autoengineer/go/internal/issues"
+)
+
+// OverrideDir is where repository-specific prompt templates are looked up
+const OverrideDir = ".github/autoengineer/prompts"
+
+// Template names
+const (
+	NameSecurity = "security"
+	NamePipeline = "pipeline"
+	NameInfra    = "infra"
+	NameDedup    = "dedup"
+
+	// NameScope is the generic template used for custom scopes
+	NameScope = "scope"
+)
+
+// templateExt is the file extension of prompt templates
+const templateExt = ".tmpl"
+
+// SourceEmbedded is reported by Source for templates built into the binary
+const SourceEmbedded = "embedded"
+
+//go:embed templates/*.tmpl
+var embedded embed.FS
+
+// AnalysisData is passed to the scope analysis templates
+type AnalysisData struct {
+	// Scope is the analysis scope being rendered
+	Scope string
+
+	// ExistingIssues are the open tracked issues the model should not report again
+	ExistingIssues []issues.SearchResult
+
+	// ExistingContext is the default "skip these issues" block built from ExistingIssues
+	ExistingContext string
+
+	// ExtraContext holds the custom instructions
+	ExtraContext string
+
+	// Title is the scope's display name
+	Title string
+
+	// Category is assigned to the scope's findings
+	Category string
+
+	// Instructions is the prompt of a custom scope
+	Instructions string
+
+	// Globs are the scope's file globs (empty means all files)
+	Globs []string
+
+	// Files lists the tracked files in the scope, relative to the root
+	Files []string
+
+	// Inventory lists the technologies detected in the repository
+	Inventory inventory.Inventory
+
+	// Shard is the directory the analysis is limited to when sharding,
+	// "." for the files outside every other shard, or "" for the whole repository
+	Shard string
+}
+
+// DedupData is passed to the deduplication template
+type DedupData struct {
+	// ExistingIssues are the open tracked issues to filter findings against
+	ExistingIssues []issues.SearchResult
+
+	// Findings are the findings to deduplicate
+	Findings []findings.Finding
+
+	// FindingsJSON is Findings encoded as an indented JSON array
+	FindingsJSON string
+}
+
+// funcs are the helper functions available to every template
+var funcs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"json": func(v interface{}) (string, error) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		return string(data), err
+	},
+}
+
+// Set is a parsed collection of prompt templates
+type Set struct {
+	templates map[string]*template.Template
+	sources   map[string]string
+	names     []string
+}
+
+// Names returns the names of all built-in templates
+func Names() []string {
+	return []string{NameSecurity, NamePipeline, NameInfra, NameDedup}
+}
+
+// Names returns the names of every template in the set, including custom scopes
+func (s *Set) Names() []string {
+	return append([]string(nil), s.names...)
+}
+
+// Default returns the embedded templates without any overrides
+func Default() *Set {
+	set, err := Load("")
+	if err != nil {
+		// The embedded templates are compiled into the binary and always parse
+		panic(err)
+	}
+	return set
+}
+
+// Load parses the embedded templates and applies any overrides found in dir.
+// An empty dir, or one that doesn't exist, means no overrides. Each custom
+// scope gets a copy of the generic scope template, which dir can also override.
+func Load(dir string, customScopes ...string) (*Set, error) {
+	set := &Set{
+		templates: make(map[string]*template.Template),
+		sources:   make(map[string]string),
+	}
+
+	for _, name := range Names() {
+		text, err := embedded.ReadFile("templates/" + name + templateExt)
+		if err != nil {
+			return nil, fmt.Errorf("missing embedded prompt template %s: %w", name, err)
+		}
+		if err := set.parse(name, string(text), SourceEmbedded); err != nil {
+			return nil, err
+		}
+	}
+
+	scopeText, err := embedded.ReadFile("templates/" + NameScope + templateExt)
+	if err != nil {
+		return nil, fmt.Errorf("missing embedded prompt template %s: %w", NameScope, err)
+	}
+	for _, name := range customScopes {
+		if _, exists := set.templates[name]; exists {
+			return nil, fmt.Errorf("custom scope %q conflicts with a built-in prompt template", name)
+		}
+		if err := set.parse(name, string(scopeText), SourceEmbedded+" ("+NameScope+")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if dir == "" {
+		return set, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return set, nil
+		}
+		return nil, fmt.Errorf("failed to read prompt overrides: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != templateExt {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), templateExt)
+		if _, ok := set.templates[name]; !ok {
+			return nil, fmt.Errorf("unknown prompt template %s in %s (must be one of: %s)",
+				entry.Name(), dir, strings.Join(set.names, ", "))
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		text, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template %s: %w", path, err)
+		}
+		if err := set.parse(name, string(text), path); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
+
+// parse compiles a template and records where it came from
+func (s *Set) parse(name, text, source string) error {
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse prompt template %s (%s): %w", name, source, err)
+	}
+
+	if _, exists := s.templates[name]; !exists {
+		s.names = append(s.names, name)
+	}
+	s.templates[name] = tmpl
+	s.sources[name] = source
+	return nil
+}
+
+// Render executes the named template with data
+func (s *Set) Render(name string, data interface{}) (string, error) {
+	tmpl, ok := s.templates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown prompt template %q", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt template %s (%s): %w", name, s.sources[name], err)
+	}
+	return buf.String(), nil
+}
+
+// Source returns "embedded" or the path of the override file for a template
+func (s *Set) Source(name string) string {
+	return s.sources[name]
+}
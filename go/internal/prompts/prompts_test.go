@@ -0,0 +1,201 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+	"github.com/liam-witterick/autoengineer/go/internal/issues"
+)
+
+func TestDefaultTemplatesRender(t *testing.T) {
+	set := Default()
+
+	for _, name := range []string{NameSecurity, NamePipeline, NameInfra} {
+		t.Run(name, func(t *testing.T) {
+			prompt, err := set.Render(name, AnalysisData{
+				Scope:           name,
+				ExistingContext: "\n\nSKIP these already-tracked issues",
+				ExtraContext:    "\n\nCUSTOM INSTRUCTIONS",
+			})
+			if err != nil {
+				t.Fatalf("Render() error: %v", err)
+			}
+
+			if !strings.HasPrefix(prompt, "Review the infrastructure code") {
+				t.Errorf("doc comment should be trimmed from the prompt, got: %q", prompt[:40])
+			}
+			if !strings.Contains(prompt, `"category": "`+name+`"`) {
+				t.Errorf("prompt should ask for category %q", name)
+			}
+			if !strings.Contains(prompt, "Skip issues documented as TODOs\n\nSKIP these already-tracked issues\n\nCUSTOM INSTRUCTIONS") {
+				t.Error("existing and extra context should follow the rules")
+			}
+			if set.Source(name) != SourceEmbedded {
+				t.Errorf("Source() = %q, want %q", set.Source(name), SourceEmbedded)
+			}
+		})
+	}
+}
+
+func TestTemplatesRenderInventory(t *testing.T) {
+	set := Default()
+	inv := inventory.Inventory{Entries: []inventory.Entry{
+		{Technology: inventory.Terraform, Files: []string{"main.tf", "vars.tf"}},
+		{Technology: inventory.GitHubActions, Files: []string{".github/workflows/ci.yml"}},
+	}}
+
+	prompt, err := set.Render(NameInfra, AnalysisData{Scope: NameInfra, Inventory: inv})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if !strings.Contains(prompt, "Output ONLY a JSON array.\n\nDETECTED TECHNOLOGIES: Terraform (2 files), GitHub Actions (1 file)\n\nINFRASTRUCTURE FOCUS AREAS") {
+		t.Errorf("prompt should list the detected technologies, got:\n%s", prompt)
+	}
+
+	prompt, err = set.Render(NameInfra, AnalysisData{Scope: NameInfra})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if strings.Contains(prompt, "DETECTED TECHNOLOGIES") {
+		t.Error("an empty inventory should not be rendered")
+	}
+}
+
+func TestTemplatesRenderShard(t *testing.T) {
+	set := Default()
+
+	prompt, err := set.Render(NameSecurity, AnalysisData{Scope: NameSecurity, Shard: "services/api"})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if !strings.Contains(prompt, "SHARD: Analyze ONLY the files under services/api/ (the rest of the repo is analyzed separately).\nFile paths in findings must still be relative to the repo root.\n\nSECURITY FOCUS AREAS") {
+		t.Errorf("prompt should be limited to the shard directory, got:\n%s", prompt)
+	}
+
+	prompt, err = set.Render(NameSecurity, AnalysisData{Scope: NameSecurity, Shard: ".", Files: []string{"main.tf", "Dockerfile"}})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if !strings.Contains(prompt, "SHARD: Analyze ONLY these files (the rest of the repo is analyzed separately):\n- main.tf\n- Dockerfile\nFile paths") {
+		t.Errorf("root shard prompt should list its files, got:\n%s", prompt)
+	}
+
+	prompt, err = set.Render(NameSecurity, AnalysisData{Scope: NameSecurity})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if strings.Contains(prompt, "SHARD:") {
+		t.Error("an unsharded prompt should not mention shards")
+	}
+}
+
+func TestDedupTemplate(t *testing.T) {
+	set := Default()
+
+	prompt, err := set.Render(NameDedup, DedupData{
+		ExistingIssues: []issues.SearchResult{{Number: 7, Title: "Open security group"}},
+		Findings:       []findings.Finding{{Title: "x"}},
+		FindingsJSON:   `[{"title": "x"}]`,
+	})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+
+	if !strings.Contains(prompt, "EXISTING TRACKED ISSUES (already have GitHub issues - remove related findings):\n- \"Open security group\" (Issue #7)\n\nNEW FINDINGS TO DEDUPLICATE:\n[{\"title\": \"x\"}]") {
+		t.Errorf("unexpected dedup prompt:\n%s", prompt)
+	}
+
+	prompt, err = set.Render(NameDedup, DedupData{FindingsJSON: "[]"})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if strings.Contains(prompt, "EXISTING TRACKED ISSUES") {
+		t.Error("existing issues section should be omitted when there are none")
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	dir := t.TempDir()
+	override := `Scope {{.Scope}} with {{len .Files}} files: {{join .Files ","}}`
+	if err := os.WriteFile(filepath.Join(dir, "security.tmpl"), []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Non-template files are ignored
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	prompt, err := set.Render(NameSecurity, AnalysisData{Scope: "security", Files: []string{"main.tf", "ci.yml"}})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if prompt != "Scope security with 2 files: main.tf,ci.yml" {
+		t.Errorf("override prompt = %q", prompt)
+	}
+	if set.Source(NameSecurity) != filepath.Join(dir, "security.tmpl") {
+		t.Errorf("Source() = %q, want override path", set.Source(NameSecurity))
+	}
+	if set.Source(NameInfra) != SourceEmbedded {
+		t.Errorf("templates without an override should stay embedded, got %q", set.Source(NameInfra))
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{name: "unknown template", file: "securty.tmpl", content: "x", wantErr: "unknown prompt template securty.tmpl"},
+		{name: "parse error", file: "infra.tmpl", content: "{{.Scope", wantErr: "failed to parse prompt template infra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Load(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadMissingDir(t *testing.T) {
+	set, err := Load(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("Load() of a missing directory should use the defaults, got %v", err)
+	}
+	if set.Source(NameDedup) != SourceEmbedded {
+		t.Errorf("Source() = %q, want %q", set.Source(NameDedup), SourceEmbedded)
+	}
+}
+
+func TestRenderUnknownField(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pipeline.tmpl"), []byte("{{.Technologies}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if _, err := set.Render(NamePipeline, AnalysisData{}); err == nil {
+		t.Error("expected error for a field that doesn't exist")
+	}
+}
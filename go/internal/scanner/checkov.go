@@ -5,20 +5,36 @@ import (
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"regexp"
 	"strings"
 
+	"github.com/liam-witterick/autoengineer/go/internal/config"
 	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
 )
 
 // CheckovScanner implements Scanner for Checkov
 type CheckovScanner struct {
 	binaryPath string
+	frameworks string
+	skipPaths  []string
 }
 
 const (
 	checkovFrameworks = "terraform,dockerfile,kubernetes,helm,serverless"
 )
 
+// checkovTechnologyFrameworks maps inventory technologies to Checkov frameworks
+var checkovTechnologyFrameworks = map[string]string{
+	inventory.Terraform:      "terraform",
+	inventory.Dockerfile:     "dockerfile",
+	inventory.Kubernetes:     "kubernetes",
+	inventory.Helm:           "helm",
+	inventory.CloudFormation: "cloudformation",
+	inventory.GitHubActions:  "github_actions",
+	inventory.Ansible:        "ansible",
+}
+
 // NewCheckovScanner creates a new Checkov scanner
 func NewCheckovScanner() *CheckovScanner {
 	return &CheckovScanner{
@@ -36,6 +52,43 @@ func (s *CheckovScanner) Type() ScannerType {
 	return TypeLocal
 }
 
+// Technologies returns the technologies Checkov has frameworks for
+func (s *CheckovScanner) Technologies() []string {
+	var techs []string
+	for _, tech := range inventory.All {
+		if _, ok := checkovTechnologyFrameworks[tech]; ok {
+			techs = append(techs, tech)
+		}
+	}
+	return techs
+}
+
+// UseTechnologies limits the frameworks Checkov runs to those present in the repo
+func (s *CheckovScanner) UseTechnologies(present []string) {
+	var frameworks []string
+	for _, tech := range present {
+		if framework, ok := checkovTechnologyFrameworks[tech]; ok {
+			frameworks = append(frameworks, framework)
+		}
+	}
+	s.frameworks = strings.Join(frameworks, ",")
+}
+
+// SkipPaths excludes ignored directories and files from the next run
+func (s *CheckovScanner) SkipPaths(paths []string) {
+	s.skipPaths = paths
+}
+
+// skipPathPattern turns a directory (with a trailing "/") or file into a
+// --skip-path regular expression anchored at the scan root, so it can't
+// match a path of the same name deeper in the tree
+func skipPathPattern(p string) string {
+	if dir, ok := strings.CutSuffix(p, "/"); ok {
+		return `^(\./)?` + regexp.QuoteMeta(dir) + `(/|$)`
+	}
+	return `^(\./)?` + regexp.QuoteMeta(p) + `$`
+}
+
 // IsInstalled checks if Checkov is available
 func (s *CheckovScanner) IsInstalled() bool {
 	_, err := exec.LookPath(s.binaryPath)
@@ -72,8 +125,16 @@ func (s *CheckovScanner) Run(ctx context.Context, scope string) ([]findings.Find
 	
 	// Add framework filters based on scope
 	if scope == "security" || scope == "all" {
-		// Include all frameworks for comprehensive security scan
-		args = append(args, "--framework", checkovFrameworks)
+		// Include all frameworks for comprehensive security scan,
+		// or only those the inventory found
+		frameworks := checkovFrameworks
+		if s.frameworks != "" {
+			frameworks = s.frameworks
+		}
+		args = append(args, "--framework", frameworks)
+	}
+	for _, p := range s.skipPaths {
+		args = append(args, "--skip-path", skipPathPattern(p))
 	}
 	
 	cmd := exec.CommandContext(ctx, s.binaryPath, args...)
@@ -109,8 +170,13 @@ type checkovCheck struct {
 	CheckResult   map[string]interface{} `json:"check_result"`
 	FilePath      string   `json:"file_path"`
 	FileLineRange []int    `json:"file_line_range"`
+	CodeBlock     [][]interface{} `json:"code_block"`
 	Resource      string   `json:"resource"`
 	Guideline     string   `json:"guideline"`
+
+	// Severity is CRITICAL, HIGH, MEDIUM, LOW or INFO, or null without a
+	// Prisma Cloud API key
+	Severity string `json:"severity"`
 }
 
 type checkovSummary struct {
@@ -135,9 +201,13 @@ func (s *CheckovScanner) parseResults(output []byte, scope string) ([]findings.F
 			Title:       check.CheckName,
 			Description: fmt.Sprintf("Checkov check %s failed for resource: %s", check.CheckID, check.Resource),
 			Recommendation: check.Guideline,
-			Files:       []string{check.FilePath},
-			Severity:    mapCheckovSeverity(check.CheckID),
+			Files:       []string{config.NormalizePath(check.FilePath)},
+			Severity:    mapCheckovSeverity(check.CheckID, check.Severity),
 			Category:    findings.CategorySecurity,
+			Rule:        check.CheckID,
+		}
+		if snippet, ok := check.codeSnippet(); ok {
+			finding.CodeSnippets = []findings.CodeSnippet{snippet}
 		}
 		
 		results = append(results, finding)
@@ -146,9 +216,46 @@ func (s *CheckovScanner) parseResults(output []byte, scope string) ([]findings.F
 	return results, nil
 }
 
-// mapCheckovSeverity maps Checkov check IDs to severity levels
-// This is a simplified mapping - could be enhanced with a lookup table
-func mapCheckovSeverity(checkID string) string {
+// codeSnippet returns the failing resource's lines, if Checkov reported them.
+// The code block is a list of [line number, text] pairs.
+func (c checkovCheck) codeSnippet() (findings.CodeSnippet, bool) {
+	if len(c.FileLineRange) != 2 || c.FileLineRange[0] <= 0 {
+		return findings.CodeSnippet{}, false
+	}
+	var code strings.Builder
+	for _, line := range c.CodeBlock {
+		if len(line) == 2 {
+			if text, ok := line[1].(string); ok {
+				code.WriteString(text)
+			}
+		}
+	}
+	return findings.CodeSnippet{
+		File:      config.NormalizePath(c.FilePath),
+		StartLine: c.FileLineRange[0],
+		EndLine:   c.FileLineRange[1],
+		Code:      strings.TrimRight(code.String(), "\n"),
+	}, true
+}
+
+// mapCheckovSeverity maps the severity Checkov reports to our severity
+// levels. Checkov only reports severities with a Prisma Cloud API key, so
+// without one the check ID decides.
+func mapCheckovSeverity(checkID, severity string) string {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return findings.SeverityCritical
+	case "HIGH":
+		return findings.SeverityHigh
+	case "MEDIUM":
+		return findings.SeverityMedium
+	case "LOW":
+		return findings.SeverityLow
+	case "INFO":
+		return findings.SeverityInfo
+	}
+
+	// This is a simplified mapping - could be enhanced with a lookup table
 	// High severity patterns (common critical security issues)
 	if strings.Contains(checkID, "CKV_AWS_18") || // S3 bucket logging
 		strings.Contains(checkID, "CKV_AWS_19") || // S3 bucket encryption
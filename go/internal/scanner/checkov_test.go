@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+func TestMapCheckovSeverity(t *testing.T) {
+	tests := []struct {
+		checkID, severity string
+		want              string
+	}{
+		{"CKV_AWS_79", "CRITICAL", findings.SeverityCritical},
+		{"CKV_AWS_79", "HIGH", findings.SeverityHigh},
+		{"CKV_AWS_20", "MEDIUM", findings.SeverityMedium},
+		{"CKV_AWS_79", "low", findings.SeverityLow},
+		{"CKV_AWS_79", "INFO", findings.SeverityInfo},
+		// Without a reported severity the check ID decides
+		{"CKV_AWS_20", "", findings.SeverityHigh},
+		{"CKV_AWS_79", "", findings.SeverityMedium},
+	}
+	for _, tt := range tests {
+		if got := mapCheckovSeverity(tt.checkID, tt.severity); got != tt.want {
+			t.Errorf("mapCheckovSeverity(%q, %q) = %q, want %q", tt.checkID, tt.severity, got, tt.want)
+		}
+	}
+}
+
+func TestParseResultsSeverity(t *testing.T) {
+	output := []byte(`{"check_type": "terraform", "results": {"failed_checks": [
+		{"check_id": "CKV_AWS_79", "check_name": "Ensure IMDSv2 is required", "file_path": "/main.tf", "resource": "aws_instance.web", "severity": "CRITICAL"},
+		{"check_id": "CKV_AWS_126", "check_name": "Ensure detailed monitoring", "file_path": "/main.tf", "resource": "aws_instance.web", "severity": null}
+	]}}`)
+	results, err := NewCheckovScanner().parseResults(output, "all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Severity != findings.SeverityCritical || results[1].Severity != findings.SeverityMedium {
+		t.Errorf("unexpected severities: %+v", results)
+	}
+}
+
+func TestParseResultsNormalizesPaths(t *testing.T) {
+	output := []byte(`{"check_type": "terraform", "results": {"failed_checks": [
+		{"check_id": "CKV_AWS_18", "check_name": "Ensure access logging", "file_path": "/modules/s3/main.tf", "file_line_range": [1, 2], "code_block": [[1, "resource {\n"], [2, "}\n"]], "resource": "aws_s3_bucket.b"},
+		{"check_id": "CKV_AWS_18", "check_name": "Ensure access logging", "file_path": "/modules/keep/main.tf", "resource": "aws_s3_bucket.k"}
+	]}}`)
+	results, err := NewCheckovScanner().parseResults(output, "all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(results))
+	}
+	if results[0].Files[0] != "modules/s3/main.tf" || results[0].CodeSnippets[0].File != "modules/s3/main.tf" {
+		t.Errorf("expected repo-relative paths, got files %v and snippet %q", results[0].Files, results[0].CodeSnippets[0].File)
+	}
+
+	ignorePaths := []string{"modules/**", "!modules/keep/**"}
+	if !config.MatchGlobs(ignorePaths, results[0].Files[0]) {
+		t.Errorf("expected %s to match %v", results[0].Files[0], ignorePaths)
+	}
+	if config.MatchGlobs(ignorePaths, results[1].Files[0]) {
+		t.Errorf("expected %s to be re-included by %v", results[1].Files[0], ignorePaths)
+	}
+}
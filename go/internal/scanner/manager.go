@@ -8,12 +8,15 @@ import (
 
 	"github.com/liam-witterick/autoengineer/go/internal/config"
 	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
 )
 
 // Manager manages scanner execution and coordination
 type Manager struct {
-	scanners []Scanner
-	config   *config.ScannerConfig
+	scanners  []Scanner
+	config    *config.ScannerConfig
+	inventory *inventory.Inventory
+	skipPaths []string
 }
 
 // NewManager creates a new scanner manager
@@ -30,6 +33,34 @@ func NewManager(cfg *config.ScannerConfig) *Manager {
 	}
 }
 
+// SetInventory lets the manager skip scanners that have nothing to scan
+func (m *Manager) SetInventory(inv inventory.Inventory) {
+	m.inventory = &inv
+}
+
+// SetSkipPaths passes ignored directories and files on to the scanners that
+// can skip them (see SkippedPaths)
+func (m *Manager) SetSkipPaths(paths []string) {
+	m.skipPaths = paths
+}
+
+// presentTechnologies returns the scanner's technologies found in the inventory,
+// and whether the scanner is limited to specific technologies at all
+func (m *Manager) presentTechnologies(s Scanner) ([]string, bool) {
+	ts, ok := s.(TechnologyScanner)
+	if !ok || m.inventory == nil {
+		return nil, false
+	}
+
+	var present []string
+	for _, tech := range ts.Technologies() {
+		if m.inventory.Has(tech) {
+			present = append(present, tech)
+		}
+	}
+	return present, true
+}
+
 // DetectScanners returns status of all scanners
 func (m *Manager) DetectScanners() []ScannerStatus {
 	var statuses []ScannerStatus
@@ -51,7 +82,15 @@ func (m *Manager) DetectScanners() []ScannerStatus {
 			status.Version = scanner.Version()
 		}
 		
-		if !enabled {
+		// Skip scanners that support none of the technologies in the repo
+		if present, limited := m.presentTechnologies(scanner); enabled && limited && len(present) == 0 {
+			enabled = false
+			status.Enabled = false
+			status.Skipped = true
+			status.Reason = "nothing to scan"
+		}
+		
+		if !enabled && status.Reason == "" {
 			if !installed {
 				status.Reason = "not installed"
 			} else if m.config != nil && m.config.IsDisabled(scanner.Name()) {
@@ -110,6 +149,13 @@ func (m *Manager) RunAll(ctx context.Context, scope string) ([]findings.Finding,
 	var wg sync.WaitGroup
 	
 	for _, scanner := range enabledScanners {
+		if present, limited := m.presentTechnologies(scanner); limited {
+			scanner.(TechnologyScanner).UseTechnologies(present)
+		}
+		if ps, ok := scanner.(PathScanner); ok {
+			ps.SkipPaths(m.skipPaths)
+		}
+		
 		wg.Add(1)
 		
 		// Print starting message
@@ -137,8 +183,15 @@ func (m *Manager) RunAll(ctx context.Context, scope string) ([]findings.Finding,
 	
 	for result := range results {
 		scanResults[result.Scanner] = result
-		if result.Error == nil {
-			allFindings = append(allFindings, result.Findings...)
+	}
+	
+	// Append in scanner order so output (and replayed dedup prompts) is deterministic
+	for _, scanner := range enabledScanners {
+		if result := scanResults[scanner.Name()]; result.Error == nil {
+			for _, f := range result.Findings {
+				f.Source = result.Scanner
+				allFindings = append(allFindings, f)
+			}
 		}
 	}
 	
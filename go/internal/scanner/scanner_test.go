@@ -2,9 +2,13 @@ package scanner
 
 import (
 	"context"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
 )
 
 func TestCheckovScanner(t *testing.T) {
@@ -116,3 +120,141 @@ func TestManagerRunAllWithNoScanners(t *testing.T) {
 		}
 	}
 }
+
+// fakeScanner is an installed local scanner limited to some technologies
+type fakeScanner struct {
+	techs []string
+	used  []string
+	ran   bool
+}
+
+func (s *fakeScanner) Name() string                     { return "checkov" }
+func (s *fakeScanner) IsInstalled() bool                { return true }
+func (s *fakeScanner) Version() string                  { return "1.0" }
+func (s *fakeScanner) Type() ScannerType                { return TypeLocal }
+func (s *fakeScanner) Technologies() []string           { return s.techs }
+func (s *fakeScanner) UseTechnologies(present []string) { s.used = present }
+func (s *fakeScanner) Run(ctx context.Context, scope string) ([]findings.Finding, error) {
+	s.ran = true
+	return nil, nil
+}
+
+func TestManagerSkipsScannersWithNothingToScan(t *testing.T) {
+	fake := &fakeScanner{techs: []string{inventory.Terraform, inventory.Kubernetes}}
+	mgr := &Manager{scanners: []Scanner{fake}, config: &config.ScannerConfig{}}
+	
+	mgr.SetInventory(inventory.Inventory{Entries: []inventory.Entry{
+		{Technology: inventory.GitHubActions, Files: []string{".github/workflows/ci.yml"}},
+	}})
+	
+	_, statuses := mgr.RunAll(context.Background(), "all")
+	if fake.ran {
+		t.Error("Expected scanner with nothing to scan not to run")
+	}
+	if !statuses[0].Skipped || statuses[0].Reason != "nothing to scan" {
+		t.Errorf("Expected skipped with reason 'nothing to scan', got %+v", statuses[0])
+	}
+	
+	mgr.SetInventory(inventory.Inventory{Entries: []inventory.Entry{
+		{Technology: inventory.Kubernetes, Files: []string{"k8s/deploy.yaml"}},
+	}})
+	
+	_, statuses = mgr.RunAll(context.Background(), "all")
+	if !fake.ran || !statuses[0].Ran {
+		t.Error("Expected scanner to run when one of its technologies is present")
+	}
+	if len(fake.used) != 1 || fake.used[0] != inventory.Kubernetes {
+		t.Errorf("Expected scanner limited to kubernetes, got %v", fake.used)
+	}
+}
+
+func TestCheckovUseTechnologies(t *testing.T) {
+	scanner := NewCheckovScanner()
+	
+	scanner.UseTechnologies([]string{inventory.Terraform, inventory.GitHubActions, inventory.Compose})
+	
+	if scanner.frameworks != "terraform,github_actions" {
+		t.Errorf("Expected frameworks 'terraform,github_actions', got '%s'", scanner.frameworks)
+	}
+}
+
+func TestSkippedPaths(t *testing.T) {
+	files := []string{
+		"main.tf",
+		"examples/basic/main.tf",
+		"examples/basic/vars.tf",
+		"examples/keep/main.tf",
+		"examples/README.md",
+		"modules/vpc/examples/a.tf",
+		"modules/vpc/main.tf",
+	}
+	ignored := func(file string) bool {
+		return config.MatchGlobs([]string{"**/examples/**", "!examples/keep/**"}, file)
+	}
+
+	got := SkippedPaths(files, ignored)
+	want := []string{"examples/README.md", "examples/basic/", "modules/vpc/examples/"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("SkippedPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestSkipPathPattern(t *testing.T) {
+	dir := regexp.MustCompile(skipPathPattern("examples/basic/"))
+	for path, want := range map[string]bool{
+		"examples/basic":           true,
+		"./examples/basic/main.tf": true,
+		"modules/examples/basic":   false,
+		"examples/basic2/main.tf":  false,
+	} {
+		if got := dir.MatchString(path); got != want {
+			t.Errorf("directory pattern matching %q = %v, want %v", path, got, want)
+		}
+	}
+
+	file := regexp.MustCompile(skipPathPattern("examples/main.tf"))
+	if !file.MatchString("./examples/main.tf") || file.MatchString("examples/main.tfvars") {
+		t.Error("file pattern should match exactly the file")
+	}
+}
+
+func TestParseResultsCodeSnippets(t *testing.T) {
+	checkov, err := NewCheckovScanner().parseResults([]byte(`{"results": {"failed_checks": [{
+		"check_id": "CKV_AWS_260", "check_name": "Ingress from 0.0.0.0/0 to port 80", "file_path": "/sg.tf",
+		"file_line_range": [3, 4], "code_block": [[3, "ingress {\n"], [4, "  cidr_blocks = [\"0.0.0.0/0\"]\n"]]
+	}]}}`), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := findings.CodeSnippet{File: "sg.tf", StartLine: 3, EndLine: 4, Code: "ingress {\n  cidr_blocks = [\"0.0.0.0/0\"]"}
+	if len(checkov) != 1 || checkov[0].Rule != "CKV_AWS_260" || len(checkov[0].CodeSnippets) != 1 || checkov[0].CodeSnippets[0] != want {
+		t.Errorf("unexpected checkov findings %+v", checkov)
+	}
+
+	trivy, err := NewTrivyScanner().parseResults([]byte(`{"Results": [{"Target": "Dockerfile", "Misconfigurations": [{
+		"ID": "DS002", "Title": "Image user should not be 'root'", "Severity": "HIGH",
+		"CauseMetadata": {"StartLine": 2, "EndLine": 2, "Code": {"Lines": [{"Number": 2, "Content": "USER root"}]}}
+	}]}]}`), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = findings.CodeSnippet{File: "Dockerfile", StartLine: 2, EndLine: 2, Code: "USER root"}
+	if len(trivy) != 1 || trivy[0].Rule != "DS002" || len(trivy[0].CodeSnippets) != 1 || trivy[0].CodeSnippets[0] != want {
+		t.Errorf("unexpected trivy findings %+v", trivy)
+	}
+}
+
+func TestMapTrivySeverity(t *testing.T) {
+	tests := map[string]string{
+		"CRITICAL": findings.SeverityCritical,
+		"HIGH":     findings.SeverityHigh,
+		"MEDIUM":   findings.SeverityMedium,
+		"low":      findings.SeverityLow,
+		"UNKNOWN":  findings.SeverityInfo,
+	}
+	for in, want := range tests {
+		if got := mapTrivySeverity(in); got != want {
+			t.Errorf("mapTrivySeverity(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package scanner
+
+import (
+	"path"
+	"sort"
+)
+
+// SkippedPaths lists the ignored files among files (slash-separated, relative
+// to the repository root) as compactly as possible: a directory, with a
+// trailing "/", when every file below it is ignored, otherwise the file
+func SkippedPaths(files []string, ignored func(string) bool) []string {
+	total := make(map[string]int)
+	skipped := make(map[string]int)
+	var ignoredFiles []string
+	for _, file := range files {
+		isIgnored := ignored(file)
+		if isIgnored {
+			ignoredFiles = append(ignoredFiles, file)
+		}
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			total[dir]++
+			if isIgnored {
+				skipped[dir]++
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var paths []string
+	for _, file := range ignoredFiles {
+		// The topmost directory holding only ignored files covers the file
+		skip := file
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			if skipped[dir] == total[dir] {
+				skip = dir + "/"
+			}
+		}
+		if !seen[skip] {
+			seen[skip] = true
+			paths = append(paths, skip)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
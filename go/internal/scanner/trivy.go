@@ -7,12 +7,15 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/liam-witterick/autoengineer/go/internal/config"
 	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
 )
 
 // TrivyScanner implements Scanner for Trivy
 type TrivyScanner struct {
 	binaryPath string
+	skipPaths  []string
 }
 
 const (
@@ -36,6 +39,19 @@ func (s *TrivyScanner) Type() ScannerType {
 	return TypeLocal
 }
 
+// Technologies returns the technologies trivy config can scan
+func (s *TrivyScanner) Technologies() []string {
+	return []string{inventory.Terraform, inventory.Helm, inventory.Kubernetes, inventory.Dockerfile, inventory.CloudFormation}
+}
+
+// UseTechnologies is a no-op: trivy config detects file types itself
+func (s *TrivyScanner) UseTechnologies(present []string) {}
+
+// SkipPaths excludes ignored directories and files from the next run
+func (s *TrivyScanner) SkipPaths(paths []string) {
+	s.skipPaths = paths
+}
+
 // IsInstalled checks if Trivy is available
 func (s *TrivyScanner) IsInstalled() bool {
 	_, err := exec.LookPath(s.binaryPath)
@@ -78,6 +94,13 @@ func (s *TrivyScanner) Run(ctx context.Context, scope string) ([]findings.Findin
 	if scope == "security" || scope == "all" {
 		args = append(args, "--severity", trivySeverities)
 	}
+	for _, p := range s.skipPaths {
+		if dir, ok := strings.CutSuffix(p, "/"); ok {
+			args = append(args, "--skip-dirs", dir)
+		} else {
+			args = append(args, "--skip-files", p)
+		}
+	}
 	
 	cmd := exec.CommandContext(ctx, s.binaryPath, args...)
 	output, err := cmd.Output()
@@ -115,6 +138,36 @@ type trivyMisconfig struct {
 	Severity   string `json:"Severity"`
 	PrimaryURL string `json:"PrimaryURL"`
 	References []string `json:"References"`
+	CauseMetadata trivyCauseMetadata `json:"CauseMetadata"`
+}
+
+type trivyCauseMetadata struct {
+	StartLine int `json:"StartLine"`
+	EndLine   int `json:"EndLine"`
+	Code      struct {
+		Lines []struct {
+			Number  int    `json:"Number"`
+			Content string `json:"Content"`
+		} `json:"Lines"`
+	} `json:"Code"`
+}
+
+// codeSnippet returns the misconfigured lines of target, if Trivy reported them
+func (m trivyMisconfig) codeSnippet(target string) (findings.CodeSnippet, bool) {
+	cause := m.CauseMetadata
+	if cause.StartLine <= 0 {
+		return findings.CodeSnippet{}, false
+	}
+	lines := make([]string, 0, len(cause.Code.Lines))
+	for _, line := range cause.Code.Lines {
+		lines = append(lines, line.Content)
+	}
+	return findings.CodeSnippet{
+		File:      config.NormalizePath(target),
+		StartLine: cause.StartLine,
+		EndLine:   cause.EndLine,
+		Code:      strings.Join(lines, "\n"),
+	}, true
 }
 
 // parseResults parses Trivy JSON output into findings
@@ -140,9 +193,13 @@ func (s *TrivyScanner) parseResults(output []byte, scope string) ([]findings.Fin
 				Title:       misconfig.Title,
 				Description: description,
 				Recommendation: misconfig.Resolution,
-				Files:       []string{fileResult.Target},
+				Files:       []string{config.NormalizePath(fileResult.Target)},
 				Severity:    mapTrivySeverity(misconfig.Severity),
 				Category:    findings.CategorySecurity,
+				Rule:        misconfig.ID,
+			}
+			if snippet, ok := misconfig.codeSnippet(fileResult.Target); ok {
+				finding.CodeSnippets = []findings.CodeSnippet{snippet}
 			}
 			
 			results = append(results, finding)
@@ -155,12 +212,16 @@ func (s *TrivyScanner) parseResults(output []byte, scope string) ([]findings.Fin
 // mapTrivySeverity maps Trivy severity levels to our severity levels
 func mapTrivySeverity(severity string) string {
 	switch strings.ToUpper(severity) {
-	case "CRITICAL", "HIGH":
+	case "CRITICAL":
+		return findings.SeverityCritical
+	case "HIGH":
 		return findings.SeverityHigh
 	case "MEDIUM":
 		return findings.SeverityMedium
-	case "LOW", "UNKNOWN":
+	case "LOW":
 		return findings.SeverityLow
+	case "UNKNOWN":
+		return findings.SeverityInfo
 	default:
 		return findings.SeverityMedium
 	}
@@ -24,6 +24,25 @@ type Scanner interface {
 	Type() ScannerType
 }
 
+// TechnologyScanner is implemented by scanners that only understand some
+// technologies. The manager skips them when none are present in the repo.
+type TechnologyScanner interface {
+	// Technologies returns the inventory technologies the scanner can analyze
+	Technologies() []string
+
+	// UseTechnologies limits the next run to the technologies present in the repo
+	UseTechnologies(present []string)
+}
+
+// PathScanner is implemented by scanners that can leave ignored paths out of
+// a run. Findings in ignored paths are filtered out either way; skipping them
+// saves the scanner the work.
+type PathScanner interface {
+	// SkipPaths excludes directories (with a trailing "/") and files,
+	// relative to the repository root, from the next run
+	SkipPaths(paths []string)
+}
+
 // ScannerType represents the type of scanner
 type ScannerType string
 
@@ -0,0 +1,325 @@
+// Package schema implements the subset of JSON Schema used to validate
+// LLM output and configuration files.
+//
+// Supported keywords: type, properties, required, additionalProperties (boolean
+// or schema), items, enum, minLength, maxLength, minimum, maximum, minItems and
+// pattern. Annotations such as title, description and $schema are ignored.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema is a parsed JSON Schema document (or sub-schema)
+type Schema struct {
+	Type                 typeList           `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *additional        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// ValidationError describes a single schema violation
+type ValidationError struct {
+	// Path is a JSON pointer to the offending value (e.g. "/0/severity")
+	Path    string
+	Message string
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s", path, e.Message)
+}
+
+// typeList accepts both "type": "string" and "type": ["string", "null"]
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = typeList{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("type must be a string or array of strings")
+	}
+	*t = multiple
+	return nil
+}
+
+// additional accepts both "additionalProperties": false and a schema
+type additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.Allowed = allowed
+		return nil
+	}
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	a.Allowed = true
+	a.Schema = &s
+	return nil
+}
+
+// Parse parses and compiles a JSON Schema document
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// MustParse is like Parse but panics on error; intended for embedded schemas
+func MustParse(data []byte) *Schema {
+	s, err := Parse(data)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// compile precompiles patterns throughout the schema tree
+func (s *Schema) compile() error {
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	for _, prop := range s.Properties {
+		if err := prop.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		if err := s.AdditionalProperties.Schema.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks a decoded JSON value (as produced by encoding/json into
+// interface{}) against the schema and returns every violation found
+func (s *Schema) Validate(value interface{}) []ValidationError {
+	var errs []ValidationError
+	s.validate(value, "", &errs)
+	return errs
+}
+
+// ValidateJSON decodes raw JSON and validates it
+func (s *Schema) ValidateJSON(data []byte) ([]ValidationError, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return s.Validate(value), nil
+}
+
+func (s *Schema) validate(value interface{}, path string, errs *[]ValidationError) {
+	add := func(format string, args ...interface{}) {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !matchesType(value, s.Type) {
+		add("expected %s, got %s", strings.Join(s.Type, " or "), typeName(value))
+		return
+	}
+
+	if len(s.Enum) > 0 && !inEnum(value, s.Enum) {
+		add("must be one of %s", formatEnum(s.Enum))
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := len([]rune(v))
+		if s.MinLength != nil && length < *s.MinLength {
+			if *s.MinLength == 1 {
+				add("must not be empty")
+			} else {
+				add("must be at least %d characters", *s.MinLength)
+			}
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			add("must be at most %d characters (got %d)", *s.MaxLength, length)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			add("%q does not match pattern %s", v, s.Pattern)
+		}
+
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			add("must be >= %v", *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			add("must be <= %v", *s.Maximum)
+		}
+
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			add("must contain at least %d item(s)", *s.MinItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(item, path+"/"+strconv.Itoa(i), errs)
+			}
+		}
+
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, ValidationError{Path: path + "/" + name, Message: "is required"})
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := path + "/" + escapePointer(key)
+			if prop, ok := s.Properties[key]; ok {
+				prop.validate(v[key], childPath, errs)
+				continue
+			}
+			if s.AdditionalProperties == nil {
+				continue
+			}
+			if !s.AdditionalProperties.Allowed {
+				*errs = append(*errs, ValidationError{Path: childPath, Message: "unknown property"})
+			} else if s.AdditionalProperties.Schema != nil {
+				s.AdditionalProperties.Schema.validate(v[key], childPath, errs)
+			}
+		}
+	}
+}
+
+// PropertyNames returns the sorted property names declared by an object schema
+func (s *Schema) PropertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func matchesType(value interface{}, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "integer":
+			if f, ok := value.(float64); ok && f == float64(int64(f)) {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "null":
+			if value == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	parts := make([]string, len(enum))
+	for i, v := range enum {
+		parts[i] = fmt.Sprintf("%v", v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escapePointer escapes a key for use in a JSON pointer (RFC 6901)
+func escapePointer(key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	return strings.ReplaceAll(key, "/", "~1")
+}
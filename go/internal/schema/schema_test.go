@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+  "type": "array",
+  "minItems": 1,
+  "items": {
+    "type": "object",
+    "required": ["title", "severity"],
+    "additionalProperties": false,
+    "properties": {
+      "title": {"type": "string", "minLength": 1, "maxLength": 10},
+      "severity": {"type": "string", "enum": ["high", "low"]},
+      "path": {"type": "string", "pattern": "^[^/]"},
+      "line": {"type": "integer", "minimum": 1},
+      "tags": {"type": ["array", "null"], "items": {"type": "string"}}
+    }
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	s, err := Parse([]byte(testSchema))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		wantPaths []string
+		wantMsg   string
+	}{
+		{
+			name:  "valid document",
+			input: `[{"title": "ok", "severity": "high", "path": "a/b", "line": 3, "tags": ["x"]}]`,
+		},
+		{
+			name:  "null allowed by type list",
+			input: `[{"title": "ok", "severity": "low", "tags": null}]`,
+		},
+		{
+			name:      "empty array",
+			input:     `[]`,
+			wantPaths: []string{""},
+			wantMsg:   "at least 1 item",
+		},
+		{
+			name:      "wrong root type",
+			input:     `{"title": "ok"}`,
+			wantPaths: []string{""},
+			wantMsg:   "expected array, got object",
+		},
+		{
+			name:      "missing required",
+			input:     `[{"title": "ok"}]`,
+			wantPaths: []string{"/0/severity"},
+			wantMsg:   "is required",
+		},
+		{
+			name:      "enum violation",
+			input:     `[{"title": "ok", "severity": "critical"}]`,
+			wantPaths: []string{"/0/severity"},
+			wantMsg:   "must be one of high, low",
+		},
+		{
+			name:      "max length",
+			input:     `[{"title": "this title is too long", "severity": "high"}]`,
+			wantPaths: []string{"/0/title"},
+			wantMsg:   "at most 10",
+		},
+		{
+			name:      "empty string",
+			input:     `[{"title": "", "severity": "high"}]`,
+			wantPaths: []string{"/0/title"},
+			wantMsg:   "must not be empty",
+		},
+		{
+			name:      "pattern",
+			input:     `[{"title": "ok", "severity": "high", "path": "/etc/passwd"}]`,
+			wantPaths: []string{"/0/path"},
+			wantMsg:   "does not match pattern",
+		},
+		{
+			name:      "integer and minimum",
+			input:     `[{"title": "ok", "severity": "high", "line": 0}, {"title": "ok", "severity": "high", "line": 1.5}]`,
+			wantPaths: []string{"/0/line", "/1/line"},
+		},
+		{
+			name:      "unknown property",
+			input:     `[{"title": "ok", "severity": "high", "colour": "red"}]`,
+			wantPaths: []string{"/0/colour"},
+			wantMsg:   "unknown property",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := s.ValidateJSON([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("ValidateJSON failed: %v", err)
+			}
+
+			if len(errs) != len(tt.wantPaths) {
+				t.Fatalf("expected %d error(s), got %d: %v", len(tt.wantPaths), len(errs), errs)
+			}
+
+			for i, e := range errs {
+				if e.Path != tt.wantPaths[i] {
+					t.Errorf("error %d: expected path %q, got %q", i, tt.wantPaths[i], e.Path)
+				}
+				if tt.wantMsg != "" && !strings.Contains(e.Message, tt.wantMsg) {
+					t.Errorf("error %d: expected message containing %q, got %q", i, tt.wantMsg, e.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestAdditionalPropertiesSchema(t *testing.T) {
+	s := MustParse([]byte(`{"type": "object", "additionalProperties": {"type": "integer"}}`))
+
+	errs := s.Validate(map[string]interface{}{"a": float64(1), "b": "two"})
+	if len(errs) != 1 || errs[0].Path != "/b" {
+		t.Errorf("expected one error at /b, got %v", errs)
+	}
+}
+
+func TestParseInvalidPattern(t *testing.T) {
+	if _, err := Parse([]byte(`{"type": "string", "pattern": "("}`)); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestValidationErrorString(t *testing.T) {
+	e := ValidationError{Path: "/0/title", Message: "is required"}
+	if e.Error() != "/0/title: is required" {
+		t.Errorf("unexpected error string %q", e.Error())
+	}
+
+	root := ValidationError{Message: "expected array, got object"}
+	if root.Error() != "/: expected array, got object" {
+		t.Errorf("unexpected root error string %q", root.Error())
+	}
+}
@@ -0,0 +1,198 @@
+// Package setup renders the commented configuration files and scheduled
+// workflow written by `autoengineer init`.
+package setup
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+)
+
+// Paths of the generated files
+const (
+	ConfigPath       = ".github/autoengineer.yaml"
+	IgnorePath       = ".github/autoengineer-ignore.yaml"
+	InstructionsPath = ".github/copilot-instructions.md"
+	WorkflowPath     = ".github/workflows/autoengineer.yml"
+)
+
+// DefaultLabel is the issue label used when no label is configured
+const DefaultLabel = "autoengineer"
+
+// DefaultSchedule runs the workflow every Monday at 06:00 UTC
+const DefaultSchedule = "0 6 * * 1"
+
+//go:embed templates/*.tmpl
+var embedded embed.FS
+
+// templates are parsed with [[ ]] delimiters so the workflow's ${{ }}
+// expressions need no escaping
+var templates = template.Must(template.New("").Delims("[[", "]]").Funcs(template.FuncMap{
+	"title": inventory.Title,
+}).ParseFS(embedded, "templates/*.tmpl"))
+
+// Scanner is a local scanner and whether it was found on this machine
+type Scanner struct {
+	Name      string
+	Installed bool
+}
+
+// Options are the answers used to render the files
+type Options struct {
+	// Technologies are the detected technologies, in inventory display order
+	Technologies []string
+
+	// Scanners are the known local scanners
+	Scanners []Scanner
+
+	// Backend is the LLM backend: copilot or openai
+	Backend string
+
+	// BaseURL and Model configure the openai backend
+	BaseURL string
+	Model   string
+
+	// Label is the issue label; DefaultLabel needs no configuration
+	Label string
+
+	// DisabledScopes are written to the ignore file
+	DisabledScopes []string
+
+	// IgnorePaths are written to the ignore file
+	IgnorePaths []string
+
+	// Workflow adds the scheduled GitHub Actions workflow
+	Workflow bool
+
+	// Schedule is the workflow's cron expression
+	Schedule string
+
+	// CreateIssues makes the workflow's profile create issues rather than only report
+	CreateIssues bool
+
+	// MinSeverity is the lowest severity the workflow creates issues for
+	MinSeverity string
+}
+
+// CustomLabel reports whether the label differs from the default
+func (o Options) CustomLabel() bool {
+	return o.Label != "" && o.Label != DefaultLabel
+}
+
+// File is a generated file, with a path relative to the repository root
+type File struct {
+	Path    string
+	Content string
+}
+
+// Render returns the files for the options: the config, ignore config and
+// instructions file, plus the workflow when requested
+func Render(opts Options) ([]File, error) {
+	if opts.Schedule == "" {
+		opts.Schedule = DefaultSchedule
+	}
+	if opts.MinSeverity == "" {
+		opts.MinSeverity = findings.SeverityInfo
+	}
+
+	outputs := []struct {
+		path, template string
+	}{
+		{ConfigPath, "autoengineer.yaml.tmpl"},
+		{IgnorePath, "autoengineer-ignore.yaml.tmpl"},
+		{InstructionsPath, "copilot-instructions.md.tmpl"},
+	}
+	if opts.Workflow {
+		outputs = append(outputs, struct{ path, template string }{WorkflowPath, "workflow.yml.tmpl"})
+	}
+
+	files := make([]File, 0, len(outputs))
+	for _, out := range outputs {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, out.template, opts); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", out.path, err)
+		}
+		files = append(files, File{Path: out.path, Content: buf.String()})
+	}
+	return files, nil
+}
+
+// Result reports what Write did with a file
+type Result struct {
+	Path string
+
+	// Skipped is set when the file already existed and was kept
+	Skipped bool
+}
+
+// Write writes files under root, keeping existing files unless force is set
+func Write(root string, files []File, force bool) ([]Result, error) {
+	results := make([]Result, 0, len(files))
+	for _, f := range files {
+		path := filepath.Join(root, filepath.FromSlash(f.Path))
+		if _, err := os.Stat(path); err == nil && !force {
+			results = append(results, Result{Path: f.Path, Skipped: true})
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return results, fmt.Errorf("failed to create directory for %s: %w", f.Path, err)
+		}
+		if err := os.WriteFile(path, []byte(f.Content), 0644); err != nil {
+			return results, fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+		results = append(results, Result{Path: f.Path})
+	}
+	return results, nil
+}
+
+// ignoreCandidates map top-level directories that rarely hold real
+// configuration to the ignore pattern suggested for them
+var ignoreCandidates = map[string]string{
+	"examples": "examples/*",
+	"example":  "example/*",
+	"samples":  "samples/*",
+	"vendor":   "vendor/*",
+	"test":     "test/fixtures/*",
+}
+
+// SuggestIgnorePaths suggests ignore patterns for example, vendored and
+// test fixture directories present in the repository
+func SuggestIgnorePaths(files []string) []string {
+	seen := make(map[string]bool)
+	var patterns []string
+	add := func(pattern string) {
+		if !seen[pattern] {
+			seen[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	for _, file := range files {
+		parts := strings.Split(strings.TrimPrefix(file, "./"), "/")
+		if len(parts) < 2 {
+			continue
+		}
+		if pattern, ok := ignoreCandidates[parts[0]]; ok {
+			if parts[0] != "test" || parts[1] == "fixtures" {
+				add(pattern)
+			}
+		}
+		for _, dir := range parts[:len(parts)-1] {
+			if dir == "testdata" {
+				add("**/testdata/**")
+			}
+		}
+	}
+
+	sort.Strings(patterns)
+	return patterns
+}
@@ -0,0 +1,197 @@
+package setup
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/inventory"
+	"gopkg.in/yaml.v3"
+)
+
+// decodeStrict parses YAML, rejecting fields the target doesn't have. A file
+// of only comments decodes to the zero value.
+func decodeStrict(t *testing.T, content string, out interface{}) {
+	t.Helper()
+	dec := yaml.NewDecoder(bytes.NewReader([]byte(content)))
+	dec.KnownFields(true)
+	if err := dec.Decode(out); err != nil && err != io.EOF {
+		t.Fatalf("generated YAML does not parse: %v\n%s", err, content)
+	}
+}
+
+func renderFiles(t *testing.T, opts Options) map[string]string {
+	t.Helper()
+	files, err := Render(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byPath := make(map[string]string)
+	for _, f := range files {
+		byPath[f.Path] = f.Content
+	}
+	return byPath
+}
+
+func TestRenderDefaults(t *testing.T) {
+	files := renderFiles(t, Options{Backend: config.LLMBackendCopilot})
+
+	if _, ok := files[WorkflowPath]; ok {
+		t.Error("workflow should only be rendered when requested")
+	}
+	for _, path := range []string{ConfigPath, IgnorePath, InstructionsPath} {
+		if files[path] == "" {
+			t.Errorf("expected %s to be rendered", path)
+		}
+	}
+
+	var cfg config.FullConfig
+	decodeStrict(t, files[ConfigPath], &cfg)
+	if cfg.LLM != nil {
+		t.Errorf("copilot backend should leave the llm section commented out, got %+v", cfg.LLM)
+	}
+
+	var ignore config.IgnoreConfig
+	decodeStrict(t, files[IgnorePath], &ignore)
+	if len(ignore.IgnorePaths) != 0 || len(ignore.DisabledScopes) != 0 || len(ignore.Accepted) != 0 {
+		t.Errorf("default ignore config should be all comments, got %+v", ignore)
+	}
+}
+
+func TestRenderAnswers(t *testing.T) {
+	files := renderFiles(t, Options{
+		Technologies:   []string{inventory.Terraform, inventory.GitHubActions},
+		Scanners:       []Scanner{{Name: "checkov", Installed: true}, {Name: "trivy"}},
+		Backend:        config.LLMBackendOpenAI,
+		BaseURL:        "http://localhost:11434/v1",
+		Model:          "llama3.1",
+		Label:          "devops",
+		DisabledScopes: []string{"pipeline"},
+		IgnorePaths:    []string{"examples/*", "**/testdata/**"},
+		Workflow:       true,
+		CreateIssues:   true,
+		MinSeverity:    "high",
+	})
+
+	var cfg config.FullConfig
+	decodeStrict(t, files[ConfigPath], &cfg)
+	if cfg.LLM == nil || cfg.LLM.Backend != config.LLMBackendOpenAI || cfg.LLM.BaseURL != "http://localhost:11434/v1" || cfg.LLM.Model != "llama3.1" {
+		t.Errorf("unexpected llm config: %+v", cfg.LLM)
+	}
+	if err := cfg.LLM.Validate(); err != nil {
+		t.Errorf("generated llm config is invalid: %v", err)
+	}
+	if !strings.Contains(files[ConfigPath], "# Detected technologies: Terraform, GitHub Actions") {
+		t.Errorf("config should list detected technologies:\n%s", files[ConfigPath])
+	}
+	if !strings.Contains(files[ConfigPath], "# trivy is not installed") || strings.Contains(files[ConfigPath], "# checkov is not installed") {
+		t.Errorf("config should only note missing scanners:\n%s", files[ConfigPath])
+	}
+	if v, _ := cfg.Defaults.Value("label"); v != "devops" {
+		t.Errorf("custom label should be a default setting, got %q", v)
+	}
+	scheduled := cfg.Profiles["scheduled"]
+	if v, _ := scheduled.Value("create_issues"); v != "true" {
+		t.Errorf("scheduled profile should create issues, got %v", scheduled)
+	}
+	if v, _ := scheduled.Value("min_severity"); v != "high" {
+		t.Errorf("scheduled profile should use the min severity, got %v", scheduled)
+	}
+
+	var ignore config.IgnoreConfig
+	decodeStrict(t, files[IgnorePath], &ignore)
+	if strings.Join(ignore.IgnorePaths, ",") != "examples/*,**/testdata/**" {
+		t.Errorf("unexpected ignore paths: %v", ignore.IgnorePaths)
+	}
+	if !ignore.IsScopeDisabled("pipeline") {
+		t.Error("pipeline scope should be disabled")
+	}
+
+	workflow := files[WorkflowPath]
+	var parsed map[string]interface{}
+	decodeStrict(t, workflow, &parsed)
+	for _, want := range []string{
+		`cron: "0 6 * * 1"`,
+		"autoengineer scan --profile scheduled",
+		"OPENAI_API_KEY: ${{ secrets.OPENAI_API_KEY }}",
+	} {
+		if !strings.Contains(workflow, want) {
+			t.Errorf("workflow missing %q:\n%s", want, workflow)
+		}
+	}
+	if strings.Contains(workflow, "@github/copilot") {
+		t.Error("openai backend should not install the copilot CLI")
+	}
+}
+
+func TestRenderReportOnlyWorkflow(t *testing.T) {
+	files := renderFiles(t, Options{Backend: config.LLMBackendCopilot, Label: DefaultLabel, Workflow: true, Schedule: "0 3 * * *"})
+	workflow := files[WorkflowPath]
+
+	for _, want := range []string{`cron: "0 3 * * *"`, "autoengineer scan --profile scheduled", "npm install -g @github/copilot"} {
+		if !strings.Contains(workflow, want) {
+			t.Errorf("workflow missing %q:\n%s", want, workflow)
+		}
+	}
+
+	var cfg config.FullConfig
+	decodeStrict(t, files[ConfigPath], &cfg)
+	if len(cfg.Defaults) != 0 {
+		t.Errorf("the default label needs no settings, got %v", cfg.Defaults)
+	}
+	if v, _ := cfg.Profiles["scheduled"].Value("scan_only"); v != "true" {
+		t.Errorf("scheduled profile should only save findings, got %v", cfg.Profiles["scheduled"])
+	}
+}
+
+func TestWriteKeepsExistingFiles(t *testing.T) {
+	root := t.TempDir()
+	existing := filepath.Join(root, ".github", "autoengineer.yaml")
+	if err := os.MkdirAll(filepath.Dir(existing), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("scanners: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []File{{Path: ConfigPath, Content: "new\n"}, {Path: WorkflowPath, Content: "workflow\n"}}
+	results, err := Write(root, files, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Skipped || results[1].Skipped {
+		t.Errorf("expected only the existing file to be skipped, got %+v", results)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "scanners: {}\n" {
+		t.Error("existing file should be kept without force")
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, ".github", "workflows", "autoengineer.yml")); string(data) != "workflow\n" {
+		t.Error("workflow should be written with its directory")
+	}
+
+	if _, err := Write(root, files, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "new\n" {
+		t.Error("force should overwrite existing files")
+	}
+}
+
+func TestSuggestIgnorePaths(t *testing.T) {
+	files := []string{
+		"main.tf",
+		"examples/basic/main.tf",
+		"test/fixtures/bad.tf",
+		"test/unit_test.go",
+		"modules/vpc/testdata/plan.json",
+		"vendor/github.com/x/y.go",
+	}
+	got := strings.Join(SuggestIgnorePaths(files), ",")
+	if want := "**/testdata/**,examples/*,test/fixtures/*,vendor/*"; got != want {
+		t.Errorf("SuggestIgnorePaths() = %s, want %s", got, want)
+	}
+}
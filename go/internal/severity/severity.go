@@ -0,0 +1,31 @@
+// Package severity defines the severity levels shared by findings and the
+// configuration, so both validate against the same list.
+package severity
+
+// Severity levels
+const (
+	Critical = "critical"
+	High     = "high"
+	Medium   = "medium"
+	Low      = "low"
+	Info     = "info"
+)
+
+// Levels lists the severity levels from most to least severe
+var Levels = []string{Critical, High, Medium, Low, Info}
+
+// Rank returns the position of a severity in Levels (lower is more
+// severe), or len(Levels) for an unknown severity
+func Rank(s string) int {
+	for i, level := range Levels {
+		if s == level {
+			return i
+		}
+	}
+	return len(Levels)
+}
+
+// Valid reports whether s is a severity level
+func Valid(s string) bool {
+	return Rank(s) < len(Levels)
+}
@@ -0,0 +1,17 @@
+package severity
+
+import "testing"
+
+func TestRank(t *testing.T) {
+	for i, level := range Levels {
+		if got := Rank(level); got != i {
+			t.Errorf("Rank(%q) = %d, want %d", level, got, i)
+		}
+		if !Valid(level) {
+			t.Errorf("Valid(%q) = false", level)
+		}
+	}
+	if Rank("urgent") != len(Levels) || Valid("urgent") || Valid("") {
+		t.Error("unknown severities should rank after info and be invalid")
+	}
+}
@@ -0,0 +1,150 @@
+// Package shard partitions a repository's files so each analysis scope can be
+// run once per part of a large monorepo instead of once for the whole tree.
+package shard
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Sharding modes
+const (
+	ModeNone    = "none"
+	ModeDir     = "dir"
+	ModeProject = "project"
+)
+
+// Modes lists the valid sharding modes
+var Modes = []string{ModeNone, ModeDir, ModeProject}
+
+// RootDir is the directory of the shard holding files outside any other shard
+const RootDir = "."
+
+// projectMarkers are file names that make their directory a project root
+var projectMarkers = map[string]bool{
+	"autoengineer.yaml":  true,
+	"autoengineer.yml":   true,
+	"main.tf":            true,
+	"terragrunt.hcl":     true,
+	"Chart.yaml":         true,
+	"kustomization.yaml": true,
+	"kustomization.yml":  true,
+	"Dockerfile":         true,
+	"docker-compose.yml": true,
+	"compose.yaml":       true,
+	"ansible.cfg":        true,
+	"serverless.yml":     true,
+	"go.mod":             true,
+	"package.json":       true,
+	"pyproject.toml":     true,
+	"requirements.txt":   true,
+	"Cargo.toml":         true,
+	"pom.xml":            true,
+	"build.gradle":       true,
+}
+
+// Shard is a directory of the repository and the files analyzed with it
+type Shard struct {
+	// Dir is the shard's directory relative to the root, or "." for the root shard
+	Dir string
+
+	// Files are the shard's files, relative to the repository root
+	Files []string
+}
+
+// Name returns the shard's display name
+func (s Shard) Name() string {
+	if s.Dir == RootDir {
+		return "(root)"
+	}
+	return s.Dir
+}
+
+// ValidateMode checks that mode is a known sharding mode
+func ValidateMode(mode string) error {
+	for _, m := range Modes {
+		if m == mode {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid shard mode: %s (must be %s)", mode, strings.Join(Modes, "|"))
+}
+
+// Partition splits files (slash-separated, relative to the root) into shards,
+// sorted by directory with the root shard first. ModeNone returns nil, meaning
+// the repository is analyzed as a whole.
+func Partition(files []string, mode string) ([]Shard, error) {
+	if err := ValidateMode(mode); err != nil {
+		return nil, err
+	}
+
+	var dirOf func(file string) string
+	switch mode {
+	case ModeNone:
+		return nil, nil
+	case ModeDir:
+		dirOf = topLevelDir
+	case ModeProject:
+		roots := ProjectRoots(files)
+		dirOf = func(file string) string {
+			return enclosingRoot(file, roots)
+		}
+	}
+
+	byDir := make(map[string][]string)
+	for _, file := range files {
+		dir := dirOf(file)
+		byDir[dir] = append(byDir[dir], file)
+	}
+
+	shards := make([]Shard, 0, len(byDir))
+	for dir, dirFiles := range byDir {
+		shards = append(shards, Shard{Dir: dir, Files: dirFiles})
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		if shards[i].Dir == RootDir || shards[j].Dir == RootDir {
+			return shards[i].Dir == RootDir && shards[j].Dir != RootDir
+		}
+		return shards[i].Dir < shards[j].Dir
+	})
+	return shards, nil
+}
+
+// ProjectRoots returns the directories containing a project marker file such
+// as a nested autoengineer.yaml, main.tf, Chart.yaml, Dockerfile or go.mod,
+// sorted. The repository root is never reported as a project root.
+func ProjectRoots(files []string) []string {
+	seen := make(map[string]bool)
+	var roots []string
+	for _, file := range files {
+		dir := path.Dir(file)
+		if dir == RootDir || seen[dir] || !projectMarkers[path.Base(file)] {
+			continue
+		}
+		seen[dir] = true
+		roots = append(roots, dir)
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+// topLevelDir returns the first path segment of a file, or "." for files at the root
+func topLevelDir(file string) string {
+	if i := strings.Index(file, "/"); i >= 0 {
+		return file[:i]
+	}
+	return RootDir
+}
+
+// enclosingRoot returns the deepest project root containing file, or "."
+func enclosingRoot(file string, roots []string) string {
+	best := RootDir
+	for _, root := range roots {
+		if strings.HasPrefix(file, root+"/") && len(root) > len(best) {
+			best = root
+		}
+	}
+	return best
+}
@@ -0,0 +1,71 @@
+package shard
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var testFiles = []string{
+	"README.md",
+	".github/workflows/ci.yml",
+	"services/api/Dockerfile",
+	"services/api/main.go",
+	"services/api/deploy/Chart.yaml",
+	"services/api/deploy/values.yaml",
+	"services/web/package.json",
+	"services/web/src/index.js",
+	"terraform/main.tf",
+	"terraform/modules/vpc/main.tf",
+	"terraform/modules/vpc/outputs.tf",
+	"docs/guide.md",
+}
+
+// describe renders shards as "dir=count" pairs for comparison
+func describe(shards []Shard) string {
+	parts := make([]string, len(shards))
+	for i, s := range shards {
+		parts[i] = s.Dir + "=" + strconv.Itoa(len(s.Files))
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestPartitionDir(t *testing.T) {
+	shards, err := Partition(testFiles, ModeDir)
+	if err != nil {
+		t.Fatalf("Partition() error: %v", err)
+	}
+
+	if got := describe(shards); got != ".=1,.github=1,docs=1,services=6,terraform=3" {
+		t.Errorf("Partition(dir) = %s", got)
+	}
+	if shards[0].Name() != "(root)" {
+		t.Errorf("root shard Name() = %q", shards[0].Name())
+	}
+}
+
+func TestPartitionProject(t *testing.T) {
+	shards, err := Partition(testFiles, ModeProject)
+	if err != nil {
+		t.Fatalf("Partition() error: %v", err)
+	}
+
+	want := ".=3,services/api=2,services/api/deploy=2,services/web=2,terraform=1,terraform/modules/vpc=2"
+	if got := describe(shards); got != want {
+		t.Errorf("Partition(project) = %s, want %s", got, want)
+	}
+}
+
+func TestPartitionNone(t *testing.T) {
+	shards, err := Partition(testFiles, ModeNone)
+	if err != nil {
+		t.Fatalf("Partition() error: %v", err)
+	}
+	if shards != nil {
+		t.Errorf("Partition(none) = %v, want nil", shards)
+	}
+
+	if _, err := Partition(testFiles, "files"); err == nil {
+		t.Error("expected error for an unknown mode")
+	}
+}
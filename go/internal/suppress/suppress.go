@@ -0,0 +1,186 @@
+// Package suppress honours inline "autoengineer:ignore" comments, which
+// suppress a finding next to the code it is about, e.g.
+//
+//	# autoengineer:ignore CKV_AWS_24 reason=bastion is reachable from the VPN only
+package suppress
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+// Marker starts an inline suppression in a comment
+const Marker = "autoengineer:ignore"
+
+// Annotation is an inline suppression comment
+type Annotation struct {
+	File string
+	Line int
+
+	// Target is the scanner rule ID or the fingerprint of the suppressed finding
+	Target string
+	Reason string
+}
+
+// Suppressed records a finding suppressed by an annotation
+type Suppressed struct {
+	Finding    findings.Finding
+	Annotation Annotation
+}
+
+// Suppressor matches findings against the annotations in files under Root
+type Suppressor struct {
+	Root string
+
+	cache map[string][]string
+}
+
+// New creates a suppressor reading files under root
+func New(root string) *Suppressor {
+	return &Suppressor{Root: root, cache: make(map[string][]string)}
+}
+
+// Apply returns the findings no annotation suppresses, and those suppressed.
+// An annotation suppresses a finding whose rule or fingerprint it names when
+// it sits within one of the finding's code snippets, or in the comment lines
+// directly above it.
+func (s *Suppressor) Apply(all []findings.Finding) (kept []findings.Finding, suppressed []Suppressed) {
+	kept = make([]findings.Finding, 0, len(all))
+	for _, f := range all {
+		if a, ok := s.match(f); ok {
+			suppressed = append(suppressed, Suppressed{Finding: f, Annotation: a})
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept, suppressed
+}
+
+// match returns the annotation suppressing a finding, if any
+func (s *Suppressor) match(f findings.Finding) (Annotation, bool) {
+	fingerprint := findings.Fingerprint(f)
+	for _, snippet := range f.CodeSnippets {
+		prefixes := commentPrefixes(snippet.File)
+		if snippet.StartLine <= 0 || prefixes == nil {
+			continue
+		}
+		lines := s.readLines(snippet.File)
+		if len(lines) == 0 {
+			continue
+		}
+
+		end := snippet.EndLine
+		if end < snippet.StartLine {
+			end = snippet.StartLine
+		}
+		if end > len(lines) {
+			end = len(lines)
+		}
+		start := snippet.StartLine
+		for start > 1 && isComment(lines[start-2], prefixes) {
+			start--
+		}
+
+		for n := start; n <= end; n++ {
+			target, reason, ok := Parse(lines[n-1], prefixes)
+			if !ok {
+				continue
+			}
+			if strings.EqualFold(target, f.Rule) || strings.EqualFold(target, fingerprint) {
+				return Annotation{File: snippet.File, Line: n, Target: target, Reason: reason}, true
+			}
+		}
+	}
+	return Annotation{}, false
+}
+
+// Parse returns the target and reason of an annotation on a line, which
+// must follow one of the comment prefixes
+func Parse(line string, prefixes []string) (target, reason string, ok bool) {
+	i := strings.Index(line, Marker)
+	if i < 0 {
+		return "", "", false
+	}
+	before := strings.TrimRight(line[:i], " \t")
+	commented := false
+	for _, prefix := range prefixes {
+		if strings.HasSuffix(before, prefix) {
+			commented = true
+			break
+		}
+	}
+	if !commented {
+		return "", "", false
+	}
+
+	rest := strings.TrimSpace(line[i+len(Marker):])
+	if strings.HasPrefix(rest, "reason=") {
+		return "", "", false
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return "", "", false
+	}
+	target = fields[0]
+	if j := strings.Index(rest, "reason="); j >= 0 {
+		reason = strings.TrimSpace(rest[j+len("reason="):])
+		reason = strings.Trim(strings.TrimSuffix(reason, "*/"), ` "`)
+	}
+	return target, reason, true
+}
+
+// commentPrefixes returns the line comment prefixes of the file types that
+// take annotations: HCL, YAML and Dockerfiles
+func commentPrefixes(file string) []string {
+	base := strings.ToLower(path.Base(filepath.ToSlash(file)))
+	switch {
+	case strings.HasSuffix(base, ".tf"), strings.HasSuffix(base, ".tfvars"), strings.HasSuffix(base, ".hcl"):
+		return []string{"#", "//", "/*"}
+	case strings.HasSuffix(base, ".yaml"), strings.HasSuffix(base, ".yml"):
+		return []string{"#"}
+	case base == "dockerfile", strings.HasPrefix(base, "dockerfile."), strings.HasSuffix(base, ".dockerfile"):
+		return []string{"#"}
+	}
+	return nil
+}
+
+// isComment reports whether a line holds only a comment
+func isComment(line string, prefixes []string) bool {
+	line = strings.TrimSpace(line)
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readLines returns the lines of a file, caching reads across findings
+func (s *Suppressor) readLines(file string) []string {
+	if lines, ok := s.cache[file]; ok {
+		return lines
+	}
+	s.cache[file] = nil
+
+	rel := config.NormalizePath(file)
+	handle, err := os.Open(filepath.Join(s.Root, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil
+	}
+	defer handle.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(handle)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	s.cache[file] = lines
+	return lines
+}
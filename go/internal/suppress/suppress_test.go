@@ -0,0 +1,126 @@
+package suppress
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+const securityGroupTF = `resource "aws_security_group" "web" {
+  name = "web"
+
+  # Public HTTPS endpoint
+  # autoengineer:ignore CKV_AWS_260 reason="served through CloudFront"
+  ingress {
+    from_port   = 443
+    to_port     = 443
+    cidr_blocks = ["0.0.0.0/0"] // autoengineer:ignore AVD-AWS-0107 reason=public by design
+  }
+}
+`
+
+const deploymentYAML = `apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          securityContext:
+            privileged: true
+`
+
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"modules/web/sg.tf":     securityGroupTF,
+		"k8s/deploy.yaml":       deploymentYAML,
+		"docs/README.md":        "# autoengineer:ignore CKV_AWS_260 reason=not an annotated file type\n",
+		"docker/app/Dockerfile": "FROM alpine\n# autoengineer:ignore DS002 reason=needs root for ping\nUSER root\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestApply(t *testing.T) {
+	root := setupRepo(t)
+
+	snippet := func(file string, start, end int) []findings.CodeSnippet {
+		return []findings.CodeSnippet{{File: file, StartLine: start, EndLine: end}}
+	}
+
+	// LLM findings are suppressed by fingerprint
+	llm := findings.Finding{Title: "Privileged container", Category: findings.CategorySecurity, Files: []string{"k8s/deploy.yaml"}, CodeSnippets: snippet("k8s/deploy.yaml", 8, 10)}
+	annotated := deploymentYAML + "          # autoengineer:ignore " + findings.Fingerprint(llm) + "\n"
+	if err := os.WriteFile(filepath.Join(root, "k8s", "deploy.yaml"), []byte(annotated), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	all := []findings.Finding{
+		// Annotation in the comment block directly above the range
+		{Title: "Ingress open to the world", Rule: "CKV_AWS_260", CodeSnippets: snippet("/modules/web/sg.tf", 6, 10)},
+		// Trailing annotation within the range
+		{Title: "Public CIDR", Rule: "AVD-AWS-0107", CodeSnippets: snippet("modules/web/sg.tf", 9, 9)},
+		// Annotation naming another rule
+		{Title: "Missing description", Rule: "CKV_AWS_23", CodeSnippets: snippet("modules/web/sg.tf", 1, 11)},
+		// Annotation not directly above the range
+		{Title: "Ingress open to the world", Rule: "CKV_AWS_260", CodeSnippets: snippet("modules/web/sg.tf", 7, 8)},
+		// Not an annotated file type
+		{Title: "Docs", Rule: "CKV_AWS_260", CodeSnippets: snippet("docs/README.md", 1, 1)},
+		{Title: "Runs as root", Rule: "DS002", CodeSnippets: snippet("./docker/app/Dockerfile", 3, 3)},
+		llm,
+	}
+
+	kept, suppressed := New(root).Apply(all)
+	if len(kept) != 3 || kept[0].Rule != "CKV_AWS_23" || kept[1].Rule != "CKV_AWS_260" || kept[2].Title != "Docs" {
+		t.Errorf("unexpected kept findings %+v", kept)
+	}
+
+	want := []Annotation{
+		{File: "/modules/web/sg.tf", Line: 5, Target: "CKV_AWS_260", Reason: "served through CloudFront"},
+		{File: "modules/web/sg.tf", Line: 9, Target: "AVD-AWS-0107", Reason: "public by design"},
+		{File: "./docker/app/Dockerfile", Line: 2, Target: "DS002", Reason: "needs root for ping"},
+		{File: "k8s/deploy.yaml", Line: 10, Target: findings.Fingerprint(llm)},
+	}
+	if len(suppressed) != len(want) {
+		t.Fatalf("expected %d suppressed findings, got %+v", len(want), suppressed)
+	}
+	for i, s := range suppressed {
+		if s.Annotation != want[i] {
+			t.Errorf("annotation %d = %+v, want %+v", i, s.Annotation, want[i])
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	hcl := []string{"#", "//", "/*"}
+	tests := []struct {
+		line           string
+		target, reason string
+		ok             bool
+	}{
+		{"# autoengineer:ignore CKV_AWS_20 reason=public website", "CKV_AWS_20", "public website", true},
+		{`acl = "public-read" # autoengineer:ignore CKV_AWS_20 reason="public website"`, "CKV_AWS_20", "public website", true},
+		{"/* autoengineer:ignore 3f9a1c0d2b7e4a51 reason=reviewed */", "3f9a1c0d2b7e4a51", "reviewed", true},
+		{"#autoengineer:ignore CKV_AWS_20", "CKV_AWS_20", "", true},
+		{"# autoengineer:ignore reason=no target", "", "", false},
+		{`name = "autoengineer:ignore CKV_AWS_20"`, "", "", false},
+		{"# unrelated comment", "", "", false},
+	}
+	for _, tt := range tests {
+		target, reason, ok := Parse(tt.line, hcl)
+		if target != tt.target || reason != tt.reason || ok != tt.ok {
+			t.Errorf("Parse(%q) = %q, %q, %v; want %q, %q, %v", tt.line, target, reason, ok, tt.target, tt.reason, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Package verify grounds LLM findings in the working tree, catching
+// references to files that don't exist and code that isn't where the model
+// says it is.
+package verify
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+// DefaultMinSimilarity is the fuzzy match score a quoted snippet needs to count as found
+const DefaultMinSimilarity = 0.6
+
+// Dropped records a finding removed because it could not be grounded
+type Dropped struct {
+	Finding findings.Finding
+	Reason  string
+}
+
+// Result summarizes a verification pass
+type Result struct {
+	// Findings are the findings that survive verification (possibly corrected or flagged)
+	Findings []findings.Finding
+
+	// Dropped are findings removed in drop mode
+	Dropped []Dropped
+
+	// Flagged counts findings kept but tagged as unverified in flag mode
+	Flagged int
+
+	// MissingFiles counts file references removed because the file does not exist
+	MissingFiles int
+
+	// CorrectedSnippets counts snippets whose line numbers were corrected
+	CorrectedSnippets int
+
+	// RemovedSnippets counts snippets whose code could not be found in the file
+	RemovedSnippets int
+}
+
+// Verifier checks findings against files under Root
+type Verifier struct {
+	Root          string
+	Mode          string
+	MinSimilarity float64
+
+	cache map[string][]string
+}
+
+// New creates a verifier from the verification config
+func New(root string, cfg *config.VerificationConfig) *Verifier {
+	return &Verifier{
+		Root:          root,
+		Mode:          cfg.EffectiveMode(),
+		MinSimilarity: cfg.EffectiveMinSimilarity(DefaultMinSimilarity),
+		cache:         make(map[string][]string),
+	}
+}
+
+// Verify checks every finding and returns the grounded set
+func (v *Verifier) Verify(all []findings.Finding) Result {
+	result := Result{Findings: make([]findings.Finding, 0, len(all))}
+
+	if v.Mode == config.VerificationModeOff {
+		result.Findings = append(result.Findings, all...)
+		return result
+	}
+
+	for _, f := range all {
+		grounded, reason := v.verifyFinding(&f, &result)
+		if grounded {
+			result.Findings = append(result.Findings, f)
+			continue
+		}
+
+		if v.Mode == config.VerificationModeFlag {
+			f.AddTag(findings.TagUnverified)
+			result.Flagged++
+			result.Findings = append(result.Findings, f)
+			continue
+		}
+
+		result.Dropped = append(result.Dropped, Dropped{Finding: f, Reason: reason})
+	}
+
+	return result
+}
+
+// verifyFinding corrects the finding in place and reports whether it is grounded
+func (v *Verifier) verifyFinding(f *findings.Finding, result *Result) (bool, string) {
+	// 1. Every referenced file must exist
+	var existing []string
+	for _, file := range f.Files {
+		if v.exists(file) {
+			existing = append(existing, file)
+		} else {
+			result.MissingFiles++
+		}
+	}
+
+	if len(existing) == 0 {
+		// In flag mode keep the original references so reviewers can see what was claimed
+		if v.Mode == config.VerificationModeDrop {
+			f.Files = existing
+		}
+		return false, "none of the referenced files exist"
+	}
+	f.Files = existing
+
+	// 2. Every quoted snippet must be found in its file
+	if len(f.CodeSnippets) == 0 {
+		return true, ""
+	}
+
+	var grounded []findings.CodeSnippet
+	for _, snippet := range f.CodeSnippets {
+		lines, ok := v.readLines(snippet.File)
+		if !ok {
+			result.RemovedSnippets++
+			continue
+		}
+
+		start, end, found := locateSnippet(lines, snippet, v.MinSimilarity)
+		if !found {
+			result.RemovedSnippets++
+			continue
+		}
+
+		if start != snippet.StartLine || end != snippet.EndLine {
+			snippet.StartLine = start
+			snippet.EndLine = end
+			result.CorrectedSnippets++
+		}
+		grounded = append(grounded, snippet)
+	}
+
+	if len(grounded) == 0 {
+		return false, "quoted code was not found in the referenced files"
+	}
+	f.CodeSnippets = grounded
+
+	return true, ""
+}
+
+// exists reports whether a relative path names a regular file under the root
+func (v *Verifier) exists(path string) bool {
+	if path == "" || filepath.IsAbs(path) {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(v.Root, filepath.FromSlash(path)))
+	return err == nil && !info.IsDir()
+}
+
+// readLines returns the lines of a file, caching reads across findings
+func (v *Verifier) readLines(path string) ([]string, bool) {
+	if lines, ok := v.cache[path]; ok {
+		return lines, lines != nil
+	}
+
+	if !v.exists(path) {
+		v.cache[path] = nil
+		return nil, false
+	}
+
+	file, err := os.Open(filepath.Join(v.Root, filepath.FromSlash(path)))
+	if err != nil {
+		v.cache[path] = nil
+		return nil, false
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if scanner.Err() != nil {
+		v.cache[path] = nil
+		return nil, false
+	}
+
+	v.cache[path] = lines
+	return lines, true
+}
+
+// locateSnippet finds the 1-based line range of the snippet's code in lines.
+// The stated range is tried first; otherwise the best-matching window in the
+// file is used, preferring the one closest to the stated start on ties.
+func locateSnippet(lines []string, snippet findings.CodeSnippet, minSimilarity float64) (int, int, bool) {
+	window := len(strings.Split(strings.TrimRight(snippet.Code, "\n"), "\n"))
+	inFile := snippet.StartLine > 0 && snippet.StartLine <= len(lines)
+
+	// The stated range, with a missing or reversed end derived from the
+	// code's length and the end clamped to the file
+	end := snippet.EndLine
+	if end < snippet.StartLine {
+		end = snippet.StartLine + window - 1
+	}
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	quoted := significantLines(strings.Split(snippet.Code, "\n"))
+	if len(quoted) == 0 {
+		// Nothing to compare against - accept the stated range if it is inside the file
+		if inFile {
+			return snippet.StartLine, end, true
+		}
+		return 0, 0, false
+	}
+
+	// The stated range matches - keep it
+	if inFile && similarity(quoted, significantLines(lines[snippet.StartLine-1:end])) >= minSimilarity {
+		return snippet.StartLine, end, true
+	}
+
+	// Search the file for the best window of the same size
+	bestScore := 0.0
+	bestStart := 0
+	for i := 0; i < len(lines); i++ {
+		end := i + window
+		if end > len(lines) {
+			end = len(lines)
+		}
+		candidate := significantLines(lines[i:end])
+		if len(candidate) == 0 || normalizeLine(lines[i]) == "" {
+			continue
+		}
+
+		score := similarity(quoted, candidate)
+		if score > bestScore || (score == bestScore && score > 0 && closer(i+1, bestStart, snippet.StartLine)) {
+			bestScore = score
+			bestStart = i + 1
+		}
+	}
+
+	if bestScore < minSimilarity {
+		return 0, 0, false
+	}
+
+	bestEnd := bestStart + window - 1
+	if bestEnd > len(lines) {
+		bestEnd = len(lines)
+	}
+	return bestStart, bestEnd, true
+}
+
+// closer reports whether candidate is nearer to target than current
+func closer(candidate, current, target int) bool {
+	return abs(candidate-target) < abs(current-target)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// significantLines normalizes lines and drops blanks and elision markers
+func significantLines(lines []string) []string {
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		normalized := normalizeLine(line)
+		if normalized == "" || normalized == "..." || normalized == "# ..." || normalized == "// ..." {
+			continue
+		}
+		result = append(result, normalized)
+	}
+	return result
+}
+
+// normalizeLine collapses whitespace so indentation differences don't matter
+func normalizeLine(line string) string {
+	return strings.Join(strings.Fields(line), " ")
+}
+
+// similarity returns the Dice coefficient of the longest common subsequence of
+// two line lists, so reordered or partially elided quotes still score well
+func similarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if linesMatch(a[i-1], b[j-1]) {
+				curr[j] = prev[j-1] + 1
+			} else if prev[j] >= curr[j-1] {
+				curr[j] = prev[j]
+			} else {
+				curr[j] = curr[j-1]
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return 2 * float64(prev[len(b)]) / float64(len(a)+len(b))
+}
+
+// linesMatch compares two normalized lines, tolerating quoted lines the model truncated
+func linesMatch(quoted, actual string) bool {
+	if quoted == actual {
+		return true
+	}
+	trimmed := strings.TrimSuffix(quoted, "...")
+	return len(trimmed) >= 8 && trimmed != quoted && strings.HasPrefix(actual, strings.TrimSpace(trimmed))
+}
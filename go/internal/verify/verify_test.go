@@ -0,0 +1,194 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liam-witterick/autoengineer/go/internal/config"
+	"github.com/liam-witterick/autoengineer/go/internal/findings"
+)
+
+const securityGroupTF = `resource "aws_security_group" "web" {
+  name = "web"
+
+  ingress {
+    from_port   = 443
+    to_port     = 443
+    protocol    = "tcp"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+`
+
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "modules", "web"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "modules", "web", "sg.tf"), []byte(securityGroupTF), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestVerifyKeepsGroundedFinding(t *testing.T) {
+	root := setupRepo(t)
+	v := New(root, &config.VerificationConfig{})
+
+	result := v.Verify([]findings.Finding{{
+		Title: "Security group open to the world",
+		Files: []string{"modules/web/sg.tf"},
+		CodeSnippets: []findings.CodeSnippet{{
+			File:      "modules/web/sg.tf",
+			StartLine: 4,
+			EndLine:   9,
+			Code:      "  ingress {\n    from_port   = 443\n    to_port     = 443\n    protocol    = \"tcp\"\n    cidr_blocks = [\"0.0.0.0/0\"]\n  }",
+		}},
+	}})
+
+	if len(result.Findings) != 1 {
+		t.Fatalf("expected finding to be kept, got %d (dropped: %v)", len(result.Findings), result.Dropped)
+	}
+	if result.CorrectedSnippets != 0 {
+		t.Errorf("expected no corrections, got %d", result.CorrectedSnippets)
+	}
+}
+
+func TestVerifyCorrectsLineNumbers(t *testing.T) {
+	root := setupRepo(t)
+	v := New(root, &config.VerificationConfig{})
+
+	result := v.Verify([]findings.Finding{{
+		Title: "Open CIDR",
+		Files: []string{"modules/web/sg.tf"},
+		CodeSnippets: []findings.CodeSnippet{{
+			File:      "modules/web/sg.tf",
+			StartLine: 40,
+			EndLine:   41,
+			// Indentation differs from the file and should not matter
+			Code: "protocol = \"tcp\"\ncidr_blocks = [\"0.0.0.0/0\"]",
+		}},
+	}})
+
+	if len(result.Findings) != 1 {
+		t.Fatalf("expected finding to be kept, got dropped: %v", result.Dropped)
+	}
+	snippet := result.Findings[0].CodeSnippets[0]
+	if snippet.StartLine != 7 || snippet.EndLine != 8 {
+		t.Errorf("expected corrected range 7-8, got %d-%d", snippet.StartLine, snippet.EndLine)
+	}
+	if result.CorrectedSnippets != 1 {
+		t.Errorf("expected 1 correction, got %d", result.CorrectedSnippets)
+	}
+}
+
+func TestVerifyFillsMissingEndLine(t *testing.T) {
+	root := setupRepo(t)
+	v := New(root, &config.VerificationConfig{})
+
+	result := v.Verify([]findings.Finding{{
+		Title: "Open CIDR",
+		Files: []string{"modules/web/sg.tf"},
+		CodeSnippets: []findings.CodeSnippet{
+			{File: "modules/web/sg.tf", StartLine: 7, Code: "protocol = \"tcp\"\ncidr_blocks = [\"0.0.0.0/0\"]"},
+			// Nothing to compare, and an end before the start
+			{File: "modules/web/sg.tf", StartLine: 10, EndLine: 2, Code: "}"},
+		},
+	}})
+
+	if len(result.Findings) != 1 {
+		t.Fatalf("expected finding to be kept, got dropped: %v", result.Dropped)
+	}
+	snippets := result.Findings[0].CodeSnippets
+	if len(snippets) != 2 || snippets[0].StartLine != 7 || snippets[0].EndLine != 8 || snippets[1].StartLine != 10 || snippets[1].EndLine != 10 {
+		t.Errorf("expected ranges 7-8 and 10-10, got %+v", snippets)
+	}
+	if result.CorrectedSnippets != 2 {
+		t.Errorf("expected 2 corrections, got %d", result.CorrectedSnippets)
+	}
+}
+
+func TestVerifyDropsMissingFiles(t *testing.T) {
+	root := setupRepo(t)
+	v := New(root, &config.VerificationConfig{Mode: config.VerificationModeDrop})
+
+	result := v.Verify([]findings.Finding{
+		{Title: "Ghost file", Files: []string{"modules/db/rds.tf"}},
+		{Title: "Partly real", Files: []string{"modules/web/sg.tf", "modules/web/missing.tf"}},
+	})
+
+	if len(result.Dropped) != 1 || result.Dropped[0].Finding.Title != "Ghost file" {
+		t.Fatalf("expected ghost finding to be dropped, got %+v", result.Dropped)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("expected 1 finding kept, got %d", len(result.Findings))
+	}
+	if files := result.Findings[0].Files; len(files) != 1 || files[0] != "modules/web/sg.tf" {
+		t.Errorf("expected missing file reference to be removed, got %v", files)
+	}
+	if result.MissingFiles != 2 {
+		t.Errorf("expected 2 missing file references, got %d", result.MissingFiles)
+	}
+}
+
+func TestVerifyFlagsFabricatedCode(t *testing.T) {
+	root := setupRepo(t)
+	v := New(root, &config.VerificationConfig{Mode: config.VerificationModeFlag})
+
+	result := v.Verify([]findings.Finding{{
+		Title: "Hardcoded password",
+		Files: []string{"modules/web/sg.tf"},
+		CodeSnippets: []findings.CodeSnippet{{
+			File:      "modules/web/sg.tf",
+			StartLine: 3,
+			EndLine:   4,
+			Code:      "password = \"hunter2\"\nusername = \"admin\"",
+		}},
+	}})
+
+	if len(result.Dropped) != 0 {
+		t.Fatalf("flag mode should not drop findings, got %v", result.Dropped)
+	}
+	if len(result.Findings) != 1 || !result.Findings[0].HasTag(findings.TagUnverified) {
+		t.Errorf("expected finding to be tagged %q, got %+v", findings.TagUnverified, result.Findings)
+	}
+	if result.Flagged != 1 {
+		t.Errorf("expected 1 flagged finding, got %d", result.Flagged)
+	}
+}
+
+func TestVerifyOff(t *testing.T) {
+	v := New(t.TempDir(), &config.VerificationConfig{Mode: config.VerificationModeOff})
+
+	result := v.Verify([]findings.Finding{{Title: "Anything", Files: []string{"nope.tf"}}})
+	if len(result.Findings) != 1 || len(result.Dropped) != 0 {
+		t.Errorf("expected verification to be skipped, got %+v", result)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		min  float64
+		max  float64
+	}{
+		{"identical", []string{"a = 1", "b = 2"}, []string{"a = 1", "b = 2"}, 1, 1},
+		{"disjoint", []string{"a = 1"}, []string{"b = 2"}, 0, 0},
+		{"partial", []string{"a = 1", "b = 2", "c = 3"}, []string{"a = 1", "c = 3"}, 0.79, 0.81},
+		{"truncated line", []string{"description = \"Allow all..."}, []string{"description = \"Allow all inbound traffic\""}, 1, 1},
+		{"empty", nil, []string{"a"}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := similarity(tt.a, tt.b)
+			if got < tt.min || got > tt.max {
+				t.Errorf("similarity = %v, want between %v and %v", got, tt.min, tt.max)
+			}
+		})
+	}
+}